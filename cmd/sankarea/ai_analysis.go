@@ -35,6 +35,10 @@ func AnalyzeArticleSentiment(article *Article) (*SentimentAnalysis, error) {
 	if cfg == nil || !cfg.EnableSummarization || cfg.OpenAIAPIKey == "" {
 		return nil, fmt.Errorf("OpenAI integration not configured")
 	}
+	if aiBudgetExceeded() {
+		return nil, ErrAIBudgetExceeded
+	}
+	task := cfg.AI.Analyze
 
 	client := openai.NewClient(cfg.OpenAIAPIKey)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
@@ -62,7 +66,8 @@ Provide output in JSON format with these fields:
 	resp, err := client.CreateChatCompletion(
 		ctx,
 		openai.ChatCompletionRequest{
-			Model: "gpt-3.5-turbo",
+			Model:     task.Model,
+			MaxTokens: task.MaxTokens,
 			Messages: []openai.ChatCompletionMessage{
 				{
 					Role:    "system",
@@ -73,7 +78,7 @@ Provide output in JSON format with these fields:
 					Content: fmt.Sprintf("Analyze this article from %s:\n\n%s", article.Source, contentToAnalyze),
 				},
 			},
-			Temperature: 0.2, // Low temperature for more consistent results
+			Temperature: task.RequestTemperature(),
 			ResponseFormat: &openai.ChatCompletionResponseFormat{
 				Type: openai.ChatCompletionResponseFormatTypeJSONObject,
 			},
@@ -128,63 +133,31 @@ func min(a, b int) int {
 	return b
 }
 
-// SummarizeArticle generates a concise summary of an article
+// SummarizeArticle generates a concise summary of an article with the
+// configured summarizer, reusing a cached summary when there is one
 func SummarizeArticle(article *Article, maxLength int) (string, error) {
-	if cfg == nil || !cfg.EnableSummarization || cfg.OpenAIAPIKey == "" {
-		return "", fmt.Errorf("OpenAI integration not configured")
+	if cfg == nil || !cfg.EnableSummarization {
+		return "", fmt.Errorf("summarization not enabled")
+	}
+
+	// Reuse an earlier summary of the same article
+	if summary, ok := summaryCache.Get(article); ok {
+		return summary, nil
 	}
 
-	client := openai.NewClient(cfg.OpenAIAPIKey)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	// Prepare system prompt for article summarization
-	systemPrompt := fmt.Sprintf(`You are an AI that summarizes news articles in a neutral, factual manner.
-Create a concise summary that captures the key points of the article.
-Keep the summary under %d characters.
-Do not include your own opinions or analysis.`, maxLength)
-
-	// Extract a shorter version of the content for analysis
-	contentToAnalyze := article.Title
-	if len(article.Content) > 0 {
-		// Take the first 2500 characters or so for summarization
-		contentLen := min(len(article.Content), 2500)
-		contentToAnalyze += "\n\n" + article.Content[:contentLen]
-	}
-
-	// Create completion request
-	resp, err := client.CreateChatCompletion(
-		ctx,
-		openai.ChatCompletionRequest{
-			Model: "gpt-3.5-turbo",
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    "system",
-					Content: systemPrompt,
-				},
-				{
-					Role:    "user",
-					Content: fmt.Sprintf("Summarize this article from %s:\n\n%s", article.Source, contentToAnalyze),
-				},
-			},
-			Temperature: 0.3, // Low temperature for more consistent results
-		},
-	)
+	summary, err := activeSummarizer().Summarize(ctx, article, maxLength)
 	if err != nil {
-		return "", fmt.Errorf("OpenAI API error: %v", err)
+		return "", err
 	}
 
-	// Update API usage cost
-	updateOpenAIUsageCost(resp.Usage.TotalTokens)
-
-	// Extract summary
-	summary := resp.Choices[0].Message.Content
-	summary = strings.TrimSpace(summary)
-
 	// Ensure summary doesn't exceed max length
 	if len(summary) > maxLength {
 		summary = summary[:maxLength] + "..."
 	}
 
+	summaryCache.Put(article, summary)
 	return summary, nil
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrAIBudgetExceeded is returned when the daily OpenAI budget has been used up
+var ErrAIBudgetExceeded = errors.New("daily AI budget exceeded")
+
+// aiUsage tracks OpenAI token usage for the current day
+var aiUsage = struct {
+	sync.Mutex
+	day    string
+	tokens int
+	cost   float64
+	warned bool
+}{}
+
+// resetAIUsageIfNewDay clears the counters at the start of a new day. Caller must hold the lock.
+func resetAIUsageIfNewDay() {
+	today := time.Now().Format("2006-01-02")
+	if aiUsage.day != today {
+		aiUsage.day = today
+		aiUsage.tokens = 0
+		aiUsage.cost = 0
+		aiUsage.warned = false
+	}
+}
+
+// updateOpenAIUsageCost records tokens used by a completion and warns once when the budget runs out
+func updateOpenAIUsageCost(tokens int) {
+	aiUsage.Lock()
+	defer aiUsage.Unlock()
+
+	resetAIUsageIfNewDay()
+	IncrementCounter("api_call")
+	aiUsage.tokens += tokens
+	aiUsage.cost += float64(tokens) / 1000 * cfg.AI.CostPer1KTokens
+
+	if !aiUsage.warned && budgetExceededLocked() {
+		aiUsage.warned = true
+		Logger().Printf("WARNING: daily AI budget exceeded (%d tokens, $%.2f); AI features disabled until tomorrow",
+			aiUsage.tokens, aiUsage.cost)
+	}
+}
+
+// aiBudgetExceeded reports whether today's token or cost budget has been used up
+func aiBudgetExceeded() bool {
+	aiUsage.Lock()
+	defer aiUsage.Unlock()
+
+	resetAIUsageIfNewDay()
+	return budgetExceededLocked()
+}
+
+// budgetExceededLocked checks the counters against the configured limits. Caller must hold the lock.
+func budgetExceededLocked() bool {
+	if cfg == nil {
+		return false
+	}
+	if cfg.AI.DailyTokenBudget > 0 && aiUsage.tokens >= cfg.AI.DailyTokenBudget {
+		return true
+	}
+	if cfg.AI.DailyCostBudget > 0 && aiUsage.cost >= cfg.AI.DailyCostBudget {
+		return true
+	}
+	return false
+}
+
+// GetAIUsage returns today's token count and estimated cost
+func GetAIUsage() (int, float64) {
+	aiUsage.Lock()
+	defer aiUsage.Unlock()
+
+	resetAIUsageIfNewDay()
+	return aiUsage.tokens, aiUsage.cost
+}
@@ -0,0 +1,146 @@
+// cmd/sankarea/alert_target.go
+package main
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// alertMention returns who breaking news alerts ping: an /alert target
+// override saved in the state, otherwise breaking_news.mention
+func (b *Bot) alertMention() string {
+    stateMux.RLock()
+    override := ""
+    if state != nil {
+        override = state.AlertMention
+    }
+    stateMux.RUnlock()
+
+    if override != "" {
+        return override
+    }
+    return b.config.BreakingNews.Mention
+}
+
+// SetAlertMention saves an alert target override that survives restarts;
+// an empty mention falls back to the config
+func SetAlertMention(mention string) error {
+    return UpdateState(func(s *State) {
+        s.AlertMention = mention
+    })
+}
+
+// resolveAlertTarget turns a mentionable option into a mention, checking
+// that the role or user belongs to the guild
+func resolveAlertTarget(s *discordgo.Session, guildID, id string) (string, error) {
+    roles, err := s.GuildRoles(guildID)
+    if err != nil {
+        return "", fmt.Errorf("failed to load guild roles: %v", err)
+    }
+    for _, role := range roles {
+        if role.ID != id {
+            continue
+        }
+        if role.ID == guildID {
+            return "@everyone", nil
+        }
+        return "<@&" + role.ID + ">", nil
+    }
+
+    member, err := s.GuildMember(guildID, id)
+    if err != nil || member.User == nil {
+        return "", fmt.Errorf("no role or member %s in this server", id)
+    }
+    return "<@" + member.User.ID + ">", nil
+}
+
+// alertKeywords lists the title keywords the breaking news rules match
+func (b *Bot) alertKeywords() []string {
+    var keywords []string
+    for _, rule := range b.config.BreakingNews.Rules {
+        keywords = append(keywords, rule.Keywords...)
+    }
+    return keywords
+}
+
+// handleAlertCommand shows or changes who breaking news alerts mention
+func (b *Bot) handleAlertCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+    if i.GuildID == "" {
+        respondWithError(s, i, "This command only works in a server")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    if len(options) == 0 {
+        respondWithError(s, i, "Unknown alert subcommand")
+        return nil
+    }
+
+    var content string
+    switch options[0].Name {
+    case "target":
+        // Mentionable options carry the role or user ID as their value
+        var id string
+        for _, opt := range options[0].Options {
+            if opt.Name == "who" {
+                id, _ = opt.Value.(string)
+            }
+        }
+        mention, err := resolveAlertTarget(s, i.GuildID, id)
+        if err != nil {
+            respondWithError(s, i, err.Error())
+            return nil
+        }
+        if err := SetAlertMention(mention); err != nil {
+            respondWithError(s, i, "Failed to save the alert target")
+            return fmt.Errorf("failed to set alert target: %v", err)
+        }
+        b.logger.Info("Alert target set to %s by %s", mention, interactionUserID(i))
+        content = fmt.Sprintf("🔔 Breaking news alerts now mention %s.", mention)
+
+    case "clear":
+        if err := SetAlertMention(""); err != nil {
+            respondWithError(s, i, "Failed to clear the alert target")
+            return fmt.Errorf("failed to clear alert target: %v", err)
+        }
+        b.logger.Info("Alert target cleared by %s", interactionUserID(i))
+        content = "🔔 Alert target reset to `breaking_news.mention` from the config."
+        if b.config.BreakingNews.Mention == "" {
+            content = "🔕 Breaking news alerts no longer mention anyone."
+        }
+
+    case "show":
+        mention := b.alertMention()
+        if mention == "" {
+            mention = "nobody"
+        }
+        keywords := "none"
+        if kw := b.alertKeywords(); len(kw) > 0 {
+            keywords = strings.Join(kw, ", ")
+        }
+        status := "enabled"
+        if !b.config.BreakingNews.Enabled || b.config.BreakingNews.ChannelID == "" {
+            status = "disabled"
+        }
+        content = fmt.Sprintf("🔔 **Breaking news alerts** (%s)\nTarget: %s\nKeywords: %s", status, mention, keywords)
+
+    default:
+        respondWithError(s, i, "Unknown alert subcommand")
+        return nil
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content:         content,
+            Flags:           discordgo.MessageFlagsEphemeral,
+            AllowedMentions: &discordgo.MessageAllowedMentions{},
+        },
+    })
+}
@@ -0,0 +1,139 @@
+// cmd/sankarea/analytics.go
+package main
+
+import (
+    "fmt"
+    "sync"
+    "time"
+)
+
+// metricsRetention is how long hourly samples are kept
+const metricsRetention = 90 * 24 * time.Hour
+
+// MetricsSample is a snapshot of the cumulative state counters
+type MetricsSample struct {
+    Hour     time.Time
+    Articles int
+    Errors   int
+    APICalls int
+}
+
+// MetricsPoint is the activity within one bucket of a history range
+type MetricsPoint struct {
+    Time     time.Time `json:"time"`
+    Articles int       `json:"articles"`
+    Errors   int       `json:"errors"`
+    APICalls int       `json:"api_calls"`
+}
+
+// metricsRanges maps the dashboard's range values to a window and bucket size
+var metricsRanges = map[string]struct {
+    window time.Duration
+    bucket time.Duration
+}{
+    "24h": {24 * time.Hour, time.Hour},
+    "7d":  {7 * 24 * time.Hour, time.Hour},
+    "30d": {30 * 24 * time.Hour, 24 * time.Hour},
+    "90d": {metricsRetention, 24 * time.Hour},
+}
+
+// AnalyticsEngine records the article, error and API call counters hourly
+// in the database so dashboard trends survive restarts
+type AnalyticsEngine struct {
+    mu       sync.RWMutex
+    database *Database
+}
+
+// analytics is the process-wide analytics engine
+var analytics = &AnalyticsEngine{}
+
+// SetDatabase enables recording samples to the metrics_history table
+func (a *AnalyticsEngine) SetDatabase(db *Database) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    a.database = db
+}
+
+// db returns the sample store, or nil before SetDatabase
+func (a *AnalyticsEngine) db() *Database {
+    a.mu.RLock()
+    defer a.mu.RUnlock()
+    return a.database
+}
+
+// Record stores the current counters under this hour and drops samples
+// past the retention period
+func (a *AnalyticsEngine) Record() error {
+    db := a.db()
+    if db == nil {
+        return fmt.Errorf("analytics database not set")
+    }
+
+    state := GetState()
+    sample := &MetricsSample{
+        Hour:     time.Now().UTC().Truncate(time.Hour),
+        Articles: state.ArticleCount,
+        Errors:   state.ErrorCount,
+        APICalls: state.APICallCount,
+    }
+    if err := db.SaveMetricsSample(sample); err != nil {
+        return err
+    }
+    if _, err := db.CleanOldMetricsSamples(metricsRetention); err != nil {
+        return err
+    }
+    return nil
+}
+
+// History returns per-bucket activity for a range such as "24h" or "30d"
+func (a *AnalyticsEngine) History(rangeName string) ([]MetricsPoint, error) {
+    r, ok := metricsRanges[rangeName]
+    if !ok {
+        return nil, fmt.Errorf("unknown range %q", rangeName)
+    }
+    db := a.db()
+    if db == nil {
+        return nil, fmt.Errorf("analytics database not set")
+    }
+
+    // One extra sample before the window gives the first bucket its baseline
+    samples, err := db.GetMetricsSamples(time.Now().UTC().Add(-r.window - time.Hour))
+    if err != nil {
+        return nil, err
+    }
+    return bucketMetrics(samples, r.bucket), nil
+}
+
+// bucketMetrics turns cumulative samples into the activity between them,
+// summed per bucket. A counter that went down was reset, so its new value
+// is all activity since the reset.
+func bucketMetrics(samples []*MetricsSample, bucket time.Duration) []MetricsPoint {
+    points := make([]MetricsPoint, 0)
+    for idx := 1; idx < len(samples); idx++ {
+        prev, cur := samples[idx-1], samples[idx]
+        at := cur.Hour.Truncate(bucket)
+        if len(points) == 0 || !points[len(points)-1].Time.Equal(at) {
+            points = append(points, MetricsPoint{Time: at})
+        }
+        point := &points[len(points)-1]
+        point.Articles += counterDelta(prev.Articles, cur.Articles)
+        point.Errors += counterDelta(prev.Errors, cur.Errors)
+        point.APICalls += counterDelta(prev.APICalls, cur.APICalls)
+    }
+    return points
+}
+
+// counterDelta is how much a cumulative counter grew between two samples
+func counterDelta(prev, cur int) int {
+    if cur < prev {
+        return cur
+    }
+    return cur - prev
+}
+
+// recordAnalytics is the hourly job that samples the counters
+func (b *Bot) recordAnalytics() {
+    if err := analytics.Record(); err != nil {
+        b.logger.Error("Failed to record analytics sample: %v", err)
+    }
+}
@@ -47,7 +47,7 @@ func fetchAndPostArticles(dg *discordgo.Session, channelID string, sources []Sou
 			}
 
 			msg := fmt.Sprintf("**[%s]** *(bias: %s)*\n[%s](%s)", src.Name, src.Bias, item.Title, item.Link)
-			_, err := dg.ChannelMessageSend(channelID, msg)
+			_, err := sendTextPost(dg, channelID, msg)
 			if err != nil {
 				log.Printf("Failed to post article from %s: %v", src.Name, err)
 				continue
@@ -59,8 +59,11 @@ func fetchAndPostArticles(dg *discordgo.Session, channelID string, sources []Sou
 	}
 
 	log.Printf("Posted %d articles", postedCount)
-	state.FeedCount = postedCount
-	saveState(state)
+	if err := UpdateState(func(st *State) {
+		st.FeedCount = postedCount
+	}); err != nil {
+		log.Printf("Failed to update state: %v", err)
+	}
 }
 
 // clearArticlesSent clears the map tracking sent articles (use on restart)
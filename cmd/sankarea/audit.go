@@ -0,0 +1,359 @@
+// cmd/sankarea/audit.go
+package main
+
+import (
+    "bytes"
+    "crypto/subtle"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Audit event types, used to filter exports
+const (
+    AuditTypeCommand = "command" // an admin or moderation slash command
+    AuditTypeConfig  = "config"  // config.json saved
+    AuditTypeSources = "sources" // sources.yml saved
+    AuditTypeAdmin   = "admin"   // an action sent to the audit log channel
+)
+
+// auditTypes lists the event types in the order /audit offers them
+var auditTypes = []string{AuditTypeCommand, AuditTypeConfig, AuditTypeSources, AuditTypeAdmin}
+
+// auditedCommands are the slash commands recorded in the audit trail
+var auditedCommands = map[string]bool{
+    "audit":       true,
+    "config":      true,
+    "reload":      true,
+    "refactcheck": true,
+    "mode":        true,
+    "alert":       true,
+    "cleanup":     true,
+    "forgetuser":  true,
+    "sources":     true,
+}
+
+// Limits for audit exports
+const (
+    auditDefaultRange = 30 * 24 * time.Hour
+    auditExportLimit  = 10000
+)
+
+// AuditEvent is one recorded admin, moderation or config action
+type AuditEvent struct {
+    ID      int64     `json:"id"`
+    At      time.Time `json:"at"`
+    Actor   string    `json:"actor"` // Discord user ID, empty for the bot itself
+    Type    string    `json:"type"`
+    Action  string    `json:"action"`
+    Target  string    `json:"target,omitempty"`
+    Result  string    `json:"result,omitempty"` // ok, failed or denied for commands
+    Details string    `json:"details,omitempty"`
+}
+
+// AuditFilter selects events for an export; empty fields match everything
+type AuditFilter struct {
+    From  time.Time
+    To    time.Time
+    Actor string
+    Type  string
+    Limit int
+}
+
+// AuditExport is the JSON document an export produces
+type AuditExport struct {
+    GeneratedAt time.Time     `json:"generated_at"`
+    From        time.Time     `json:"from"`
+    To          time.Time     `json:"to"`
+    Actor       string        `json:"actor,omitempty"`
+    Type        string        `json:"type,omitempty"`
+    Truncated   bool          `json:"truncated"` // more events matched than the export limit
+    Events      []*AuditEvent `json:"events"`
+}
+
+// AuditTrail records audit events in the audit_log table
+type AuditTrail struct {
+    mu       sync.RWMutex
+    database *Database
+}
+
+// auditTrail is the process-wide audit trail
+var auditTrail = &AuditTrail{}
+
+// SetDatabase enables recording events to the audit_log table
+func (a *AuditTrail) SetDatabase(db *Database) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    a.database = db
+}
+
+// db returns the event store, or nil before SetDatabase
+func (a *AuditTrail) db() *Database {
+    a.mu.RLock()
+    defer a.mu.RUnlock()
+    return a.database
+}
+
+// Record stores an event; events before the database is set are only logged
+func (a *AuditTrail) Record(event *AuditEvent) {
+    if event.At.IsZero() {
+        event.At = time.Now().UTC()
+    }
+    db := a.db()
+    if db == nil {
+        Logger().Printf("Audit (not stored): %s %s by %s", event.Type, event.Action, event.Actor)
+        return
+    }
+    if err := db.SaveAuditEvent(event); err != nil {
+        Logger().Printf("Failed to record audit event: %v", err)
+    }
+}
+
+// Forget deletes the events recorded for an actor and returns how many there were
+func (a *AuditTrail) Forget(actor string) (int64, error) {
+    db := a.db()
+    if db == nil {
+        return 0, nil
+    }
+    return db.DeleteAuditEvents(actor)
+}
+
+// Export returns the events matching filter, oldest first
+func (a *AuditTrail) Export(filter AuditFilter) (*AuditExport, error) {
+    db := a.db()
+    if db == nil {
+        return nil, fmt.Errorf("audit database not set")
+    }
+
+    // One extra row tells whether the export was cut off
+    limit := filter.Limit
+    if limit <= 0 || limit > auditExportLimit {
+        limit = auditExportLimit
+    }
+    filter.Limit = limit + 1
+    events, err := db.GetAuditEvents(filter)
+    if err != nil {
+        return nil, err
+    }
+
+    export := &AuditExport{
+        GeneratedAt: time.Now().UTC(),
+        From:        filter.From,
+        To:          filter.To,
+        Actor:       filter.Actor,
+        Type:        filter.Type,
+        Events:      events,
+    }
+    if len(events) > limit {
+        export.Truncated = true
+        export.Events = events[:limit]
+    }
+    if export.Events == nil {
+        export.Events = []*AuditEvent{}
+    }
+    return export, nil
+}
+
+// parseAuditRange parses from and to as YYYY-MM-DD dates in UTC. to covers
+// its whole day; missing dates default to the last 30 days.
+func parseAuditRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+    end := now.UTC()
+    if to = strings.TrimSpace(to); to != "" {
+        day, err := time.Parse("2006-01-02", to)
+        if err != nil {
+            return time.Time{}, time.Time{}, fmt.Errorf("Invalid date '%s', use YYYY-MM-DD", to)
+        }
+        end = day.Add(24 * time.Hour)
+    }
+    start := end.Add(-auditDefaultRange)
+    if from = strings.TrimSpace(from); from != "" {
+        day, err := time.Parse("2006-01-02", from)
+        if err != nil {
+            return time.Time{}, time.Time{}, fmt.Errorf("Invalid date '%s', use YYYY-MM-DD", from)
+        }
+        start = day
+    }
+    if !start.Before(end) {
+        return time.Time{}, time.Time{}, fmt.Errorf("The start date must be before the end date")
+    }
+    return start, end, nil
+}
+
+// validAuditType reports whether t is empty or a known event type
+func validAuditType(t string) bool {
+    if t == "" {
+        return true
+    }
+    for _, known := range auditTypes {
+        if t == known {
+            return true
+        }
+    }
+    return false
+}
+
+// auditCommand describes a command invocation: its path, e.g. "/sources boost",
+// and its options as sorted name=value pairs
+func auditCommand(data discordgo.ApplicationCommandInteractionData) (string, string) {
+    path := "/" + data.Name
+    options := data.Options
+    for len(options) == 1 && (options[0].Type == discordgo.ApplicationCommandOptionSubCommand ||
+        options[0].Type == discordgo.ApplicationCommandOptionSubCommandGroup) {
+        path += " " + options[0].Name
+        options = options[0].Options
+    }
+
+    var args []string
+    for _, opt := range options {
+        // /sources picks its action with an option rather than a subcommand
+        if data.Name == "sources" && opt.Name == "action" {
+            path += " " + opt.StringValue()
+            continue
+        }
+        args = append(args, fmt.Sprintf("%s=%v", opt.Name, opt.Value))
+    }
+    sort.Strings(args)
+    return path, strings.Join(args, " ")
+}
+
+// recordCommandAudit adds an audited slash command to the audit trail with
+// how it ended
+func (b *Bot) recordCommandAudit(i *discordgo.InteractionCreate, err error) {
+    data := i.ApplicationCommandData()
+    if !auditedCommands[data.Name] {
+        return
+    }
+    path, args := auditCommand(data)
+    if path == "/sources list" {
+        return
+    }
+
+    event := &AuditEvent{
+        Actor:  interactionUserID(i),
+        Type:   AuditTypeCommand,
+        Action: path,
+        Target: args,
+        Result: "ok",
+    }
+    switch {
+    case !b.isAdmin(i):
+        event.Result = "denied"
+    case err != nil:
+        event.Result = "failed"
+        event.Details = err.Error()
+    }
+    auditTrail.Record(event)
+}
+
+// marshalAuditExport renders an export as indented JSON with its file name
+func marshalAuditExport(export *AuditExport) ([]byte, string, error) {
+    data, err := json.MarshalIndent(export, "", "  ")
+    if err != nil {
+        return nil, "", fmt.Errorf("failed to marshal audit export: %v", err)
+    }
+    filename := fmt.Sprintf("audit-%s-%s.json", export.From.Format("20060102"), export.To.Add(-time.Second).Format("20060102"))
+    return data, filename, nil
+}
+
+// handleAuditExport serves /api/audit?from=YYYY-MM-DD&to=YYYY-MM-DD&actor=ID&type=T.
+// It needs audit_export_token as a bearer token and is off without one.
+func (d *Dashboard) handleAuditExport(w http.ResponseWriter, r *http.Request) {
+    if cfg.AuditExportToken == "" {
+        http.NotFound(w, r)
+        return
+    }
+    token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+    if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.AuditExportToken)) != 1 {
+        Logger().Printf("Rejected audit export from %s", r.RemoteAddr)
+        http.Error(w, "Unauthorized", http.StatusUnauthorized)
+        return
+    }
+
+    query := r.URL.Query()
+    from, to, err := parseAuditRange(query.Get("from"), query.Get("to"), time.Now())
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    auditType := query.Get("type")
+    if !validAuditType(auditType) {
+        http.Error(w, fmt.Sprintf("Unknown type '%s'", auditType), http.StatusBadRequest)
+        return
+    }
+
+    export, err := auditTrail.Export(AuditFilter{From: from, To: to, Actor: query.Get("actor"), Type: auditType})
+    if err != nil {
+        http.Error(w, "Failed to load audit events", http.StatusInternalServerError)
+        Logger().Printf("Failed to export audit events: %v", err)
+        return
+    }
+    data, filename, err := marshalAuditExport(export)
+    if err != nil {
+        http.Error(w, "Failed to build the export", http.StatusInternalServerError)
+        Logger().Printf("Failed to export audit events: %v", err)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+    if _, err := w.Write(data); err != nil {
+        Logger().Printf("Failed to write audit export: %v", err)
+    }
+}
+
+// handleAuditCommand attaches the audit events in a date range as JSON,
+// optionally only one actor's or one type's
+func (b *Bot) handleAuditCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    from, to, err := parseAuditRange(getOptionString(options, "from"), getOptionString(options, "to"), time.Now())
+    if err != nil {
+        respondWithError(s, i, err.Error())
+        return nil
+    }
+    filter := AuditFilter{From: from, To: to, Type: getOptionString(options, "type")}
+    for _, opt := range options {
+        if opt.Name == "actor" {
+            filter.Actor = fmt.Sprint(opt.Value)
+        }
+    }
+
+    export, err := auditTrail.Export(filter)
+    if err != nil {
+        respondWithError(s, i, "Failed to load audit events")
+        return fmt.Errorf("failed to export audit events: %v", err)
+    }
+    data, filename, err := marshalAuditExport(export)
+    if err != nil {
+        respondWithError(s, i, "Failed to build the export")
+        return err
+    }
+
+    content := fmt.Sprintf("📋 %d audit events from %s to %s", len(export.Events),
+        from.Format("2006-01-02"), to.Add(-time.Second).Format("2006-01-02"))
+    if export.Truncated {
+        content += fmt.Sprintf("\n⚠️ Only the first %d are included; narrow the range or filters", auditExportLimit)
+    }
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Files: []*discordgo.File{{
+                Name:        filename,
+                ContentType: "application/json",
+                Reader:      bytes.NewReader(data),
+            }},
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
@@ -20,6 +20,11 @@ type Bot struct {
     formatter  *Formatter
     dashboard  *Dashboard
     factChecker *FactChecker
+    factCheckPool *FactCheckPool
+    factCheckLimit *FactCheckLimiter
+    cooldowns  *CooldownManager
+    configManager *ConfigManager
+    leader     *LeaderElector
     config     *BotConfig
     startTime  time.Time
     mutex      sync.RWMutex
@@ -49,7 +54,9 @@ func NewBot(config *BotConfig) (*Bot, error) {
         database:    db,
         logger:      Logger(),
         formatter:   NewFormatter(),
-        factChecker: NewFactChecker(),
+        factChecker: DefaultFactChecker(),
+        factCheckLimit: NewFactCheckLimiter(config.FactCheckRatePerMinute),
+        cooldowns:   NewCooldownManager(),
         config:      config,
         startTime:   time.Now(),
     }
@@ -83,13 +90,62 @@ func (b *Bot) Start() error {
         return fmt.Errorf("failed to open Discord connection: %v", err)
     }
 
+    // Reuse stored summaries instead of re-summarizing articles
+    summaryCache.SetDatabase(b.database)
+    factCheckQueue.SetDatabase(b.database)
+
+    // Sample counters for the dashboard history, starting with a baseline
+    analytics.SetDatabase(b.database)
+    b.recordAnalytics()
+
+    // Admin actions and config changes go to the audit_log table
+    auditTrail.SetDatabase(b.database)
+
+    // Route errors to the database and the error channel
+    errorSystem.SetDatabase(b.database)
+    if cfg != nil {
+        errorSystem.SetDiscord(b.discord, cfg.ErrorChannelID)
+    }
+
+    // Fact checks run on their own workers, before any fetch needs them
+    b.factCheckPool = NewFactCheckPool(b, b.config.FactCheckWorkers)
+    b.factCheckPool.Start()
+
+    // Settle leadership before the first feed check, so a standby doesn't post
+    b.leader = NewLeaderElector(b.database, b.config.Leader)
+    b.leader.Start()
+    leaderElection = b.leader
+
     // Start scheduler
     if err := b.scheduler.Start(); err != nil {
         return fmt.Errorf("failed to start scheduler: %v", err)
     }
 
+    // Register maintenance jobs and start cron
+    if err := b.scheduleMaintenance(); err != nil {
+        return fmt.Errorf("failed to schedule maintenance: %v", err)
+    }
+    cronManager.Start()
+
+    // Pick up hand edits to the sources file without a restart
+    manager, err := NewConfigManager(botConfigPath(), time.Minute)
+    if err != nil {
+        b.logger.Warn("Config watching disabled: %v", err)
+    } else if err := manager.WatchSources(b.config.SourcesPath, b.onSourcesChanged); err != nil {
+        b.logger.Warn("Sources watching disabled: %v", err)
+        manager.Stop()
+    } else {
+        b.configManager = manager
+        manager.StartWatching()
+    }
+
+    if b.config.SelfTestOnStartup {
+        go b.logSelfTest()
+    }
+
     // Start dashboard if enabled
     if b.dashboard != nil {
+        b.dashboard.SetRefreshFunc(b.scheduler.RefreshNow)
         go func() {
             if err := b.dashboard.Start(); err != nil {
                 b.logger.Error("Dashboard error: %v", err)
@@ -100,12 +156,45 @@ func (b *Bot) Start() error {
     return nil
 }
 
+// onSourcesChanged reloads sources after the sources file is edited on disk
+func (b *Bot) onSourcesChanged() {
+    diff, err := b.scheduler.ReloadSources()
+    if err != nil {
+        b.logger.Error("Failed to reload sources: %v", err)
+        return
+    }
+    b.logger.Info("Sources reloaded from disk: %s", formatSourceDiff(diff))
+}
+
 // Stop gracefully shuts down the bot
 func (b *Bot) Stop() error {
     b.logger.Info("Stopping bot...")
 
-    // Stop scheduler
+    // Shutdown steps share one deadline
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    // Stop scheduler and queue anything it fetched but didn't post
     b.scheduler.Stop()
+    drained, err := b.scheduler.DrainInFlight(ctx)
+    if err != nil {
+        b.logger.Error("Failed to queue unposted articles: %v", err)
+    }
+    b.logger.Info("Queued %d unposted articles for the next start", drained)
+
+    // Wait for running cron jobs
+    <-cronManager.Stop().Done()
+    if b.factCheckPool != nil {
+        b.factCheckPool.Stop()
+    }
+    refactCheckJob.Cancel()
+
+    // Hand over leadership only once nothing is posting
+    b.leader.Stop()
+
+    if b.configManager != nil {
+        b.configManager.Stop()
+    }
 
     // Stop dashboard if running
     if b.dashboard != nil {
@@ -160,9 +249,9 @@ func (b *Bot) handleMessageCreate(s *discordgo.Session, m *discordgo.MessageCrea
     // Handle commands
     switch cmd {
     case "sources":
-        b.handleSourcesCommand(s, m)
+        b.handleSourcesMessage(s, m)
     case "status":
-        b.handleStatusCommand(s, m)
+        b.handleStatusMessage(s, m)
     case "help":
         b.handleHelpCommand(s, m)
     case "refresh":
@@ -185,7 +274,7 @@ func (b *Bot) handleInteractionCreate(s *discordgo.Session, i *discordgo.Interac
 
 // Command handlers
 
-func (b *Bot) handleSourcesCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
+func (b *Bot) handleSourcesMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
     // Create categories map
     categories := make(map[string][]string)
     
@@ -220,52 +309,8 @@ func (b *Bot) handleSourcesCommand(s *discordgo.Session, m *discordgo.MessageCre
     }
 }
 
-func (b *Bot) handleStatusCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
-    // Get bot statistics
-    stats := b.scheduler.GetStats()
-    uptime := time.Since(b.startTime).Round(time.Second)
-
-    // Create embed
-    embed := &discordgo.MessageEmbed{
-        Title: "Bot Status",
-        Color: 0x43B581,
-        Fields: []*discordgo.MessageEmbedField{
-            {
-                Name:   "Uptime",
-                Value:  uptime.String(),
-                Inline: true,
-            },
-            {
-                Name:   "Articles Fetched",
-                Value:  fmt.Sprintf("%d", stats.ArticleCount),
-                Inline: true,
-            },
-            {
-                Name:   "Active Sources",
-                Value:  fmt.Sprintf("%d", stats.ActiveSources),
-                Inline: true,
-            },
-            {
-                Name:   "Last Update",
-                Value:  stats.LastUpdate.Format("2006-01-02 15:04:05 MST"),
-                Inline: false,
-            },
-        },
-        Timestamp: time.Now().Format(time.RFC3339),
-    }
-
-    // Add error information if there are any
-    if stats.LastError != "" {
-        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-            Name:   "Last Error",
-            Value:  stats.LastError,
-            Inline: false,
-        })
-        embed.Color = 0xF04747 // Red color for error state
-    }
-
-    // Send embed
-    _, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+func (b *Bot) handleStatusMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
+    _, err := s.ChannelMessageSendEmbed(m.ChannelID, b.statusEmbed())
     if err != nil {
         b.logger.Error("Failed to send status: %v", err)
     }
@@ -334,11 +379,84 @@ func (b *Bot) handleRefreshCommand(s *discordgo.Session, m *discordgo.MessageCre
 func (b *Bot) handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
     cmd := i.ApplicationCommandData().Name
 
+    // Commands mapped to roles in command_permissions need one of those roles
+    if !b.canUseCommand(i, cmd) {
+        respondWithError(s, i, fmt.Sprintf("You don't have a role that can use /%s", cmd))
+        return
+    }
+
+    // Rate-limit expensive commands per user; admins are exempt
+    if !b.isAdmin(i) {
+        cooldown := time.Duration(b.config.CommandCooldowns[cmd]) * time.Second
+        if remaining, ok := b.cooldowns.Acquire(interactionUserID(i), cmd, cooldown); !ok {
+            respondWithError(s, i, fmt.Sprintf("⏳ Please wait %s before using /%s again",
+                remaining.Round(time.Second), cmd))
+            return
+        }
+    }
+
+    var err error
     switch cmd {
+    case "news":
+        err = b.handleNewsCommand(s, i)
+    case "digest":
+        err = b.handleDigestCommand(s, i)
+    case "factcheck":
+        err = b.handleFactCheckCommand(s, i)
     case "sources":
-        b.handleSourcesSlashCommand(s, i)
+        err = b.handleSourcesCommand(s, i)
+    case "ping":
+        err = b.handlePingCommand(s, i)
+    case "version":
+        err = b.handleVersionCommand(s, i)
     case "status":
-        b.handleStatusSlashCommand(s, i)
+        err = b.handleStatusCommand(s, i)
+    case "mystatus":
+        err = b.handleMyStatusCommand(s, i)
+    case "language":
+        err = b.handleLanguageCommand(s, i)
+    case "compare":
+        err = b.handleCompareCommand(s, i)
+    case "history":
+        err = b.handleHistoryCommand(s, i)
+    case "why":
+        err = b.handleWhyCommand(s, i)
+    case "formatpreview":
+        err = b.handleFormatPreviewCommand(s, i)
+    case "topsources":
+        err = b.handleTopSourcesCommand(s, i)
+    case "sourcehealth":
+        err = b.handleSourceHealthCommand(s, i)
+    case "recommend":
+        err = b.handleRecommendCommand(s, i)
+    case "article-languages":
+        err = b.handleArticleLanguagesCommand(s, i)
+    case "snooze":
+        err = b.handleSnoozeCommand(s, i)
+    case "forgetme":
+        err = b.handleForgetMeCommand(s, i)
+    case "forgetuser":
+        err = b.handleForgetUserCommand(s, i)
+    case "config":
+        err = b.handleConfigCommand(s, i)
+    case "reload":
+        err = b.handleReloadCommand(s, i)
+    case "preview-digest":
+        err = b.handlePreviewDigestCommand(s, i)
+    case "mode":
+        err = b.handleModeCommand(s, i)
+    case "alert":
+        err = b.handleAlertCommand(s, i)
+    case "selftest":
+        err = b.handleSelfTestCommand(s, i)
+    case "validate":
+        err = b.handleValidateCommand(s, i)
+    case "cleanup":
+        err = b.handleCleanupCommand(s, i)
+    case "audit":
+        err = b.handleAuditCommand(s, i)
+    case "refactcheck":
+        err = b.handleRefactCheckCommand(s, i)
     default:
         s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
             Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -347,9 +465,44 @@ func (b *Bot) handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionC
             },
         })
     }
+
+    if err != nil {
+        b.logger.Error("Command /%s failed: %v", cmd, err)
+    }
+    b.recordCommandAudit(i, err)
+}
+
+// isAdmin reports whether the interaction user is the bot owner, a server
+// administrator or holds a role mapped to the command being run
+func (b *Bot) isAdmin(i *discordgo.InteractionCreate) bool {
+    if b.isSuperuser(i) {
+        return true
+    }
+    // A role mapped to the command in command_permissions grants its admin
+    // actions, including the components the command posts
+    command, ok := interactionCommand(i)
+    return ok && b.hasCommandRole(i, command)
 }
 
 func (b *Bot) handleMessageComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+    switch customID := i.MessageComponentData().CustomID; {
+    case strings.HasPrefix(customID, pagePrefix):
+        if err := b.handlePageButton(s, i); err != nil {
+            b.logger.Error("Failed to turn page: %v", err)
+        }
+        return
+    case strings.HasPrefix(customID, purgePrefix):
+        if err := b.handlePurgeButton(s, i); err != nil {
+            b.logger.Error("Failed to purge source: %v", err)
+        }
+        return
+    case strings.HasPrefix(customID, sourceManagePrefix):
+        if err := b.handleSourceManageComponent(s, i); err != nil {
+            b.logger.Error("Failed to manage source: %v", err)
+        }
+        return
+    }
+
     s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
         Type: discordgo.InteractionResponseChannelMessageWithSource,
         Data: &discordgo.InteractionResponseData{
@@ -0,0 +1,201 @@
+// cmd/sankarea/breaking.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+)
+
+// BreakingNewsConfig controls the immediate-post path for urgent stories
+type BreakingNewsConfig struct {
+    Enabled   bool               `json:"enabled"`
+    ChannelID string             `json:"channel_id"`
+    Mention   string             `json:"mention"` // e.g. "<@&role_id>" or "@here"
+    Rules     []BreakingNewsRule `json:"rules"`
+}
+
+// BreakingNewsRule matches an article when every non-empty criterion matches.
+// Within a criterion any listed value is enough.
+type BreakingNewsRule struct {
+    Keywords   []string `json:"keywords,omitempty"` // matched case-insensitively in the title
+    Sources    []string `json:"sources,omitempty"`
+    Categories []string `json:"categories,omitempty"`
+    MaxTier    int      `json:"max_tier,omitempty"` // sources at this priority tier or higher, e.g. 1 for top-tier only
+}
+
+// defaultBreakingRules is used when breaking news is enabled without rules
+var defaultBreakingRules = []BreakingNewsRule{
+    {Keywords: []string{"breaking", "alert"}},
+}
+
+// Matches reports whether the rule applies to an article
+func (r BreakingNewsRule) Matches(article *NewsArticle, priorities map[string]int) bool {
+    if len(r.Keywords) == 0 && len(r.Sources) == 0 && len(r.Categories) == 0 && r.MaxTier == 0 {
+        return false
+    }
+    if len(r.Keywords) > 0 {
+        title := strings.ToLower(article.Title)
+        found := false
+        for _, keyword := range r.Keywords {
+            if strings.Contains(title, strings.ToLower(keyword)) {
+                found = true
+                break
+            }
+        }
+        if !found {
+            return false
+        }
+    }
+    if len(r.Sources) > 0 && !containsFold(r.Sources, article.SourceName) {
+        return false
+    }
+    if len(r.Categories) > 0 && !containsFold(r.Categories, article.Category) {
+        return false
+    }
+    if r.MaxTier > 0 {
+        tier, ok := priorities[article.SourceName]
+        if !ok {
+            tier = SourcePriorityNormal
+        }
+        if tier > r.MaxTier {
+            return false
+        }
+    }
+    return true
+}
+
+// containsFold reports whether list contains value, ignoring case
+func containsFold(list []string, value string) bool {
+    for _, v := range list {
+        if strings.EqualFold(v, value) {
+            return true
+        }
+    }
+    return false
+}
+
+// isBreaking reports whether any configured rule matches the article
+func (b *Bot) isBreaking(article *NewsArticle, priorities map[string]int) bool {
+    cfg := b.config.BreakingNews
+    if !cfg.Enabled || cfg.ChannelID == "" {
+        return false
+    }
+    for _, rule := range cfg.Rules {
+        if rule.Matches(article, priorities) {
+            return true
+        }
+    }
+    return false
+}
+
+// breakingPosts tracks the links posted on the breaking path during one feed check
+type breakingPosts struct {
+    mutex sync.Mutex
+    links map[string]bool
+}
+
+// add records a link as posted
+func (p *breakingPosts) add(link string) {
+    p.mutex.Lock()
+    defer p.mutex.Unlock()
+    if p.links == nil {
+        p.links = make(map[string]bool)
+    }
+    p.links[link] = true
+}
+
+// drop removes articles already posted as breaking news
+func (p *breakingPosts) drop(articles []*NewsArticle) []*NewsArticle {
+    p.mutex.Lock()
+    defer p.mutex.Unlock()
+    kept := make([]*NewsArticle, 0, len(articles))
+    for _, article := range articles {
+        if !p.links[article.URL] {
+            kept = append(kept, article)
+        }
+    }
+    return kept
+}
+
+// postBreakingNow posts the breaking stories among one feed's articles right
+// away instead of waiting for the rest of the cycle, and records them in
+// posted so the regular batch skips them. It runs on the feed's goroutine.
+func (s *Scheduler) postBreakingNow(articles []*NewsArticle, priorities map[string]int, posted *breakingPosts) {
+    if digestOnly() {
+        return
+    }
+    var candidates []*NewsArticle
+    for _, article := range articles {
+        if s.bot.isBreaking(article, priorities) {
+            candidates = append(candidates, article)
+        }
+    }
+    for _, article := range s.dropCoolingDown(candidates) {
+        if err := s.bot.postBreaking(article); err != nil {
+            s.bot.logger.Error("Failed to post breaking news: %v", err)
+            traceDecision(article.URL, StageBreaking, "failed", err.Error())
+            continue
+        }
+        posted.add(article.URL)
+        markPosted(article.URL)
+        traceDecision(article.URL, StageBreaking, "posted", fmt.Sprintf("<#%s>", s.bot.config.BreakingNews.ChannelID))
+        s.bot.logger.Info("Posted breaking news: %s", article.Title)
+    }
+}
+
+// postBreaking sends an article to the breaking news channel with the alert mention
+func (b *Bot) postBreaking(article *NewsArticle) error {
+    cfg := b.config.BreakingNews
+    mention := b.alertMention()
+
+    item := &gofeed.Item{
+        Title:           article.Title,
+        Link:            article.URL,
+        Description:     article.Description,
+        PublishedParsed: &article.PublishedAt,
+    }
+    if article.ImageURL != "" {
+        item.Image = &gofeed.Image{URL: article.ImageURL}
+    }
+
+    content, embeds := FormatNewsItem(item, article.SourceName, article.Category, article.Description, "", nil, defaultFormatStyle(), false, true, defaultLanguage())
+    header := "🚨 **Breaking News**"
+    if mention != "" {
+        header = mention + " " + header
+        if len(embeds) > 0 {
+            embeds[0].Fields = append(embeds[0].Fields, &discordgo.MessageEmbedField{
+                Name:   "🔔 Alerting",
+                Value:  mention,
+                Inline: true,
+            })
+        }
+    }
+    if content != "" {
+        content = header + "\n" + content
+    } else {
+        content = header
+    }
+
+    if err := waitForSlowMode(b.discord, cfg.ChannelID); err != nil {
+        return fmt.Errorf("failed to send breaking news: %v", err)
+    }
+    _, err := b.discord.ChannelMessageSendComplex(cfg.ChannelID, &discordgo.MessageSend{
+        Content: content,
+        Embeds:  embeds,
+        AllowedMentions: &discordgo.MessageAllowedMentions{
+            Parse: []discordgo.AllowedMentionType{
+                discordgo.AllowedMentionTypeRoles,
+                discordgo.AllowedMentionTypeUsers,
+                discordgo.AllowedMentionTypeEveryone,
+            },
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to send breaking news: %v", err)
+    }
+    return nil
+}
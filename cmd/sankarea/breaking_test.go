@@ -0,0 +1,51 @@
+// cmd/sankarea/breaking_test.go
+package main
+
+import (
+    "testing"
+)
+
+func TestBreakingNewsRuleMatches(t *testing.T) {
+    article := &NewsArticle{Title: "BREAKING: Storm hits the coast", Category: "World", URL: "https://example.com/storm"}
+    tests := []struct {
+        name       string
+        rule       BreakingNewsRule
+        priorities map[string]int
+        want       bool
+    }{
+        {"empty rule never matches", BreakingNewsRule{}, nil, false},
+        {"keyword ignores case", BreakingNewsRule{Keywords: []string{"breaking"}}, nil, true},
+        {"any keyword is enough", BreakingNewsRule{Keywords: []string{"alert", "storm"}}, nil, true},
+        {"no keyword in the title", BreakingNewsRule{Keywords: []string{"election"}}, nil, false},
+        {"category ignores case", BreakingNewsRule{Categories: []string{"world"}}, nil, true},
+        {"other category", BreakingNewsRule{Categories: []string{"Sports"}}, nil, false},
+        {"every criterion must match", BreakingNewsRule{Keywords: []string{"storm"}, Categories: []string{"Sports"}}, nil, false},
+        {"unranked sources count as normal", BreakingNewsRule{MaxTier: SourcePriorityNormal}, nil, true},
+        {"normal sources miss a top-tier rule", BreakingNewsRule{MaxTier: SourcePriorityHigh}, nil, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := tt.rule.Matches(article, tt.priorities); got != tt.want {
+                t.Errorf("Matches = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBreakingPostsDrop(t *testing.T) {
+    var posted breakingPosts
+    articles := []*NewsArticle{
+        {URL: "https://example.com/1"},
+        {URL: "https://example.com/2"},
+        {URL: "https://example.com/3"},
+    }
+    if got := posted.drop(articles); len(got) != 3 {
+        t.Fatalf("drop before any post kept %d articles, want 3", len(got))
+    }
+
+    posted.add("https://example.com/2")
+    got := posted.drop(articles)
+    if len(got) != 2 || got[0].URL != "https://example.com/1" || got[1].URL != "https://example.com/3" {
+        t.Errorf("drop kept %v, want articles 1 and 3", got)
+    }
+}
@@ -0,0 +1,229 @@
+// cmd/sankarea/categories.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "strings"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+    "github.com/sashabaranov/go-openai"
+)
+
+// CategoryUncategorized marks a source that has no category of its own
+const CategoryUncategorized = "uncategorized"
+
+// defaultCategoryAliases maps common feed and source labels onto the
+// canonical categories; category_aliases entries take precedence
+var defaultCategoryAliases = map[string]string{
+    "tech":          CategoryTechnology,
+    "technology":    CategoryTechnology,
+    "computing":     CategoryTechnology,
+    "gadgets":       CategoryTechnology,
+    "business":      CategoryBusiness,
+    "economy":       CategoryBusiness,
+    "finance":       CategoryBusiness,
+    "markets":       CategoryBusiness,
+    "money":         CategoryBusiness,
+    "science":       CategoryScience,
+    "space":         CategoryScience,
+    "environment":   CategoryScience,
+    "health":        CategoryHealth,
+    "medicine":      CategoryHealth,
+    "wellness":      CategoryHealth,
+    "politics":      CategoryPolitics,
+    "election":      CategoryPolitics,
+    "elections":     CategoryPolitics,
+    "government":    CategoryPolitics,
+    "sport":         CategorySports,
+    "sports":        CategorySports,
+    "football":      CategorySports,
+    "soccer":        CategorySports,
+    "world":         CategoryWorld,
+    "world news":    CategoryWorld,
+    "international": CategoryWorld,
+    "global":        CategoryWorld,
+}
+
+// ValidateCategoryAliases checks that every alias points at a canonical category
+func ValidateCategoryAliases(aliases map[string]string) error {
+    for alias, category := range aliases {
+        if !isValidCategory(strings.ToLower(strings.TrimSpace(category))) {
+            return fmt.Errorf("alias %q maps to unknown category %q", alias, category)
+        }
+    }
+    return nil
+}
+
+// normalizeCategory resolves a raw label to a canonical category, or ""
+// when it matches none
+func normalizeCategory(raw string) string {
+    key := strings.ToLower(strings.TrimSpace(raw))
+    if key == "" {
+        return ""
+    }
+    if cfg != nil {
+        for alias, category := range cfg.CategoryAliases {
+            if strings.ToLower(strings.TrimSpace(alias)) == key {
+                return strings.ToLower(strings.TrimSpace(category))
+            }
+        }
+    }
+    if category, ok := defaultCategoryAliases[key]; ok {
+        return category
+    }
+    if isValidCategory(key) {
+        return key
+    }
+    return ""
+}
+
+// isUncategorized reports whether a source category leaves articles to be
+// categorized from their feed items
+func isUncategorized(category string) bool {
+    key := strings.ToLower(strings.TrimSpace(category))
+    return key == "" || key == CategoryUncategorized
+}
+
+// existingCategory returns a stored article's category, or "" for a new one
+func existingCategory(existing *NewsArticle) string {
+    if existing == nil {
+        return ""
+    }
+    return existing.Category
+}
+
+// categorizeArticle picks an article's canonical category: the source's own,
+// then the item's feed categories, then the AI categorizer when enabled.
+// Labels that match nothing are kept lowercased so custom channels still route.
+func (np *NewsProcessor) categorizeArticle(ctx context.Context, item *gofeed.Item, source NewsSource, article *NewsArticle) string {
+    if category := normalizeCategory(source.Category); category != "" {
+        return category
+    }
+    if !isUncategorized(source.Category) {
+        return strings.ToLower(strings.TrimSpace(source.Category))
+    }
+    if source.ForceCategory {
+        traceDecision(article.URL, StageCategory, CategoryUncategorized, "force_category is set; feed categories ignored")
+        return CategoryUncategorized
+    }
+
+    for _, label := range item.Categories {
+        if category := normalizeCategory(label); category != "" {
+            traceDecision(article.URL, StageCategory, category, fmt.Sprintf("feed category %q", label))
+            return category
+        }
+    }
+
+    if cfg != nil && cfg.AutoCategorize {
+        category, err := autoCategorize(ctx, article)
+        if err != nil {
+            np.logger.Debug("Auto-categorization skipped for %s: %v", article.URL, err)
+        } else if category != "" {
+            traceDecision(article.URL, StageCategory, category, "picked by the AI categorizer")
+            return category
+        }
+    }
+
+    traceDecision(article.URL, StageCategory, CategoryUncategorized, "no source, feed or AI category")
+    return CategoryUncategorized
+}
+
+// setSourceForceCategory turns a source's force_category flag on or off
+func setSourceForceCategory(actor, name string, force bool) error {
+    return UpdateSources(actor, func(sources []NewsSource) ([]NewsSource, error) {
+        for idx := range sources {
+            if strings.EqualFold(sources[idx].Name, name) {
+                sources[idx].ForceCategory = force
+                return sources, nil
+            }
+        }
+        return nil, errSourceNotFound
+    })
+}
+
+// handleForceCategorySource sets whether a source's category overrides the
+// categories its feed puts on each item
+func (b *Bot) handleForceCategorySource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    name := strings.TrimSpace(getOptionString(options, "name"))
+    if name == "" {
+        respondWithError(s, i, "Please specify the source name")
+        return nil
+    }
+    force, ok := getOptionBoolValue(options, "force")
+    if !ok {
+        force = true
+    }
+
+    if err := setSourceForceCategory(interactionUserID(i), name, force); err == errSourceNotFound {
+        respondWithError(s, i, "Source not found")
+        return nil
+    } else if err != nil {
+        respondWithError(s, i, "Failed to update source")
+        return fmt.Errorf("failed to set force_category for %s: %v", name, err)
+    }
+
+    content := fmt.Sprintf("🔒 **%s** now always uses its configured category", name)
+    if !force {
+        content = fmt.Sprintf("🔓 **%s** may take its category from its feed again", name)
+    }
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// autoCategorize asks the model to file an article under one canonical category
+func autoCategorize(ctx context.Context, article *NewsArticle) (string, error) {
+    if cfg.OpenAIAPIKey == "" {
+        return "", fmt.Errorf("OpenAI integration not configured")
+    }
+    if aiBudgetExceeded() {
+        return "", ErrAIBudgetExceeded
+    }
+
+    task := cfg.AI.Categorize
+    client := openai.NewClient(cfg.OpenAIAPIKey)
+
+    systemPrompt := fmt.Sprintf(`You file news articles under exactly one category.
+Answer with one word from this list and nothing else: %s.`, strings.Join(getValidCategories(), ", "))
+
+    contentToAnalyze := article.Title
+    if len(article.Content) > 0 {
+        contentToAnalyze += "\n\n" + article.Content[:min(len(article.Content), 1000)]
+    }
+
+    resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+        Model:     task.Model,
+        MaxTokens: task.MaxTokens,
+        Messages: []openai.ChatCompletionMessage{
+            {Role: "system", Content: systemPrompt},
+            {Role: "user", Content: contentToAnalyze},
+        },
+        Temperature: task.RequestTemperature(),
+    })
+    if err != nil {
+        return "", fmt.Errorf("OpenAI API error: %v", err)
+    }
+    if len(resp.Choices) == 0 {
+        return "", fmt.Errorf("OpenAI returned no choices")
+    }
+    updateOpenAIUsageCost(resp.Usage.TotalTokens)
+
+    answer := strings.Trim(strings.TrimSpace(resp.Choices[0].Message.Content), ".\"'")
+    category := normalizeCategory(answer)
+    if category == "" {
+        return "", fmt.Errorf("model answered with unknown category %q", answer)
+    }
+    return category, nil
+}
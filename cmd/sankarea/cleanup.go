@@ -0,0 +1,331 @@
+// cmd/sankarea/cleanup.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Discord only bulk deletes messages younger than two weeks; the margin
+// keeps a batch from failing on messages right at the limit
+const bulkDeleteMaxAge = 14*24*time.Hour - time.Hour
+
+// bulkDeleteLimit is the most messages one bulk delete can take
+const bulkDeleteLimit = 100
+
+// cleanupDeleteDelay spaces out delete requests to stay under rate limits
+const cleanupDeleteDelay = time.Second
+
+// cleanupResult counts what a cleanup did with each message
+type cleanupResult struct {
+    Deleted int // removed from Discord
+    Edited  int // shared with other sources, so only the source's embeds were removed
+    Gone    int // already deleted by someone else
+    Failed  int // left in place after an error
+}
+
+// parseCleanupAge parses ages like "12h", "7d" or "4w"
+func parseCleanupAge(value string) (time.Duration, error) {
+    value = strings.TrimSpace(strings.ToLower(value))
+
+    var d time.Duration
+    var err error
+    switch {
+    case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+        var n int
+        if _, err = fmt.Sscanf(value[:len(value)-1], "%d", &n); err == nil {
+            d = time.Duration(n) * 24 * time.Hour
+            if strings.HasSuffix(value, "w") {
+                d *= 7
+            }
+        }
+    default:
+        d, err = time.ParseDuration(value)
+    }
+
+    if err != nil || d <= 0 {
+        return 0, fmt.Errorf("Invalid age '%s', use e.g. 12h, 7d or 4w", value)
+    }
+    return d, nil
+}
+
+// postedMessage is one Discord message from a set of records
+type postedMessage struct {
+    channelID string
+    messageID string
+    postedAt  time.Time
+}
+
+// uniqueMessages collapses records to their messages, keeping the order of
+// first appearance; a batched message has one record per article
+func uniqueMessages(records []*ArticleMessage) []postedMessage {
+    seen := make(map[string]bool)
+    var messages []postedMessage
+    for _, record := range records {
+        if seen[record.MessageID] {
+            continue
+        }
+        seen[record.MessageID] = true
+        messages = append(messages, postedMessage{record.ChannelID, record.MessageID, record.PostedAt})
+    }
+    return messages
+}
+
+// groupMessageRecords splits records by message, keeping the order of first
+// appearance
+func groupMessageRecords(records []*ArticleMessage) [][]*ArticleMessage {
+    index := make(map[string]int)
+    var groups [][]*ArticleMessage
+    for _, record := range records {
+        key := record.ChannelID + "/" + record.MessageID
+        idx, ok := index[key]
+        if !ok {
+            idx = len(groups)
+            index[key] = idx
+            groups = append(groups, nil)
+        }
+        groups[idx] = append(groups[idx], record)
+    }
+    return groups
+}
+
+// onlySource reports whether every record of a message belongs to source
+func onlySource(records []*ArticleMessage, source string) bool {
+    for _, record := range records {
+        if !strings.EqualFold(record.Source, source) {
+            return false
+        }
+    }
+    return true
+}
+
+// dropSourceEmbeds removes source's articles from a message's embeds. It
+// returns the embeds left and the records of the other articles, moved to
+// their new positions.
+func dropSourceEmbeds(embeds []*discordgo.MessageEmbed, records []*ArticleMessage, source string) ([]*discordgo.MessageEmbed, []*ArticleMessage, error) {
+    sorted := append([]*ArticleMessage(nil), records...)
+    sort.Slice(sorted, func(i, j int) bool {
+        return sorted[i].EmbedIndex < sorted[j].EmbedIndex
+    })
+
+    var remaining []*discordgo.MessageEmbed
+    var kept []*ArticleMessage
+    for _, record := range sorted {
+        end := record.EmbedIndex + record.EmbedCount
+        if record.EmbedIndex < 0 || end > len(embeds) {
+            return nil, nil, fmt.Errorf("message has %d embeds, expected at least %d", len(embeds), end)
+        }
+        if strings.EqualFold(record.Source, source) {
+            continue
+        }
+        moved := *record
+        moved.EmbedIndex = len(remaining)
+        remaining = append(remaining, embeds[record.EmbedIndex:end]...)
+        kept = append(kept, &moved)
+    }
+    return remaining, kept, nil
+}
+
+// removeSourceEmbeds edits a message shared with other sources so it no
+// longer shows source's articles, and updates the message's records
+func (b *Bot) removeSourceEmbeds(records []*ArticleMessage, source string) error {
+    channelID, messageID := records[0].ChannelID, records[0].MessageID
+    msg, err := b.discord.ChannelMessage(channelID, messageID)
+    if err != nil {
+        return messageLoadError(err)
+    }
+
+    embeds, kept, err := dropSourceEmbeds(msg.Embeds, records, source)
+    if err != nil {
+        return err
+    }
+    if _, err := b.discord.ChannelMessageEditEmbeds(channelID, messageID, embeds); err != nil {
+        return fmt.Errorf("failed to edit message: %v", err)
+    }
+
+    for _, record := range records {
+        if !strings.EqualFold(record.Source, source) {
+            continue
+        }
+        if err := b.database.DeleteArticleMessage(record); err != nil {
+            b.logger.Error("Failed to drop message record for %s: %v", record.ArticleID, err)
+        }
+    }
+    for _, record := range kept {
+        if err := b.database.SaveArticleMessage(record); err != nil {
+            b.logger.Error("Failed to update message record for %s: %v", record.ArticleID, err)
+        }
+    }
+    return nil
+}
+
+// cleanupSourceMessages removes a source's posts: messages holding only its
+// articles are deleted, and batched messages shared with other sources are
+// edited to drop its embeds
+func (b *Bot) cleanupSourceMessages(records []*ArticleMessage, source string) cleanupResult {
+    var whole []*ArticleMessage
+    var shared [][]*ArticleMessage
+    for _, group := range groupMessageRecords(records) {
+        if onlySource(group, source) {
+            whole = append(whole, group...)
+        } else {
+            shared = append(shared, group)
+        }
+    }
+
+    result := b.deletePostedMessages(whole)
+    for _, group := range shared {
+        time.Sleep(cleanupDeleteDelay)
+        err := b.removeSourceEmbeds(group, source)
+        switch {
+        case err == nil:
+            result.Edited++
+        case err == errMessageGone:
+            result.Gone++
+            if err := b.database.DeleteMessageRecords(group[0].ChannelID, group[0].MessageID); err != nil {
+                b.logger.Error("Failed to drop message record %s: %v", group[0].MessageID, err)
+            }
+        default:
+            b.logger.Error("Failed to remove %s from message %s in %s: %v", source, group[0].MessageID, group[0].ChannelID, err)
+            result.Failed++
+        }
+    }
+    return result
+}
+
+// deletePostedMessages deletes messages from Discord and forgets their
+// records. Recent messages in a channel go in bulk deletes of up to 100;
+// older ones are deleted one at a time, spaced out by cleanupDeleteDelay.
+func (b *Bot) deletePostedMessages(records []*ArticleMessage) cleanupResult {
+    var result cleanupResult
+    var channels []string
+    bulk := make(map[string][]string)
+    var single []postedMessage
+    for _, msg := range uniqueMessages(records) {
+        if time.Since(msg.postedAt) >= bulkDeleteMaxAge {
+            single = append(single, msg)
+            continue
+        }
+        if _, ok := bulk[msg.channelID]; !ok {
+            channels = append(channels, msg.channelID)
+        }
+        bulk[msg.channelID] = append(bulk[msg.channelID], msg.messageID)
+    }
+
+    forget := func(channelID, messageID string) {
+        if err := b.database.DeleteMessageRecords(channelID, messageID); err != nil {
+            b.logger.Error("Failed to drop message record %s: %v", messageID, err)
+        }
+    }
+
+    for _, channelID := range channels {
+        ids := bulk[channelID]
+        for start := 0; start < len(ids); start += bulkDeleteLimit {
+            batch := ids[start:min(start+bulkDeleteLimit, len(ids))]
+            if len(batch) < 2 {
+                // Bulk deletes need at least two messages
+                single = append(single, postedMessage{channelID: channelID, messageID: batch[0]})
+                continue
+            }
+            if err := b.discord.ChannelMessagesBulkDelete(channelID, batch); err != nil {
+                // A deleted message fails the whole batch; retry them one by one
+                b.logger.Warn("Bulk delete of %d messages in %s failed, deleting one at a time: %v", len(batch), channelID, err)
+                for _, messageID := range batch {
+                    single = append(single, postedMessage{channelID: channelID, messageID: messageID})
+                }
+            } else {
+                result.Deleted += len(batch)
+                for _, messageID := range batch {
+                    forget(channelID, messageID)
+                }
+            }
+            time.Sleep(cleanupDeleteDelay)
+        }
+    }
+
+    for n, msg := range single {
+        if n > 0 {
+            time.Sleep(cleanupDeleteDelay)
+        }
+        err := b.discord.ChannelMessageDelete(msg.channelID, msg.messageID)
+        switch {
+        case err == nil:
+            result.Deleted++
+        case messageLoadError(err) == errMessageGone:
+            result.Gone++
+        default:
+            b.logger.Error("Failed to delete message %s in %s: %v", msg.messageID, msg.channelID, err)
+            result.Failed++
+            continue
+        }
+        forget(msg.channelID, msg.messageID)
+    }
+    return result
+}
+
+// handleCleanupCommand deletes the bot's posts from a source that are older
+// than the given age, using the recorded message IDs
+func (b *Bot) handleCleanupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    source := strings.TrimSpace(getOptionString(options, "source"))
+    if source == "" {
+        respondWithError(s, i, "Please specify the source to clean up")
+        return nil
+    }
+    olderThan := getOptionString(options, "older_than")
+    age, err := parseCleanupAge(olderThan)
+    if err != nil {
+        respondWithError(s, i, err.Error())
+        return nil
+    }
+    if b.database == nil {
+        respondWithError(s, i, "Cleanup needs the article database")
+        return nil
+    }
+
+    err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    records, err := b.database.GetSourceMessages(source, time.Now().UTC().Add(-age))
+    if err != nil {
+        editResponse(s, i, "❌ Failed to load the source's posts")
+        return fmt.Errorf("failed to load posts for %s: %v", source, err)
+    }
+    if len(records) == 0 {
+        editResponse(s, i, fmt.Sprintf("No recorded posts from **%s** older than %s", source, olderThan))
+        return nil
+    }
+
+    result := b.cleanupSourceMessages(records, source)
+    b.logger.Info("Cleanup of %s older than %s by %s: %d deleted, %d edited, %d already gone, %d failed",
+        source, olderThan, interactionUserID(i), result.Deleted, result.Edited, result.Gone, result.Failed)
+
+    content := fmt.Sprintf("🧹 Deleted %d messages from **%s** older than %s", result.Deleted, source, olderThan)
+    if result.Edited > 0 {
+        content += fmt.Sprintf("\n✂️ Removed its articles from %d messages shared with other sources", result.Edited)
+    }
+    if result.Gone > 0 {
+        content += fmt.Sprintf("\n%d were already deleted", result.Gone)
+    }
+    if result.Failed > 0 {
+        content += fmt.Sprintf("\n⚠️ %d could not be deleted; see the log", result.Failed)
+    }
+    editResponse(s, i, content)
+    return nil
+}
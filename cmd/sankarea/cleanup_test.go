@@ -0,0 +1,115 @@
+// cmd/sankarea/cleanup_test.go
+package main
+
+import (
+    "fmt"
+    "testing"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+func TestParseCleanupAge(t *testing.T) {
+    tests := []struct {
+        value string
+        want  time.Duration
+        ok    bool
+    }{
+        {"12h", 12 * time.Hour, true},
+        {"90m", 90 * time.Minute, true},
+        {"7d", 7 * 24 * time.Hour, true},
+        {"4w", 4 * 7 * 24 * time.Hour, true},
+        {" 2D ", 2 * 24 * time.Hour, true},
+        {"1h30m", 90 * time.Minute, true},
+        {"", 0, false},
+        {"0d", 0, false},
+        {"-3h", 0, false},
+        {"d", 0, false},
+        {"xw", 0, false},
+        {"7 days", 0, false},
+    }
+    for _, tt := range tests {
+        got, err := parseCleanupAge(tt.value)
+        if (err == nil) != tt.ok {
+            t.Errorf("parseCleanupAge(%q) error = %v, want ok %v", tt.value, err, tt.ok)
+            continue
+        }
+        if got != tt.want {
+            t.Errorf("parseCleanupAge(%q) = %v, want %v", tt.value, got, tt.want)
+        }
+    }
+}
+
+// titledEmbeds returns embeds titled with the given names
+func titledEmbeds(titles ...string) []*discordgo.MessageEmbed {
+    embeds := make([]*discordgo.MessageEmbed, len(titles))
+    for idx, title := range titles {
+        embeds[idx] = &discordgo.MessageEmbed{Title: title}
+    }
+    return embeds
+}
+
+func TestDropSourceEmbeds(t *testing.T) {
+    embeds := titledEmbeds("a1", "b1", "b1 image", "a2", "c1")
+    records := []*ArticleMessage{
+        {ArticleID: "c1", Source: "Gamma", EmbedIndex: 4, EmbedCount: 1},
+        {ArticleID: "a1", Source: "Alpha", EmbedIndex: 0, EmbedCount: 1},
+        {ArticleID: "b1", Source: "beta", EmbedIndex: 1, EmbedCount: 2},
+        {ArticleID: "a2", Source: "Alpha", EmbedIndex: 3, EmbedCount: 1},
+    }
+
+    remaining, kept, err := dropSourceEmbeds(embeds, records, "alpha")
+    if err != nil {
+        t.Fatal(err)
+    }
+    var titles []string
+    for _, embed := range remaining {
+        titles = append(titles, embed.Title)
+    }
+    if got, want := fmt.Sprint(titles), "[b1 b1 image c1]"; got != want {
+        t.Errorf("remaining embeds = %s, want %s", got, want)
+    }
+
+    positions := make(map[string][2]int)
+    for _, record := range kept {
+        positions[record.ArticleID] = [2]int{record.EmbedIndex, record.EmbedCount}
+    }
+    want := map[string][2]int{"b1": {0, 2}, "c1": {2, 1}}
+    if fmt.Sprint(positions) != fmt.Sprint(want) {
+        t.Errorf("kept positions = %v, want %v", positions, want)
+    }
+    // The caller's records keep their old positions until they're saved
+    if records[2].EmbedIndex != 1 {
+        t.Errorf("dropSourceEmbeds changed the input record to index %d", records[2].EmbedIndex)
+    }
+
+    // Records pointing past the message's embeds are refused
+    short := titledEmbeds("a1", "b1")
+    if _, _, err := dropSourceEmbeds(short, records, "alpha"); err == nil {
+        t.Error("expected an error for records past the message's embeds")
+    }
+}
+
+func TestGroupMessageRecords(t *testing.T) {
+    records := []*ArticleMessage{
+        {ArticleID: "1", ChannelID: "c1", MessageID: "m1", Source: "Alpha"},
+        {ArticleID: "2", ChannelID: "c2", MessageID: "m2", Source: "Alpha"},
+        {ArticleID: "3", ChannelID: "c1", MessageID: "m1", Source: "Beta"},
+        {ArticleID: "4", ChannelID: "c2", MessageID: "m3", Source: "ALPHA"},
+    }
+    groups := groupMessageRecords(records)
+    if len(groups) != 3 {
+        t.Fatalf("got %d groups, want 3", len(groups))
+    }
+    if len(groups[0]) != 2 || groups[0][1].ArticleID != "3" {
+        t.Errorf("first group = %v, want articles 1 and 3", groups[0])
+    }
+
+    var shared []bool
+    for _, group := range groups {
+        shared = append(shared, !onlySource(group, "alpha"))
+    }
+    if got, want := fmt.Sprint(shared), "[true false false]"; got != want {
+        t.Errorf("shared messages = %s, want %s", got, want)
+    }
+}
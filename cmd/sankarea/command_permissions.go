@@ -0,0 +1,77 @@
+// cmd/sankarea/command_permissions.go
+package main
+
+import (
+    "strings"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// allGuilds is the command_permissions key that applies to every guild
+const allGuilds = "*"
+
+// componentCommands maps a component custom ID prefix to the command that
+// posts it, so its buttons and menus are allowed for the command's roles
+var componentCommands = map[string]string{
+    purgePrefix:        "sources",
+    sourceManagePrefix: "sources",
+}
+
+// interactionCommand returns the command a slash command or component
+// interaction belongs to
+func interactionCommand(i *discordgo.InteractionCreate) (string, bool) {
+    switch i.Type {
+    case discordgo.InteractionApplicationCommand:
+        return i.ApplicationCommandData().Name, true
+    case discordgo.InteractionMessageComponent:
+        customID := i.MessageComponentData().CustomID
+        for prefix, command := range componentCommands {
+            if strings.HasPrefix(customID, prefix) {
+                return command, true
+            }
+        }
+    }
+    return "", false
+}
+
+// isSuperuser reports whether the invoker is the bot owner or a server administrator
+func (b *Bot) isSuperuser(i *discordgo.InteractionCreate) bool {
+    if i.Member == nil {
+        return false
+    }
+    return i.Member.User.ID == b.config.OwnerID || i.Member.Permissions&discordgo.PermissionAdministrator != 0
+}
+
+// commandRoles returns the role IDs allowed to use a command in a guild. A
+// guild's own entry overrides the "*" entry; nil means no roles are mapped.
+func (b *Bot) commandRoles(guildID, command string) []string {
+    if roles, ok := b.config.CommandPermissions[guildID][command]; ok {
+        return roles
+    }
+    return b.config.CommandPermissions[allGuilds][command]
+}
+
+// hasCommandRole reports whether the invoker holds a role mapped to the command
+func (b *Bot) hasCommandRole(i *discordgo.InteractionCreate, command string) bool {
+    if i.Member == nil {
+        return false
+    }
+    for _, allowed := range b.commandRoles(i.GuildID, command) {
+        for _, role := range i.Member.Roles {
+            if role == allowed {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// canUseCommand reports whether the invoker may run a command at all. Commands
+// with no mapped roles are open to everyone; mapped commands need one of the
+// roles, and superusers can always run them.
+func (b *Bot) canUseCommand(i *discordgo.InteractionCreate, command string) bool {
+    if len(b.commandRoles(i.GuildID, command)) == 0 {
+        return true
+    }
+    return b.isSuperuser(i) || b.hasCommandRole(i, command)
+}
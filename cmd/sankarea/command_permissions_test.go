@@ -0,0 +1,122 @@
+// cmd/sankarea/command_permissions_test.go
+package main
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// permissionsBot returns a bot with the command_permissions used by these tests
+func permissionsBot() *Bot {
+    return &Bot{config: &BotConfig{
+        OwnerID: "owner",
+        CommandPermissions: map[string]map[string][]string{
+            allGuilds: {"sources": {"editors"}, "digest": {"readers"}},
+            "g1":      {"sources": {"g1-editors"}},
+            "g2":      {"sources": {}},
+        },
+    }}
+}
+
+// commandInteraction returns a slash command interaction from a member
+func commandInteraction(guildID, command string, member *discordgo.Member) *discordgo.InteractionCreate {
+    return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+        Type:    discordgo.InteractionApplicationCommand,
+        GuildID: guildID,
+        Member:  member,
+        Data:    discordgo.ApplicationCommandInteractionData{Name: command},
+    }}
+}
+
+// componentInteraction returns a button press from a member
+func componentInteraction(guildID, customID string, member *discordgo.Member) *discordgo.InteractionCreate {
+    return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+        Type:    discordgo.InteractionMessageComponent,
+        GuildID: guildID,
+        Member:  member,
+        Data:    discordgo.MessageComponentInteractionData{CustomID: customID},
+    }}
+}
+
+// member returns a guild member with the given roles
+func member(id string, roles ...string) *discordgo.Member {
+    return &discordgo.Member{User: &discordgo.User{ID: id}, Roles: roles}
+}
+
+func TestCommandRoles(t *testing.T) {
+    b := permissionsBot()
+    tests := []struct {
+        guildID, command string
+        want             string
+    }{
+        {"g1", "sources", "[g1-editors]"},
+        {"g1", "digest", "[readers]"},
+        {"other", "sources", "[editors]"},
+        {"g2", "sources", "[]"},
+        {"g1", "news", "[]"},
+    }
+    for _, tt := range tests {
+        if got := fmt.Sprint(b.commandRoles(tt.guildID, tt.command)); got != tt.want {
+            t.Errorf("commandRoles(%q, %q) = %s, want %s", tt.guildID, tt.command, got, tt.want)
+        }
+    }
+}
+
+func TestCanUseCommand(t *testing.T) {
+    b := permissionsBot()
+    admin := member("a")
+    admin.Permissions = discordgo.PermissionAdministrator
+
+    tests := []struct {
+        name             string
+        guildID, command string
+        member           *discordgo.Member
+        want             bool
+    }{
+        {"unmapped command is open", "g1", "news", member("u"), true},
+        {"empty guild entry opens the command", "g2", "sources", member("u"), true},
+        {"mapped role", "other", "sources", member("u", "editors"), true},
+        {"guild entry overrides the * roles", "g1", "sources", member("u", "editors"), false},
+        {"guild role", "g1", "sources", member("u", "g1-editors"), true},
+        {"no mapped role", "other", "digest", member("u", "editors"), false},
+        {"owner", "g1", "sources", member("owner"), true},
+        {"server administrator", "g1", "sources", admin, true},
+        {"not in a guild", "other", "sources", nil, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            i := commandInteraction(tt.guildID, tt.command, tt.member)
+            if got := b.canUseCommand(i, tt.command); got != tt.want {
+                t.Errorf("canUseCommand = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+// A role that can open /sources manage or purge can also use their components
+func TestIsAdminComponents(t *testing.T) {
+    b := permissionsBot()
+    editor := member("u", "editors")
+
+    if !b.isAdmin(commandInteraction("other", "sources", editor)) {
+        t.Fatal("mapped role isn't admin for /sources")
+    }
+    tests := []struct {
+        customID string
+        member   *discordgo.Member
+        want     bool
+    }{
+        {purgePrefix + "1:confirm", editor, true},
+        {sourceManagePrefix + "select:0", editor, true},
+        {sourceManagePrefix + "select:0", member("u", "readers"), false},
+        {pagePrefix + "1:2", editor, false},
+        {pagePrefix + "1:2", member("owner"), true},
+    }
+    for _, tt := range tests {
+        if got := b.isAdmin(componentInteraction("other", tt.customID, tt.member)); got != tt.want {
+            t.Errorf("isAdmin for %s with roles %v = %v, want %v", tt.customID, tt.member.Roles, got, tt.want)
+        }
+    }
+}
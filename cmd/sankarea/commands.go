@@ -2,6 +2,7 @@
 package main
 
 import (
+    "context"
     "fmt"
     "strings"
     "time"
@@ -30,6 +31,12 @@ var (
                         {Name: "World", Value: CategoryWorld},
                     },
                 },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "query",
+                    Description: "Rank recent articles by how well they match these words",
+                    Required:    false,
+                },
             },
         },
         {
@@ -49,6 +56,183 @@ var (
                 },
             },
         },
+        {
+            Name:        "config",
+            Description: "Inspect configuration changes (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        "history",
+                    Description: "List recent config and sources changes",
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        "diff",
+                    Description: "Show what changed in a history entry",
+                    Options: []*discordgo.ApplicationCommandOption{
+                        {
+                            Type:        discordgo.ApplicationCommandOptionInteger,
+                            Name:        "n",
+                            Description: "History entry number",
+                            Required:    true,
+                        },
+                    },
+                },
+            },
+        },
+        {
+            Name:        "reload",
+            Description: "Reload configuration from disk (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        "sources",
+                    Description: "Re-read the sources file and report changes",
+                },
+            },
+        },
+        {
+            Name:        "alert",
+            Description: "Manage who breaking news alerts mention (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        "target",
+                    Description: "Set the role or user to mention",
+                    Options: []*discordgo.ApplicationCommandOption{
+                        {
+                            Type:        discordgo.ApplicationCommandOptionMentionable,
+                            Name:        "who",
+                            Description: "Role or user to mention",
+                            Required:    true,
+                        },
+                    },
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        "clear",
+                    Description: "Go back to the mention in the config",
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        "show",
+                    Description: "Show the alert target and keywords",
+                },
+            },
+        },
+        {
+            Name:        "mode",
+            Description: "Switch between posting articles and digest-only mode (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        PostingModeDigest,
+                    Description: "Only post the scheduled digest",
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionSubCommand,
+                    Name:        PostingModeStream,
+                    Description: "Post articles as they arrive",
+                },
+            },
+        },
+        {
+            Name:        "selftest",
+            Description: "Check Discord, the database, API keys and a sample feed (admin only)",
+        },
+        {
+            Name:        "validate",
+            Description: "Check schedules, channels, API keys, sources and categories in the config (admin only)",
+        },
+        {
+            Name:        "cleanup",
+            Description: "Delete the bot's old posts from a source (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "source",
+                    Description: "Source whose posts to delete",
+                    Required:    true,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "older_than",
+                    Description: "Only delete posts older than this, e.g. 12h, 7d or 4w",
+                    Required:    true,
+                },
+            },
+        },
+        {
+            Name:        "audit",
+            Description: "Export the audit log as JSON (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "from",
+                    Description: "First day, YYYY-MM-DD; default 30 days before to",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "to",
+                    Description: "Last day, YYYY-MM-DD; default today",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionUser,
+                    Name:        "actor",
+                    Description: "Only this user's actions",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "type",
+                    Description: "Only this kind of event",
+                    Required:    false,
+                    Choices: []*discordgo.ApplicationCommandOptionChoice{
+                        {Name: "Commands", Value: AuditTypeCommand},
+                        {Name: "Config changes", Value: AuditTypeConfig},
+                        {Name: "Source changes", Value: AuditTypeSources},
+                        {Name: "Audit log channel", Value: AuditTypeAdmin},
+                    },
+                },
+            },
+        },
+        {
+            Name:        "refactcheck",
+            Description: "Re-run fact checks over stored articles without reposting (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "source",
+                    Description: "Source name, or all; leave empty to see the last run's progress",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "since",
+                    Description: "Only articles published within this, e.g. 12h, 7d or 4w",
+                    Required:    false,
+                },
+            },
+        },
+        {
+            Name:        "preview-digest",
+            Description: "Preview the digest privately before it is sent (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "timeframe",
+                    Description: "Timeframe for the digest; default the scheduled digest's window",
+                    Required:    false,
+                    Choices: []*discordgo.ApplicationCommandOptionChoice{
+                        {Name: "Today", Value: "today"},
+                        {Name: "Yesterday", Value: "yesterday"},
+                        {Name: "Week", Value: "week"},
+                    },
+                },
+            },
+        },
         {
             Name:        "sources",
             Description: "Manage news sources",
@@ -61,21 +245,304 @@ var (
                     Choices: []*discordgo.ApplicationCommandOptionChoice{
                         {Name: "List", Value: "list"},
                         {Name: "Add", Value: "add"},
+                        {Name: "Update", Value: "update"},
                         {Name: "Remove", Value: "remove"},
                         {Name: "Enable", Value: "enable"},
                         {Name: "Disable", Value: "disable"},
+                        {Name: "Test HTML selector", Value: "testhtml"},
+                        {Name: "Export", Value: "export"},
+                        {Name: "Purge articles", Value: "purge"},
+                        {Name: "Force category", Value: "forcecategory"},
+                        {Name: "Manage", Value: "manage"},
+                        {Name: "Info", Value: "info"},
+                        {Name: "Boost fetching", Value: "boost"},
+                        {Name: "Import", Value: "import"},
+                    },
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "name",
+                    Description: "Source name (add, update, remove, purge, forcecategory, info, boost)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "category",
+                    Description: "Source category (add, update)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionInteger,
+                    Name:        "priority",
+                    Description: "Priority tier in digests; default normal (add, update)",
+                    Required:    false,
+                    Choices: []*discordgo.ApplicationCommandOptionChoice{
+                        {Name: "High", Value: SourcePriorityHigh},
+                        {Name: "Normal", Value: SourcePriorityNormal},
+                        {Name: "Low", Value: SourcePriorityLow},
+                    },
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionInteger,
+                    Name:        "max_posts",
+                    Description: "Most items posted per run; 0 uses max_posts_per_source (add, update)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionBoolean,
+                    Name:        "keep_history",
+                    Description: "Only disable the source and keep its articles (purge)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionBoolean,
+                    Name:        "force",
+                    Description: "Ignore the feed's item categories; default true (forcecategory)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "url",
+                    Description: "Feed URL (add, update) or page to scrape (testhtml)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "selector",
+                    Description: "CSS selector matching each article (testhtml)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "interval",
+                    Description: "Fetch every, e.g. 2m, or off to end the boost (boost)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "duration",
+                    Description: "How long the boost lasts, e.g. 30m or 6h (boost)",
+                    Required:    false,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "format",
+                    Description: "File format (export)",
+                    Required:    false,
+                    Choices: []*discordgo.ApplicationCommandOptionChoice{
+                        {Name: "YAML", Value: SourceExportYAML},
+                        {Name: "JSON", Value: SourceExportJSON},
+                        {Name: "OPML", Value: SourceExportOPML},
+                        {Name: "OPML with all settings", Value: SourceExportOPMLFull},
                     },
                 },
+                {
+                    Type:        discordgo.ApplicationCommandOptionAttachment,
+                    Name:        "file",
+                    Description: "OPML file to import (import)",
+                    Required:    false,
+                },
             },
         },
         {
             Name:        "ping",
             Description: "Check bot latency",
         },
+        {
+            Name:        "version",
+            Description: "Show bot version information",
+        },
+        {
+            Name:        "factcheck",
+            Description: "Fact-check an article",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "url",
+                    Description: "URL of the article to check",
+                    Required:    true,
+                },
+            },
+        },
         {
             Name:        "status",
             Description: "Show bot status and statistics",
         },
+        {
+            Name:        "mystatus",
+            Description: "Show everything the bot has stored about you",
+        },
+        {
+            Name:        "language",
+            Description: "Set the language used for labels in your news and digests",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "language",
+                    Description: "Preferred language",
+                    Required:    true,
+                    Choices: []*discordgo.ApplicationCommandOptionChoice{
+                        {Name: "English", Value: "en"},
+                        {Name: "Español", Value: "es"},
+                        {Name: "Français", Value: "fr"},
+                    },
+                },
+            },
+        },
+        {
+            Name:        "compare",
+            Description: "Compare how two sources cover a topic",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "source_a",
+                    Description: "First source",
+                    Required:    true,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "source_b",
+                    Description: "Second source",
+                    Required:    true,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "topic",
+                    Description: "Topic or keyword to look for",
+                    Required:    true,
+                },
+            },
+        },
+        {
+            Name:        "topsources",
+            Description: "Rank sources by articles and reliability",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionInteger,
+                    Name:        "days",
+                    Description: "Window in days (default 7, max 90)",
+                    Required:    false,
+                },
+            },
+        },
+        {
+            Name:        "why",
+            Description: "Explain why an article was or wasn't posted (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "url",
+                    Description: "Article URL",
+                    Required:    true,
+                },
+            },
+        },
+        {
+            Name:        "recommend",
+            Description: "Recent articles matching your reading, subscriptions and tracked keywords",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionInteger,
+                    Name:        "count",
+                    Description: "How many articles to suggest (default 5, max 10)",
+                    Required:    false,
+                },
+            },
+        },
+        {
+            Name:        "sourcehealth",
+            Description: "Show every source's health, uptime, errors and last fetch, worst first",
+        },
+        {
+            Name:        "formatpreview",
+            Description: "Preview an article in the compact, detailed and embed styles (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "url",
+                    Description: "Article URL",
+                    Required:    true,
+                },
+            },
+        },
+        {
+            Name:        "history",
+            Description: "Browse a source's most recent articles",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "source",
+                    Description: "Source name",
+                    Required:    true,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionInteger,
+                    Name:        "count",
+                    Description: "How many articles to show (default 10, max 50)",
+                    Required:    false,
+                },
+            },
+        },
+        {
+            Name:        "article-languages",
+            Description: "Only show articles in these languages",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "languages",
+                    Description: "Comma-separated language codes, e.g. en,es; leave empty to show all",
+                    Required:    false,
+                },
+            },
+        },
+        {
+            Name:        "snooze",
+            Description: "Hide a source from your news for a while",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "source",
+                    Description: "Name of the source to snooze",
+                    Required:    true,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "duration",
+                    Description: "How long to snooze for (e.g. 30m, 12h, 1d)",
+                    Required:    true,
+                },
+            },
+        },
+        {
+            Name:        "forgetme",
+            Description: "Delete all data the bot has stored about you",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionBoolean,
+                    Name:        "keep_warnings",
+                    Description: "Keep moderation warnings and audit log entries",
+                    Required:    false,
+                },
+            },
+        },
+        {
+            Name:        "forgetuser",
+            Description: "Delete all data stored about a user (admin only)",
+            Options: []*discordgo.ApplicationCommandOption{
+                {
+                    Type:        discordgo.ApplicationCommandOptionString,
+                    Name:        "id",
+                    Description: "ID of the user to forget",
+                    Required:    true,
+                },
+                {
+                    Type:        discordgo.ApplicationCommandOptionBoolean,
+                    Name:        "keep_warnings",
+                    Description: "Keep moderation warnings and audit log entries",
+                    Required:    false,
+                },
+            },
+        },
     }
 )
 
@@ -106,32 +573,48 @@ func (b *Bot) handleNewsCommand(s *discordgo.Session, i *discordgo.InteractionCr
 
     // Get command options
     options := i.ApplicationCommandData().Options
-    var category string
-    if len(options) > 0 {
-        category = options[0].StringValue()
-    }
+    category := getOptionString(options, "category")
+    query := strings.TrimSpace(getOptionString(options, "query"))
 
     // Fetch latest articles
     var articles []*NewsArticle
-    if category != "" {
+    switch {
+    case query != "":
+        // Rank recent articles by recency and relevance to the query
+        now := time.Now().UTC()
+        articles, err = b.database.GetArticlesByTimeRange(now.Add(-newsQueryWindow), now)
+        if category != "" {
+            articles = filterArticlesByCategory(articles, category)
+        }
+        articles = rankNewsByQuery(articles, query, sourcePriorities(), now)
+    case category != "":
         // Fetch articles for specific category
-        articles, err = b.database.GetArticlesByCategory(category, 10)
-    } else {
+        articles, err = b.database.GetArticlesByCategory(category, newsResultCount)
+    default:
         // Fetch latest articles across all categories
-        articles, err = b.database.GetLatestArticles(10)
+        articles, err = b.database.GetLatestArticles(newsResultCount)
     }
 
     if err != nil {
         return fmt.Errorf("failed to fetch articles: %v", err)
     }
+    articles = ApplyUserFilter(interactionUserID(i), articles)
+    if len(articles) > newsResultCount {
+        articles = articles[:newsResultCount]
+    }
+    if query != "" && len(articles) == 0 {
+        editResponse(s, i, fmt.Sprintf("No recent articles match \"%s\"", query))
+        return nil
+    }
 
     // Format articles into embeds
-    messages := b.formatter.FormatNewsDigest(articles)
+    messages := b.formatter.FormatNewsDigest(articles, userLanguage(interactionUserID(i)))
 
     // Send response
     for _, msg := range messages {
         _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
             Embeds: &msg.Embeds,
+            Files:  msg.Files,
         })
         if err != nil {
             return fmt.Errorf("failed to send response: %v", err)
@@ -158,30 +641,30 @@ func (b *Bot) handleDigestCommand(s *discordgo.Session, i *discordgo.Interaction
     }
 
     // Calculate time range
-    var startTime time.Time
-    switch timeframe {
-    case "today":
-        startTime = time.Now().UTC().Truncate(24 * time.Hour)
-    case "yesterday":
-        startTime = time.Now().UTC().Truncate(24 * time.Hour).Add(-24 * time.Hour)
-    case "week":
-        startTime = time.Now().UTC().Truncate(24 * time.Hour).Add(-7 * 24 * time.Hour)
-    }
+    startTime := digestStartTime(timeframe)
 
     // Fetch articles within timeframe
     articles, err := b.database.GetArticlesByTimeRange(startTime, time.Now().UTC())
     if err != nil {
         return fmt.Errorf("failed to fetch articles: %v", err)
     }
+    articles = ApplyUserFilter(interactionUserID(i), articles)
 
     // Generate digest
-    messages := b.formatter.FormatNewsDigest(articles)
-
-    // Send digest
-    for _, msg := range messages {
-        _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-            Embeds: &msg.Embeds,
-        })
+    digest := buildDigest(articles, startTime, time.Now().UTC(), userLanguage(interactionUserID(i)))
+
+    // Send digest, continuing in followups past the first message
+    for idx, msg := range digest.Messages() {
+        if idx == 0 {
+            _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+                Embeds: &msg.Embeds,
+                Files:  msg.Files,
+            })
+        } else {
+            _, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+                Embeds: msg.Embeds,
+            })
+        }
         if err != nil {
             return fmt.Errorf("failed to send digest: %v", err)
         }
@@ -190,6 +673,209 @@ func (b *Bot) handleDigestCommand(s *discordgo.Session, i *discordgo.Interaction
     return nil
 }
 
+// handleConfigCommand shows the config change history and field-level diffs
+func (b *Bot) handleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    if len(options) == 0 {
+        respondWithError(s, i, "Unknown config subcommand")
+        return nil
+    }
+
+    var content string
+    switch options[0].Name {
+    case "history":
+        snapshots, err := GetConfigHistory(15)
+        if err != nil {
+            respondWithError(s, i, "Failed to load config history")
+            return fmt.Errorf("failed to load config history: %v", err)
+        }
+        if len(snapshots) == 0 {
+            content = "No config changes recorded yet."
+            break
+        }
+        lines := []string{"📜 **Recent config changes**"}
+        for _, snapshot := range snapshots {
+            lines = append(lines, fmt.Sprintf("`#%d` %s <t:%d:R> by %s",
+                snapshot.ID, snapshot.Kind, snapshot.Time.Unix(), formatActor(snapshot.Actor)))
+        }
+        content = strings.Join(lines, "\n")
+
+    case "diff":
+        id := int(getOptionInt(options[0].Options, "n"))
+        snapshot, changes, err := DiffSnapshot(id)
+        if err != nil {
+            respondWithError(s, i, err.Error())
+            return nil
+        }
+        lines := []string{fmt.Sprintf("🔍 **#%d** %s changed <t:%d:f> by %s",
+            snapshot.ID, snapshot.Kind, snapshot.Time.Unix(), formatActor(snapshot.Actor))}
+        if len(changes) == 0 {
+            lines = append(lines, "No field changes.")
+        }
+        for _, change := range changes {
+            switch {
+            case change.Old == "":
+                lines = append(lines, fmt.Sprintf("➕ `%s` = %s", change.Field, change.New))
+            case change.New == "":
+                lines = append(lines, fmt.Sprintf("➖ `%s` (was %s)", change.Field, change.Old))
+            default:
+                lines = append(lines, fmt.Sprintf("✏️ `%s`: %s → %s", change.Field, change.Old, change.New))
+            }
+        }
+        content = truncateString(strings.Join(lines, "\n"), MaxMessageLength)
+
+    default:
+        respondWithError(s, i, "Unknown config subcommand")
+        return nil
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// formatActor renders who made a change
+func formatActor(actor string) string {
+    if actor == "" {
+        return "system"
+    }
+    return "<@" + actor + ">"
+}
+
+// handleReloadCommand reloads configuration files on demand
+func (b *Bot) handleReloadCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    if len(options) == 0 || options[0].Name != "sources" {
+        respondWithError(s, i, "Unknown reload target")
+        return nil
+    }
+
+    diff, err := b.scheduler.ReloadSources()
+    if err != nil {
+        respondWithError(s, i, fmt.Sprintf("Failed to reload sources: %v", err))
+        return fmt.Errorf("failed to reload sources: %v", err)
+    }
+    b.logger.Info("Sources reloaded by %s: %s", interactionUserID(i), formatSourceDiff(diff))
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: fmt.Sprintf("🔄 Reloaded %d sources\n%s", len(b.scheduler.GetSources()), formatSourceDiff(diff)),
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// formatSourceDiff renders a source reload diff for humans
+func formatSourceDiff(diff *SourceDiff) string {
+    if diff.Empty() {
+        return "No changes"
+    }
+
+    var lines []string
+    if len(diff.Added) > 0 {
+        lines = append(lines, "➕ Added: "+strings.Join(diff.Added, ", "))
+    }
+    if len(diff.Removed) > 0 {
+        lines = append(lines, "➖ Removed: "+strings.Join(diff.Removed, ", "))
+    }
+    if len(diff.Changed) > 0 {
+        lines = append(lines, "✏️ Changed: "+strings.Join(diff.Changed, ", "))
+    }
+    return strings.Join(lines, "\n")
+}
+
+// digestStartTime returns the start of the digest window for a timeframe option
+func digestStartTime(timeframe string) time.Time {
+    today := time.Now().UTC().Truncate(24 * time.Hour)
+    switch timeframe {
+    case "yesterday":
+        return today.Add(-24 * time.Hour)
+    case "week":
+        return today.Add(-7 * 24 * time.Hour)
+    default:
+        return today
+    }
+}
+
+// handlePreviewDigestCommand builds the scheduled digest and shows it only to the invoker
+func (b *Bot) handlePreviewDigestCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    // Without a timeframe, preview what the next scheduled digest would cover
+    start, end := b.scheduledDigestWindow()
+    timeframe := getOptionString(i.ApplicationCommandData().Options, "timeframe")
+    if timeframe != "" {
+        start = digestStartTime(timeframe)
+    } else {
+        timeframe = fmt.Sprintf("last %dh", b.config.ScheduledDigest.Hours)
+    }
+
+    digest, err := b.channelDigest(start, end)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to generate digest")
+        return fmt.Errorf("failed to generate digest preview: %v", err)
+    }
+
+    content := fmt.Sprintf("👀 Digest preview (%s): %d articles, %d embeds", timeframe, digest.TotalNews, len(digest.Embeds))
+    if problems := digestLimitProblems(digest.Embeds); len(problems) > 0 {
+        content += "\n⚠️ " + strings.Join(problems, "\n⚠️ ")
+    }
+
+    // Split into messages the way the scheduled digest is posted
+    messages := digest.Messages()
+    if len(messages) == 0 {
+        editResponse(s, i, content)
+        return nil
+    }
+    for idx, msg := range messages {
+        if idx == 0 {
+            _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+                Content: &content,
+                Embeds:  &msg.Embeds,
+                Files:   msg.Files,
+            })
+        } else {
+            _, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+                Embeds: msg.Embeds,
+                Flags:  discordgo.MessageFlagsEphemeral,
+            })
+        }
+        if err != nil {
+            return fmt.Errorf("failed to send digest preview: %v", err)
+        }
+    }
+
+    return nil
+}
+
 func (b *Bot) handleSourcesCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
     options := i.ApplicationCommandData().Options
     if len(options) == 0 {
@@ -203,26 +889,56 @@ func (b *Bot) handleSourcesCommand(s *discordgo.Session, i *discordgo.Interactio
         return b.handleListSources(s, i)
     case "add":
         return b.handleAddSource(s, i)
+    case "update":
+        return b.handleUpdateSource(s, i)
     case "remove":
         return b.handleRemoveSource(s, i)
     case "enable":
         return b.handleEnableSource(s, i)
     case "disable":
         return b.handleDisableSource(s, i)
+    case "testhtml":
+        return b.handleTestHTMLSource(s, i)
+    case "export":
+        return b.handleExportSources(s, i)
+    case "purge":
+        return b.handlePurgeSource(s, i)
+    case "forcecategory":
+        return b.handleForceCategorySource(s, i)
+    case "manage":
+        return b.handleManageSources(s, i)
+    case "info":
+        return b.handleSourceInfo(s, i)
+    case "boost":
+        return b.handleBoostSource(s, i)
+    case "import":
+        return b.handleImportSources(s, i)
     default:
         return fmt.Errorf("unknown action: %s", action)
     }
 }
 
+// handleStatusCommand handles the /status command
 func (b *Bot) handleStatusCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-    // Create status embed
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Embeds: []*discordgo.MessageEmbed{b.statusEmbed()},
+        },
+    })
+}
+
+// statusEmbed builds the status report shared by /status and !status
+func (b *Bot) statusEmbed() *discordgo.MessageEmbed {
+    stats := b.scheduler.GetStats()
+
     embed := &discordgo.MessageEmbed{
         Title: "📊 Bot Status",
-        Color: 0x7289DA,
+        Color: 0x43B581,
         Fields: []*discordgo.MessageEmbedField{
             {
                 Name:   "Uptime",
-                Value:  b.GetUptime().Round(time.Second).String(),
+                Value:  time.Since(b.startTime).Round(time.Second).String(),
                 Inline: true,
             },
             {
@@ -231,14 +947,32 @@ func (b *Bot) handleStatusCommand(s *discordgo.Session, i *discordgo.Interaction
                 Inline: true,
             },
             {
-                Name:   "Memory Usage",
-                Value:  "TODO", // Implement memory usage tracking
+                Name:   "Articles Fetched",
+                Value:  fmt.Sprintf("%d", stats.ArticleCount),
                 Inline: true,
             },
+            {
+                Name:   "Last Update",
+                Value:  stats.LastUpdate.Format("2006-01-02 15:04:05 MST"),
+                Inline: false,
+            },
         },
         Timestamp: time.Now().Format(time.RFC3339),
     }
 
+    // Show which instance this is when several share the database
+    if leadership := b.leader.Status(); leadership.Enabled {
+        value := fmt.Sprintf("%s · `%s`", leadership.Role(), leadership.InstanceID)
+        if !leadership.Leader && leadership.Holder != "" {
+            value += fmt.Sprintf("\nLeader: `%s`", leadership.Holder)
+        }
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "Instance",
+            Value:  value,
+            Inline: true,
+        })
+    }
+
     // Add source statistics
     sources, err := b.database.GetSources()
     if err == nil {
@@ -255,13 +989,70 @@ func (b *Bot) handleStatusCommand(s *discordgo.Session, i *discordgo.Interaction
         })
     }
 
-    // Send response
-    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-        Type: discordgo.InteractionResponseChannelMessageWithSource,
-        Data: &discordgo.InteractionResponseData{
-            Embeds: []*discordgo.MessageEmbed{embed},
+    if summary := unreliableDateSummary(); summary != "" {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "Unreliable Feed Dates",
+            Value:  truncateString(summary, 1024),
+            Inline: false,
+        })
+    }
+
+    // Add error information if there are any
+    if stats.LastError != "" {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "Last Error",
+            Value:  fmt.Sprintf("%s (%d errors)", stats.LastError, stats.ErrorCount),
+            Inline: false,
+        })
+        embed.Color = 0xF04747 // Red color for error state
+    }
+
+    return embed
+}
+
+// handlePingCommand reports gateway heartbeat latency and a REST round-trip
+func (b *Bot) handlePingCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+    }); err != nil {
+        return fmt.Errorf("failed to acknowledge ping: %v", err)
+    }
+
+    // Time a lightweight API call for the REST latency
+    restStatus := ""
+    start := time.Now()
+    if _, err := s.User("@me"); err != nil {
+        restStatus = " (request failed)"
+    }
+    rest := time.Since(start)
+
+    gateway := "not measured yet"
+    if heartbeat := s.HeartbeatLatency(); heartbeat > 0 {
+        gateway = fmt.Sprintf("%dms", heartbeat.Milliseconds())
+    }
+
+    embed := &discordgo.MessageEmbed{
+        Title: "🏓 Pong!",
+        Color: 0x7289DA,
+        Fields: []*discordgo.MessageEmbedField{
+            {
+                Name:   "Gateway Heartbeat",
+                Value:  gateway,
+                Inline: true,
+            },
+            {
+                Name:   "REST Round-Trip",
+                Value:  fmt.Sprintf("%dms%s", rest.Milliseconds(), restStatus),
+                Inline: true,
+            },
         },
+        Timestamp: time.Now().Format(time.RFC3339),
+    }
+
+    _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+        Embeds: &[]*discordgo.MessageEmbed{embed},
     })
+    return err
 }
 
 // Source management handlers
@@ -292,7 +1083,11 @@ func (b *Bot) handleListSources(s *discordgo.Session, i *discordgo.InteractionCr
             if source.Paused {
                 status = "❌"
             }
-            sourceList.WriteString(fmt.Sprintf("%s %s\n", status, source.Name))
+            name := source.Name
+            if source.ForceCategory {
+                name += " 🔒"
+            }
+            sourceList.WriteString(fmt.Sprintf("%s %s\n", status, name))
         }
 
         embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
@@ -310,80 +1105,64 @@ func (b *Bot) handleListSources(s *discordgo.Session, i *discordgo.InteractionCr
     })
 }
 
-// TODO: Implement other source management handlers
-func (b *Bot) handleAddSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-    return fmt.Errorf("not implemented")
-}
-
-func (b *Bot) handleRemoveSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-    return fmt.Errorf("not implemented")
-}
-
-func (b *Bot) handleEnableSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-    return fmt.Errorf("not implemented")
-}
-
-func (b *Bot) handleDisableSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-    return fmt.Errorf("not implemented")
-}
-
-// Adding to the existing commands.go file
-
 // handleAddSource handles adding a new news source
 func (b *Bot) handleAddSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-    // Check for required options
     options := i.ApplicationCommandData().Options
-    if len(options) < 3 { // We need name, URL, and category
-        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-            Type: discordgo.InteractionResponseChannelMessageWithSource,
-            Data: &discordgo.InteractionResponseData{
-                Content: "❌ Required parameters: name, URL, and category",
-                Flags:   discordgo.MessageFlagsEphemeral,
-            },
-        })
+    name := strings.TrimSpace(getOptionString(options, "name"))
+    url := strings.TrimSpace(getOptionString(options, "url"))
+    category := strings.ToLower(strings.TrimSpace(getOptionString(options, "category")))
+    if name == "" || url == "" || category == "" {
+        respondWithError(s, i, "❌ Required parameters: name, URL, and category")
+        return nil
     }
+    priority := int(getOptionInt(options, "priority"))
+    maxPosts := int(getOptionInt(options, "max_posts"))
 
-    // Create new source
-    source := &NewsSource{
-        Name:      options[1].StringValue(),
-        URL:       options[2].StringValue(),
-        Category:  options[3].StringValue(),
-        FactCheck: true, // Enable fact-checking by default
-        Paused:    false,
+    if err := validateSourceURL(url); err != nil {
+        respondWithError(s, i, fmt.Sprintf("❌ Invalid URL: %v", err))
+        return nil
     }
-
-    // Validate URL format
-    if !isValidURL(source.URL) {
-        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-            Type: discordgo.InteractionResponseChannelMessageWithSource,
-            Data: &discordgo.InteractionResponseData{
-                Content: "❌ Invalid URL format",
-                Flags:   discordgo.MessageFlagsEphemeral,
-            },
-        })
+    if !isValidCategory(category) {
+        respondWithError(s, i, fmt.Sprintf("❌ Invalid category. Valid categories: %s", strings.Join(getValidCategories(), ", ")))
+        return nil
+    }
+    if priority != 0 && sourceTier(priority) != priority {
+        respondWithError(s, i, "❌ Priority must be between 1 (highest) and 3")
+        return nil
+    }
+    if maxPosts < 0 || maxPosts > MaxSourcePostLimit {
+        respondWithError(s, i, fmt.Sprintf("❌ Max posts must be between 0 (use the default) and %d", MaxSourcePostLimit))
+        return nil
     }
 
-    // Validate category
-    if !isValidCategory(source.Category) {
-        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-            Type: discordgo.InteractionResponseChannelMessageWithSource,
-            Data: &discordgo.InteractionResponseData{
-                Content: fmt.Sprintf("❌ Invalid category. Valid categories: %s", strings.Join(getValidCategories(), ", ")),
-                Flags:   discordgo.MessageFlagsEphemeral,
-            },
-        })
+    // Create new source
+    source := NewsSource{
+        Name:      name,
+        URL:       url,
+        Category:  category,
+        FactCheck: true, // Enable fact-checking by default
+        Priority:  priority,
+        MaxPosts:  maxPosts,
+        Added:     time.Now(),
+        AddedBy:   interactionUserID(i),
     }
 
-    // Save to database
-    if err := b.database.SaveSource(source); err != nil {
-        b.logger.Error("Failed to save source: %v", err)
-        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-            Type: discordgo.InteractionResponseChannelMessageWithSource,
-            Data: &discordgo.InteractionResponseData{
-                Content: "❌ Failed to save source",
-                Flags:   discordgo.MessageFlagsEphemeral,
-            },
-        })
+    // Add and save, rejecting duplicates
+    err := UpdateSources(interactionUserID(i), func(sources []NewsSource) ([]NewsSource, error) {
+        for _, existing := range sources {
+            if strings.EqualFold(existing.Name, name) {
+                return nil, errSourceExists
+            }
+        }
+        return append(sources, source), nil
+    })
+    if err == errSourceExists {
+        respondWithError(s, i, "❌ A source with this name already exists")
+        return nil
+    }
+    if err != nil {
+        respondWithError(s, i, "❌ Failed to save source")
+        return fmt.Errorf("failed to add source %s: %v", name, err)
     }
 
     // Create success embed
@@ -402,6 +1181,11 @@ func (b *Bot) handleAddSource(s *discordgo.Session, i *discordgo.InteractionCrea
                 Value:  source.Category,
                 Inline: true,
             },
+            {
+                Name:   "Priority",
+                Value:  fmt.Sprint(sourceTier(source.Priority)),
+                Inline: true,
+            },
             {
                 Name:   "URL",
                 Value:  source.URL,
@@ -419,6 +1203,79 @@ func (b *Bot) handleAddSource(s *discordgo.Session, i *discordgo.InteractionCrea
     })
 }
 
+// handleUpdateSource changes the URL, category, priority or post limit of an
+// existing source, leaving options that weren't given as they are
+func (b *Bot) handleUpdateSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    options := i.ApplicationCommandData().Options
+    name := strings.TrimSpace(getOptionString(options, "name"))
+    if name == "" {
+        respondWithError(s, i, "❌ Please specify the source name to update")
+        return nil
+    }
+
+    // Validate new values before touching the file
+    url := strings.TrimSpace(getOptionString(options, "url"))
+    if url != "" {
+        if err := validateSourceURL(url); err != nil {
+            respondWithError(s, i, fmt.Sprintf("❌ Invalid URL: %v", err))
+            return nil
+        }
+    }
+    category := strings.ToLower(strings.TrimSpace(getOptionString(options, "category")))
+    if category != "" && !isValidCategory(category) {
+        respondWithError(s, i, fmt.Sprintf("❌ Invalid category. Valid categories: %s", strings.Join(getValidCategories(), ", ")))
+        return nil
+    }
+    priority := int(getOptionInt(options, "priority"))
+    if priority != 0 && sourceTier(priority) != priority {
+        respondWithError(s, i, "❌ Priority must be between 1 (highest) and 3")
+        return nil
+    }
+    maxPosts, setMaxPosts := getOptionIntValue(options, "max_posts")
+    if setMaxPosts && (maxPosts < 0 || maxPosts > MaxSourcePostLimit) {
+        respondWithError(s, i, fmt.Sprintf("❌ Max posts must be between 0 (use the default) and %d", MaxSourcePostLimit))
+        return nil
+    }
+
+    // Find and update source
+    err := UpdateSources(interactionUserID(i), func(sources []NewsSource) ([]NewsSource, error) {
+        for idx := range sources {
+            if !strings.EqualFold(sources[idx].Name, name) {
+                continue
+            }
+            if url != "" {
+                sources[idx].URL = url
+            }
+            if category != "" {
+                sources[idx].Category = category
+            }
+            if priority != 0 {
+                sources[idx].Priority = priority
+            }
+            if setMaxPosts {
+                sources[idx].MaxPosts = int(maxPosts)
+            }
+            return sources, nil
+        }
+        return nil, errSourceNotFound
+    })
+    if err == errSourceNotFound {
+        respondWithError(s, i, "❌ Source not found")
+        return nil
+    }
+    if err != nil {
+        respondWithError(s, i, "❌ Failed to save source")
+        return fmt.Errorf("failed to update source %s: %v", name, err)
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: fmt.Sprintf("✅ Updated source **%s**", name),
+        },
+    })
+}
+
 // handleRemoveSource handles removing a news source
 func (b *Bot) handleRemoveSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
     options := i.ApplicationCommandData().Options
@@ -579,22 +1436,22 @@ func getValidCategories() []string {
     }
 }
 
-// Continue adding after the previous command handlers
-
-func handleFactCheckCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+// handleFactCheckCommand handles the /factcheck command
+func (b *Bot) handleFactCheckCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
     options := i.ApplicationCommandData().Options
     if len(options) < 1 {
         respondWithError(s, i, "Please provide a URL to fact-check")
-        return
+        return nil
     }
 
     // Acknowledge the interaction immediately as fact-checking might take time
-    s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+    if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
         Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-    })
+    }); err != nil {
+        return fmt.Errorf("failed to acknowledge fact check: %v", err)
+    }
 
     url := options[0].StringValue()
-    factChecker := NewFactChecker()
     
     // Create a mock article for fact checking
     article := &NewsArticle{
@@ -602,11 +1459,11 @@ func handleFactCheckCommand(s *discordgo.Session, i *discordgo.InteractionCreate
         FetchedAt: time.Now(),
     }
 
-    result, err := factChecker.CheckArticle(context.Background(), article)
+    result, err := b.factChecker.CheckArticle(context.Background(), article)
     if err != nil {
         Logger().Printf("Fact-check error: %v", err)
         editResponse(s, i, "❌ Failed to perform fact-check")
-        return
+        return nil
     }
 
     // Create fact-check embed
@@ -661,95 +1518,7 @@ func handleFactCheckCommand(s *discordgo.Session, i *discordgo.InteractionCreate
     }
 
     editResponseWithEmbed(s, i, embed)
-}
-
-func handleDigestCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-    // Acknowledge the interaction
-    s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-    })
-
-    period := "daily" // default
-    if len(i.ApplicationCommandData().Options) > 0 {
-        period = i.ApplicationCommandData().Options[0].StringValue()
-    }
-
-    var startTime time.Time
-    switch period {
-    case "weekly":
-        startTime = time.Now().UTC().AddDate(0, 0, -7)
-    default: // daily
-        startTime = time.Now().UTC().AddDate(0, 0, -1)
-    }
-
-    articles, err := state.DB.GetArticlesByTimeRange(startTime, time.Now().UTC())
-    if err != nil {
-        Logger().Printf("Failed to fetch articles for digest: %v", err)
-        editResponse(s, i, "❌ Failed to generate digest")
-        return
-    }
-
-    if len(articles) == 0 {
-        editResponse(s, i, "ℹ️ No articles found for the selected period")
-        return
-    }
-
-    // Group articles by category
-    categories := make(map[string][]*NewsArticle)
-    for _, article := range articles {
-        categories[article.Category] = append(categories[article.Category], article)
-    }
-
-    // Create summary embed
-    summaryEmbed := &discordgo.MessageEmbed{
-        Title:       fmt.Sprintf("📰 %s News Digest", strings.Title(period)),
-        Description: fmt.Sprintf("News summary for %s", time.Now().Format("2006-01-02 15:04 MST")),
-        Color:       0x7289DA,
-        Fields:      make([]*discordgo.MessageEmbedField, 0),
-        Footer: &discordgo.MessageEmbedFooter{
-            Text: fmt.Sprintf("Generated by Sankarea v%s | %s", VERSION, time.Now().Format(time.RFC3339)),
-        },
-    }
-
-    // Send summary first
-    for category, articles := range categories {
-        summaryEmbed.Fields = append(summaryEmbed.Fields, &discordgo.MessageEmbedField{
-            Name:   fmt.Sprintf("%s %s", getCategoryEmoji(category), category),
-            Value:  fmt.Sprintf("%d articles", len(articles)),
-            Inline: true,
-        })
-    }
-
-    editResponseWithEmbed(s, i, summaryEmbed)
-
-    // Send category details as follow-up messages
-    for category, articles := range categories {
-        embed := &discordgo.MessageEmbed{
-            Title: fmt.Sprintf("%s %s News", getCategoryEmoji(category), category),
-            Color: getCategoryColor(category),
-            Fields: make([]*discordgo.MessageEmbedField, 0),
-        }
-
-        for _, article := range articles {
-            reliability := "N/A"
-            if article.FactCheckResult != nil {
-                reliability = fmt.Sprintf("%s (%.2f)", 
-                    article.FactCheckResult.ReliabilityTier, 
-                    article.FactCheckResult.Score)
-            }
-
-            embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-                Name: article.Title,
-                Value: fmt.Sprintf("Source: %s\nReliability: %s\n[Read More](%s)",
-                    article.Source,
-                    reliability,
-                    article.URL),
-                Inline: false,
-            })
-        }
-
-        followUpMessage(s, i, embed)
-    }
+    return nil
 }
 
 func handleHelpCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -828,6 +1597,270 @@ func getCategoryColor(category string) int {
     }
 }
 
+// handleMyStatusCommand shows the invoking user all of their stored preferences
+func (b *Bot) handleMyStatusCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    userID := interactionUserID(i)
+
+    data, err := GetUserData(userID)
+    if err != nil {
+        return fmt.Errorf("failed to load user data: %v", err)
+    }
+
+    embed := &discordgo.MessageEmbed{
+        Title:     "Your Stored Preferences",
+        Color:     0x7289DA,
+        Fields:    []*discordgo.MessageEmbedField{},
+        Timestamp: time.Now().Format(time.RFC3339),
+    }
+
+    if data.Empty() {
+        embed.Description = "The bot has no stored preferences for you."
+    }
+
+    if data.Filter != nil {
+        var lines []string
+        for source, until := range data.Filter.SnoozedSources {
+            if time.Now().Before(until) {
+                lines = append(lines, fmt.Sprintf("Snoozed: %s until <t:%d:f>", source, until.Unix()))
+            }
+        }
+        if len(data.Filter.ExcludedSources) > 0 {
+            lines = append(lines, "Excluded sources: "+strings.Join(data.Filter.ExcludedSources, ", "))
+        }
+        if len(data.Filter.ExcludedCategories) > 0 {
+            lines = append(lines, "Excluded categories: "+strings.Join(data.Filter.ExcludedCategories, ", "))
+        }
+        if data.Filter.MinTrustScore > 0 {
+            lines = append(lines, fmt.Sprintf("Minimum trust score: %.2f", data.Filter.MinTrustScore))
+        }
+        if len(data.Filter.Languages) > 0 {
+            lines = append(lines, "Article languages: "+strings.Join(data.Filter.Languages, ", "))
+        }
+        if len(lines) == 0 {
+            lines = append(lines, "No active filters")
+        }
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:  "Filters",
+            Value: truncateString(strings.Join(lines, "\n"), 1024),
+        })
+    }
+
+    if len(data.Subscriptions) > 0 {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:  "Subscriptions",
+            Value: truncateString(strings.Join(data.Subscriptions, ", "), 1024),
+        })
+    }
+
+    if data.Language != "" {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "Language",
+            Value:  data.Language,
+            Inline: true,
+        })
+    }
+
+    if len(data.TrackedKeywords) > 0 {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:  "Tracked Keywords",
+            Value: truncateString(strings.Join(data.TrackedKeywords, ", "), 1024),
+        })
+    }
+
+    if len(data.ReadMarkers) > 0 {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "Read Articles",
+            Value:  fmt.Sprintf("%d", len(data.ReadMarkers)),
+            Inline: true,
+        })
+    }
+
+    if len(data.Warnings) > 0 {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "Warnings",
+            Value:  fmt.Sprintf("%d", len(data.Warnings)),
+            Inline: true,
+        })
+    }
+
+    if !data.Empty() {
+        embed.Footer = &discordgo.MessageEmbedFooter{Text: "Use /forgetme to delete this data"}
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Embeds: []*discordgo.MessageEmbed{embed},
+            Flags:  discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// handleLanguageCommand stores the invoking user's preferred label language
+func (b *Bot) handleLanguageCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    lang := normalizeLanguage(getOptionString(i.ApplicationCommandData().Options, "language"))
+    userID := interactionUserID(i)
+    if err := SetUserLanguage(userID, lang); err != nil {
+        respondWithError(s, i, "Failed to save language")
+        return fmt.Errorf("failed to set language for %s: %v", userID, err)
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: fmt.Sprintf("🌐 Language set to %s", lang),
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// handleArticleLanguagesCommand limits the invoking user's news to chosen article languages
+func (b *Bot) handleArticleLanguagesCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    var languages []string
+    for _, code := range strings.Split(getOptionString(i.ApplicationCommandData().Options, "languages"), ",") {
+        if code = strings.TrimSpace(code); code != "" {
+            languages = append(languages, normalizeLanguage(code))
+        }
+    }
+    languages = unique(languages)
+
+    userID := interactionUserID(i)
+    if err := SetArticleLanguages(userID, languages); err != nil {
+        respondWithError(s, i, "Failed to save article languages")
+        return fmt.Errorf("failed to set article languages for %s: %v", userID, err)
+    }
+
+    content := "🌐 Showing articles in all languages"
+    if len(languages) > 0 {
+        content = "🌐 Showing articles in: " + strings.Join(languages, ", ")
+    }
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// handleSnoozeCommand hides a source from the invoking user's news until the snooze expires
+func (b *Bot) handleSnoozeCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    options := i.ApplicationCommandData().Options
+    name := getOptionString(options, "source")
+
+    duration, err := parseSnoozeDuration(getOptionString(options, "duration"))
+    if err != nil {
+        respondWithError(s, i, err.Error())
+        return nil
+    }
+
+    // Match the source name case-insensitively so the snooze uses its canonical name
+    var source string
+    for _, src := range b.scheduler.GetSources() {
+        if strings.EqualFold(src.Name, name) {
+            source = src.Name
+            break
+        }
+    }
+    if source == "" {
+        respondWithError(s, i, fmt.Sprintf("Source '%s' not found", name))
+        return nil
+    }
+
+    until := time.Now().Add(duration)
+    if err := SnoozeSource(interactionUserID(i), source, until); err != nil {
+        respondWithError(s, i, "Failed to snooze source")
+        return fmt.Errorf("failed to snooze %s: %v", source, err)
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: fmt.Sprintf("😴 %s is snoozed until <t:%d:f>", source, until.Unix()),
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// parseSnoozeDuration parses durations like "30m", "12h" or "2d", capped at 30 days
+func parseSnoozeDuration(value string) (time.Duration, error) {
+    value = strings.TrimSpace(strings.ToLower(value))
+
+    var d time.Duration
+    var err error
+    if strings.HasSuffix(value, "d") {
+        var days int
+        if _, err = fmt.Sscanf(strings.TrimSuffix(value, "d"), "%d", &days); err == nil {
+            d = time.Duration(days) * 24 * time.Hour
+        }
+    } else {
+        d, err = time.ParseDuration(value)
+    }
+
+    if err != nil || d <= 0 {
+        return 0, fmt.Errorf("Invalid duration '%s', use e.g. 30m, 12h or 1d", value)
+    }
+    if d > 30*24*time.Hour {
+        return 0, fmt.Errorf("Snooze duration can be at most 30 days")
+    }
+    return d, nil
+}
+
+// handleForgetMeCommand deletes all stored data for the invoking user
+func (b *Bot) handleForgetMeCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    keepWarnings := getOptionBool(i.ApplicationCommandData().Options, "keep_warnings")
+    return b.forgetUser(s, i, interactionUserID(i), keepWarnings)
+}
+
+// handleForgetUserCommand lets admins delete all stored data for a given user
+func (b *Bot) handleForgetUserCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    userID := strings.TrimSpace(getOptionString(options, "id"))
+    userID = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(userID, "<@"), "!"), ">")
+    if userID == "" {
+        respondWithError(s, i, "A user ID is required")
+        return nil
+    }
+    if !isUserID(userID) {
+        respondWithError(s, i, "That doesn't look like a user ID or mention")
+        return nil
+    }
+
+    return b.forgetUser(s, i, userID, getOptionBool(options, "keep_warnings"))
+}
+
+// forgetUser removes a user from every per-user store and reports what was deleted
+func (b *Bot) forgetUser(s *discordgo.Session, i *discordgo.InteractionCreate, userID string, keepWarnings bool) error {
+    removed, err := DeleteUserData(userID, keepWarnings)
+    if err != nil {
+        respondWithError(s, i, "Failed to delete some data, please try again")
+        return fmt.Errorf("failed to delete data for user %s: %v", userID, err)
+    }
+
+    b.logger.Info("Deleted stored data for user %s at request of %s: %v", userID, interactionUserID(i), removed)
+
+    content := "No stored data was found."
+    if len(removed) > 0 {
+        content = "🗑️ Deleted: " + strings.Join(removed, ", ")
+    }
+    if keepWarnings {
+        content += "\nModeration warnings were kept."
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
 func editResponse(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
     s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
         Content: &content,
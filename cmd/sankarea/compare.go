@@ -0,0 +1,226 @@
+// cmd/sankarea/compare.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// compareArticlesPerSource caps how many articles each side of /compare shows
+const compareArticlesPerSource = 3
+
+// compareSide is one outlet's coverage of a topic
+type compareSide struct {
+    Source     string
+    Articles   []*NewsArticle
+    Sentiments []*SentimentAnalysis // parallel to Articles; nil entries when analysis is unavailable
+}
+
+// loadCompareSide finds a source's recent articles on a topic and analyzes their sentiment
+func (b *Bot) loadCompareSide(source, topic string) (*compareSide, error) {
+    articles, err := b.database.SearchArticles(source, topic, compareArticlesPerSource)
+    if err != nil {
+        return nil, err
+    }
+
+    side := &compareSide{Source: source, Articles: articles}
+    for _, article := range articles {
+        sentiment, err := AnalyzeArticleSentiment(&Article{
+            Title:     article.Title,
+            Content:   article.Content,
+            URL:       article.URL,
+            Source:    article.Source,
+            Timestamp: article.PublishedAt,
+        })
+        if err != nil {
+            b.logger.Debug("Sentiment unavailable for %s: %v", article.URL, err)
+        }
+        side.Sentiments = append(side.Sentiments, sentiment)
+    }
+    return side, nil
+}
+
+// articleTrust returns an article's fact-check score, or its source's reliability
+func articleTrust(article *NewsArticle) float64 {
+    if article.FactCheckResult != nil {
+        return article.FactCheckResult.Score
+    }
+    score, _ := DefaultFactChecker().getSourceReliabilityScore(article.Source)
+    return score
+}
+
+// averageTrust returns the mean trust score of a side's articles
+func (c *compareSide) averageTrust() float64 {
+    if len(c.Articles) == 0 {
+        return 0
+    }
+    total := 0.0
+    for _, article := range c.Articles {
+        total += articleTrust(article)
+    }
+    return total / float64(len(c.Articles))
+}
+
+// averageSentiment returns the mean sentiment score and whether any was available
+func (c *compareSide) averageSentiment() (float64, bool) {
+    total, n := 0.0, 0
+    for _, s := range c.Sentiments {
+        if s != nil {
+            total += s.Score
+            n++
+        }
+    }
+    if n == 0 {
+        return 0, false
+    }
+    return total / float64(n), true
+}
+
+// opinionated counts articles flagged as opinion rather than reporting
+func (c *compareSide) opinionated() int {
+    count := 0
+    for _, s := range c.Sentiments {
+        if s != nil && s.IsOpinionated {
+            count++
+        }
+    }
+    return count
+}
+
+// keywords returns the lowercased keywords found across a side's articles
+func (c *compareSide) keywords() map[string]bool {
+    set := make(map[string]bool)
+    for _, s := range c.Sentiments {
+        if s == nil {
+            continue
+        }
+        for _, k := range s.Keywords {
+            set[strings.ToLower(k)] = true
+        }
+    }
+    return set
+}
+
+// field renders one side's articles with their trust scores and sentiments
+func (c *compareSide) field() *discordgo.MessageEmbedField {
+    lines := make([]string, 0, len(c.Articles))
+    for idx, article := range c.Articles {
+        line := fmt.Sprintf("[%s](%s)\nTrust %.0f%%", truncateString(article.Title, 80), article.URL, articleTrust(article)*100)
+        if s := c.Sentiments[idx]; s != nil {
+            line += fmt.Sprintf(" · %s (%+.1f)", s.Sentiment, s.Score)
+        }
+        lines = append(lines, line)
+    }
+    if len(lines) == 0 {
+        lines = append(lines, "No recent articles on this topic")
+    }
+    return &discordgo.MessageEmbedField{
+        Name:   c.Source,
+        Value:  truncateString(strings.Join(lines, "\n\n"), 1024),
+        Inline: true,
+    }
+}
+
+// compareFraming describes how two sides' coverage differs
+func compareFraming(a, b *compareSide) string {
+    var lines []string
+
+    if len(a.Articles) > 0 && len(b.Articles) > 0 {
+        lines = append(lines, fmt.Sprintf("Average trust: %s %.0f%% vs %s %.0f%%",
+            a.Source, a.averageTrust()*100, b.Source, b.averageTrust()*100))
+    }
+
+    sa, okA := a.averageSentiment()
+    sb, okB := b.averageSentiment()
+    if okA && okB {
+        switch diff := sa - sb; {
+        case diff > 0.2:
+            lines = append(lines, fmt.Sprintf("%s frames it more positively (%+.1f vs %+.1f)", a.Source, sa, sb))
+        case diff < -0.2:
+            lines = append(lines, fmt.Sprintf("%s frames it more positively (%+.1f vs %+.1f)", b.Source, sb, sa))
+        default:
+            lines = append(lines, fmt.Sprintf("Similar tone (%+.1f vs %+.1f)", sa, sb))
+        }
+        lines = append(lines, fmt.Sprintf("Opinion pieces: %s %d, %s %d", a.Source, a.opinionated(), b.Source, b.opinionated()))
+    }
+
+    ka, kb := a.keywords(), b.keywords()
+    if only := keywordsOnlyIn(ka, kb); len(only) > 0 {
+        lines = append(lines, fmt.Sprintf("Only %s mentions: %s", a.Source, strings.Join(only, ", ")))
+    }
+    if only := keywordsOnlyIn(kb, ka); len(only) > 0 {
+        lines = append(lines, fmt.Sprintf("Only %s mentions: %s", b.Source, strings.Join(only, ", ")))
+    }
+
+    if len(lines) == 0 {
+        return "Not enough coverage to compare"
+    }
+    return strings.Join(lines, "\n")
+}
+
+// keywordsOnlyIn returns up to five keywords in a that are missing from b
+func keywordsOnlyIn(a, b map[string]bool) []string {
+    var only []string
+    for k := range a {
+        if !b[k] {
+            only = append(only, k)
+        }
+    }
+    sort.Strings(only)
+    if len(only) > 5 {
+        only = only[:5]
+    }
+    return only
+}
+
+// handleCompareCommand shows two sources' coverage of a topic side by side
+func (b *Bot) handleCompareCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    options := i.ApplicationCommandData().Options
+    sourceA := strings.TrimSpace(getOptionString(options, "source_a"))
+    sourceB := strings.TrimSpace(getOptionString(options, "source_b"))
+    topic := strings.TrimSpace(getOptionString(options, "topic"))
+    if sourceA == "" || sourceB == "" || topic == "" {
+        respondWithError(s, i, "Two sources and a topic are required")
+        return nil
+    }
+    if b.database == nil {
+        respondWithError(s, i, "Comparing coverage needs the article database")
+        return nil
+    }
+
+    // Sentiment analysis can take a while, so acknowledge first
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    sideA, err := b.loadCompareSide(sourceA, topic)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to search articles")
+        return fmt.Errorf("failed to load coverage for %s: %v", sourceA, err)
+    }
+    sideB, err := b.loadCompareSide(sourceB, topic)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to search articles")
+        return fmt.Errorf("failed to load coverage for %s: %v", sourceB, err)
+    }
+
+    editResponseWithEmbed(s, i, &discordgo.MessageEmbed{
+        Title:       fmt.Sprintf("⚖️ Coverage of \"%s\"", truncateString(topic, 100)),
+        Description: fmt.Sprintf("%s vs %s", sourceA, sourceB),
+        Color:       0x7289DA,
+        Fields: []*discordgo.MessageEmbedField{
+            sideA.field(),
+            sideB.field(),
+            {Name: "Framing", Value: truncateString(compareFraming(sideA, sideB), 1024)},
+        },
+        Timestamp: time.Now().Format(time.RFC3339),
+    })
+    return nil
+}
@@ -4,8 +4,10 @@ package main
 import (
     "encoding/json"
     "fmt"
+    "math"
     "os"
     "path/filepath"
+    "sync"
     "time"
 )
 
@@ -25,17 +27,126 @@ type Config struct {
     SourcesPath     string   `json:"sources_path"`
     CachePath       string   `json:"cache_path"`
     Categories      []string `json:"categories"`
+    UndatedItems    string   `json:"undated_items,omitempty"` // "now" dates undated items at fetch time, "skip" drops them
+    ProxyURL        string   `json:"proxy_url,omitempty"` // http://, https:// or socks5:// proxy for outbound requests
+
+    // UserAgentString replaces the User-Agent sent for feeds and article pages
+    UserAgentString string `json:"user_agent,omitempty"`
+
+    // DisableSourceSeeding stops a missing or empty sources file from being
+    // filled with the default sources on first run
+    DisableSourceSeeding bool `json:"disable_source_seeding,omitempty"`
+
+    // Category normalization: aliases map feed and source labels onto the
+    // canonical categories; uncategorized sources can be filed by the AI
+    CategoryAliases map[string]string `json:"category_aliases,omitempty"`
+    AutoCategorize  bool              `json:"auto_categorize,omitempty"`
+
+    // Full article text is extracted and stored; channels get a teaser
+    StoreFullContent bool `json:"store_full_content,omitempty"`
+    TeaserLength     int  `json:"teaser_length,omitempty"` // characters posted, default 300
+
+    // Article language filtering; untagged sources are detected per article
+    EnableMultiLanguage bool     `json:"enable_multi_language"`
+    SupportedLanguages  []string `json:"supported_languages,omitempty"` // e.g. ["en", "es"]
+
+    // Digest configuration
+    DigestOnly           bool    `json:"digest_only"`                      // suppress individual posts; only the digest is sent
+    DigestMaxSourceShare float64 `json:"digest_max_source_share"`          // max fraction of digest slots per source
+    DigestMaxArticles    int     `json:"digest_max_articles,omitempty"`    // articles listed across all categories, default 40
+    DigestCategoryShares string  `json:"digest_category_shares,omitempty"` // "equal" (default) or "priority"
+
+    // In-memory dedup caches (sent links, fact checks, fetch times)
+    DedupCacheSize     int    `json:"dedup_cache_size,omitempty"`      // max entries per cache; default 5000
+    DedupCacheTTLHours int    `json:"dedup_cache_ttl_hours,omitempty"` // default 48
+    DedupScope         string `json:"dedup_scope,omitempty"`           // "global" (default) or "channel" to allow mirror channels
+
+    // RepostCooldownHours is the minimum time before a link can be posted
+    // again, even after the dedup caches forget it; 0 disables it
+    RepostCooldownHours int `json:"repost_cooldown_hours,omitempty"`
+
+    // DigestTemplates overrides the digest layout with text/template sources
+    DigestTemplates DigestTemplateConfig `json:"digest_templates,omitempty"`
+
+    // DigestHeader adds a banner, intro and category chart to the digest
+    DigestHeader DigestHeaderConfig `json:"digest_header,omitempty"`
+
+    // Global posting floor: articles from sources trusted below it, or
+    // fact-checked as Low, are stored but never posted
+    MinTrustScore      float64 `json:"min_trust_score,omitempty"`       // 0-1; 0 disables the floor
+    TrustFloorAuditLog bool    `json:"trust_floor_audit_log,omitempty"` // note held articles in the audit log channel
 
     // Fact checking configuration
     EnableFactCheck bool    `json:"enable_fact_check"`
     FactCheckAPI    string `json:"fact_check_api,omitempty"`
     FactCheckKey    string `json:"fact_check_key,omitempty"`
 
+    // FactCheckBackends picks and weights the reliability backends; empty
+    // uses the heuristics plus every API that has a key
+    FactCheckBackends []FactCheckBackendConfig `json:"fact_check_backends,omitempty"`
+
+    // External API keys; features that need one are off without it
+    OpenAIAPIKey          string `json:"openai_api_key,omitempty"`
+    GoogleFactCheckAPIKey string `json:"google_fact_check_api_key,omitempty"`
+    ClaimBustersAPIKey    string `json:"claimbusters_api_key,omitempty"`
+
+    // Monitoring configuration
+    ErrorChannelID         string  `json:"error_channel_id,omitempty"`
+    AuditLogChannelID      string  `json:"audit_log_channel_id,omitempty"` // admin actions and moderation notices
+    SlowSourceThresholdMs  int     `json:"slow_source_threshold_ms"`
+    MinSourceUptimePercent float64 `json:"min_source_uptime_percent"`
+    StaleFeedMinutes       int     `json:"stale_feed_minutes"`      // degraded when no new article for this long
+    MaxFailingSourcePct    float64 `json:"max_failing_source_pct"` // unhealthy when more sources than this are erroring
+
+    // SourceRecoveryMinErrors posts a notice when a source fetches again
+    // after at least this many failures in a row; 0 disables
+    SourceRecoveryMinErrors int `json:"source_recovery_min_errors,omitempty"`
+
+    // SourceMetricsSmoothing is the weight (0-1] of each fetch in the
+    // per-source response time and uptime averages
+    SourceMetricsSmoothing float64 `json:"source_metrics_smoothing,omitempty"`
+
+    // NewsRelevanceWeight is the share (0-1] of relevance against recency
+    // when /news is given a query; default 0.5
+    NewsRelevanceWeight float64 `json:"news_relevance_weight,omitempty"`
+
+    // External error notifications
+    ErrorWebhookURL             string `json:"error_webhook_url,omitempty"`
+    ErrorWebhookFormat          string `json:"error_webhook_format,omitempty"`      // "generic", "slack" or "pagerduty"
+    ErrorWebhookRoutingKey      string `json:"error_webhook_routing_key,omitempty"` // PagerDuty integration key
+    ErrorWebhookMinSeverity     string `json:"error_webhook_min_severity,omitempty"`
+    ErrorWebhookDebounceMinutes int    `json:"error_webhook_debounce_minutes"` // suppress repeats of the same error
+
+    // Output configuration
+    DefaultFormatStyle  string `json:"default_format_style"`           // "compact", "detailed" or "embed"
+    MaxEmbedsPerMessage int    `json:"max_embeds_per_message"`         // article embeds batched per message, 1-10
+    DefaultLanguage     string `json:"default_language,omitempty"`     // language for channel posts, e.g. "en"
+    LocalesPath         string `json:"locales_path,omitempty"`         // directory of <lang>.json label files
+    SensitiveImageMode  string `json:"sensitive_image_mode,omitempty"` // "spoiler" or "omit" images on sensitive articles
+    EmbedTimestamp      string `json:"embed_timestamp,omitempty"`      // "published", "fetched" or "both"
+    AutoDetectSensitive bool   `json:"auto_detect_sensitive"`          // flag sensitive articles via the moderation endpoint
+    PaywallArchive      string `json:"paywall_archive,omitempty"`      // "none", "archive.org" or "archive.today" link on paywalled posts
+    ReadingWPM          int    `json:"reading_wpm,omitempty"`          // words per minute for reading time estimates
+    SuppressPreviews    bool   `json:"suppress_link_previews"`         // no Discord link previews on compact/detailed text posts
+
+    // ContentWarnings posts articles mentioning these keywords behind a
+    // spoiler with a content warning and without their image
+    ContentWarnings []ContentWarningRule `json:"content_warnings,omitempty"`
+
+    // OpenAI configuration
+    AI AIConfig `json:"ai"`
+
     // Dashboard configuration
     DashboardEnabled bool   `json:"dashboard_enabled"`
     DashboardPort   int    `json:"dashboard_port,omitempty"`
     DashboardHost   string `json:"dashboard_host,omitempty"`
 
+    // WebhookSecret signs inbound control webhooks; empty disables them
+    WebhookSecret string `json:"webhook_secret,omitempty"`
+
+    // AuditExportToken is the bearer token for /api/audit; empty disables it
+    AuditExportToken string `json:"audit_export_token,omitempty"`
+
     // Logging configuration
     LogPath      string `json:"log_path"`
     LogLevel     string `json:"log_level"`
@@ -45,8 +156,47 @@ type Config struct {
     StartTime time.Time `json:"-"` // Not stored in JSON
 }
 
+// AIConfig controls OpenAI model selection and daily spend limits
+type AIConfig struct {
+    Summarize        AITaskConfig `json:"summarize"`
+    Summarizer       string       `json:"summarizer,omitempty"`    // "openai", "local" or "extractive"; empty picks by API key
+    LocalLLMURL      string       `json:"local_llm_url,omitempty"` // OpenAI-compatible endpoint for the local summarizer
+    Analyze          AITaskConfig `json:"analyze"`
+    Categorize       AITaskConfig `json:"categorize"`
+    DailyTokenBudget int          `json:"daily_token_budget,omitempty"` // 0 means unlimited
+    DailyCostBudget  float64      `json:"daily_cost_budget,omitempty"`  // in USD, 0 means unlimited
+    CostPer1KTokens  float64      `json:"cost_per_1k_tokens,omitempty"`
+
+    // Summary reuse: hours a cached summary stays valid (-1 disables the
+    // cache), and whether to keep it when the article text changes
+    SummaryCacheHours int  `json:"summary_cache_hours,omitempty"`
+    SummaryKeepOnEdit bool `json:"summary_keep_on_edit,omitempty"`
+}
+
+// AITaskConfig holds the completion settings for a single AI task.
+// Temperature is a pointer so an explicit 0 isn't replaced by the default.
+type AITaskConfig struct {
+    Model       string   `json:"model"`
+    MaxTokens   int      `json:"max_tokens,omitempty"`
+    Temperature *float32 `json:"temperature,omitempty"`
+}
+
+// RequestTemperature returns the temperature to send with a completion
+// request. go-openai leaves out a zero temperature, which the API then
+// treats as 1, so 0 is sent as the smallest float above it.
+func (t AITaskConfig) RequestTemperature() float32 {
+    if t.Temperature == nil {
+        return 0
+    }
+    if *t.Temperature == 0 {
+        return math.SmallestNonzeroFloat32
+    }
+    return *t.Temperature
+}
+
 var (
-    cfg *Config
+    cfg             *Config
+    configSaveMutex sync.Mutex // serializes SaveConfig writers
     DefaultCategories = []string{
         "Technology",
         "Business",
@@ -115,6 +265,96 @@ func validateConfig(c *Config) error {
     if c.EnableFactCheck && c.FactCheckAPI == "" {
         return fmt.Errorf("fact check API is required when fact checking is enabled")
     }
+    if err := validateProxyURL(c.ProxyURL); err != nil {
+        return fmt.Errorf("invalid proxy_url: %v", err)
+    }
+    switch c.ErrorWebhookFormat {
+    case "", ErrorWebhookGeneric, ErrorWebhookSlack:
+    case ErrorWebhookPagerDuty:
+        if c.ErrorWebhookURL != "" && c.ErrorWebhookRoutingKey == "" {
+            return fmt.Errorf("error_webhook_routing_key is required for the pagerduty format")
+        }
+    default:
+        return fmt.Errorf("unknown error_webhook_format: %s", c.ErrorWebhookFormat)
+    }
+    switch c.DigestCategoryShares {
+    case "", DigestSharesEqual, DigestSharesPriority:
+    default:
+        return fmt.Errorf("unknown digest_category_shares: %s", c.DigestCategoryShares)
+    }
+    if c.DigestMaxArticles < 0 {
+        return fmt.Errorf("digest_max_articles must not be negative")
+    }
+    if c.SourceRecoveryMinErrors < 0 {
+        return fmt.Errorf("source_recovery_min_errors must not be negative")
+    }
+    if _, err := ParseDigestTemplates(c.DigestTemplates); err != nil {
+        return fmt.Errorf("invalid digest_templates: %v", err)
+    }
+    if c.SourceMetricsSmoothing < 0 || c.SourceMetricsSmoothing > 1 {
+        return fmt.Errorf("source_metrics_smoothing must be between 0 and 1")
+    }
+    if c.NewsRelevanceWeight < 0 || c.NewsRelevanceWeight > 1 {
+        return fmt.Errorf("news_relevance_weight must be between 0 and 1")
+    }
+    if c.MinTrustScore < 0 || c.MinTrustScore > 1 {
+        return fmt.Errorf("min_trust_score must be between 0 and 1")
+    }
+    if err := ValidateCategoryAliases(c.CategoryAliases); err != nil {
+        return fmt.Errorf("invalid category_aliases: %v", err)
+    }
+    if err := ValidateDigestHeader(c.DigestHeader); err != nil {
+        return fmt.Errorf("invalid digest_header: %v", err)
+    }
+    for _, b := range c.FactCheckBackends {
+        switch b.Name {
+        case BackendHeuristic, BackendGoogle, BackendClaimBuster:
+        default:
+            return fmt.Errorf("unknown fact_check_backends entry %q", b.Name)
+        }
+        if b.Weight < 0 {
+            return fmt.Errorf("fact_check_backends weight for %s must not be negative", b.Name)
+        }
+    }
+    switch c.AI.Summarizer {
+    case "", SummarizerOpenAI, SummarizerExtractive:
+    case SummarizerLocal:
+        if c.AI.LocalLLMURL == "" {
+            return fmt.Errorf("ai.local_llm_url is required for the local summarizer")
+        }
+    default:
+        return fmt.Errorf("ai.summarizer must be %q, %q or %q", SummarizerOpenAI, SummarizerLocal, SummarizerExtractive)
+    }
+    for name, task := range map[string]AITaskConfig{"summarize": c.AI.Summarize, "analyze": c.AI.Analyze, "categorize": c.AI.Categorize} {
+        if task.Temperature != nil && (*task.Temperature < 0 || *task.Temperature > 2) {
+            return fmt.Errorf("ai.%s.temperature must be between 0 and 2", name)
+        }
+    }
+    switch c.EmbedTimestamp {
+    case "", EmbedTimestampPublished, EmbedTimestampFetched, EmbedTimestampBoth:
+    default:
+        return fmt.Errorf("embed_timestamp must be %q, %q or %q", EmbedTimestampPublished, EmbedTimestampFetched, EmbedTimestampBoth)
+    }
+    if c.DedupScope != "" && c.DedupScope != DedupScopeGlobal && c.DedupScope != DedupScopeChannel {
+        return fmt.Errorf("dedup_scope must be %q or %q", DedupScopeGlobal, DedupScopeChannel)
+    }
+    if c.RepostCooldownHours < 0 {
+        return fmt.Errorf("repost_cooldown_hours must not be negative")
+    }
+    if c.UndatedItems != "" && c.UndatedItems != UndatedItemsNow && c.UndatedItems != UndatedItemsSkip {
+        return fmt.Errorf("undated_items must be %q or %q", UndatedItemsNow, UndatedItemsSkip)
+    }
+    if c.SensitiveImageMode != "" && c.SensitiveImageMode != SensitiveImageSpoiler && c.SensitiveImageMode != SensitiveImageOmit {
+        return fmt.Errorf("sensitive_image_mode must be %q or %q", SensitiveImageSpoiler, SensitiveImageOmit)
+    }
+    if err := validateContentWarnings(c.ContentWarnings); err != nil {
+        return err
+    }
+    switch c.PaywallArchive {
+    case "", PaywallArchiveNone, PaywallArchiveOrg, PaywallArchiveToday:
+    default:
+        return fmt.Errorf("paywall_archive must be %q, %q or %q", PaywallArchiveNone, PaywallArchiveOrg, PaywallArchiveToday)
+    }
     return nil
 }
 
@@ -144,6 +384,67 @@ func setDefaults(c *Config) {
     if c.DashboardHost == "" {
         c.DashboardHost = "localhost"
     }
+    if c.SlowSourceThresholdMs <= 0 {
+        c.SlowSourceThresholdMs = 5000
+    }
+    if c.MinSourceUptimePercent <= 0 {
+        c.MinSourceUptimePercent = 90
+    }
+    if c.StaleFeedMinutes <= 0 {
+        c.StaleFeedMinutes = 180
+    }
+    if c.MaxFailingSourcePct <= 0 {
+        c.MaxFailingSourcePct = 50
+    }
+    if c.DigestMaxSourceShare <= 0 || c.DigestMaxSourceShare > 1 {
+        c.DigestMaxSourceShare = 0.3
+    }
+    if c.DefaultFormatStyle == "" {
+        c.DefaultFormatStyle = FormatStyleEmbed
+    }
+    if c.MaxEmbedsPerMessage <= 0 || c.MaxEmbedsPerMessage > MaxEmbedsPerMessage {
+        c.MaxEmbedsPerMessage = MaxEmbedsPerMessage
+    }
+    if c.ErrorWebhookFormat == "" {
+        c.ErrorWebhookFormat = ErrorWebhookGeneric
+    }
+    if c.ErrorWebhookMinSeverity == "" {
+        c.ErrorWebhookMinSeverity = "error"
+    }
+    if c.ErrorWebhookDebounceMinutes <= 0 {
+        c.ErrorWebhookDebounceMinutes = 10
+    }
+    if c.UndatedItems == "" {
+        c.UndatedItems = UndatedItemsNow
+    }
+    if c.SensitiveImageMode == "" {
+        c.SensitiveImageMode = SensitiveImageSpoiler
+    }
+    if c.PaywallArchive == "" {
+        c.PaywallArchive = PaywallArchiveNone
+    }
+    if c.ReadingWPM <= 0 {
+        c.ReadingWPM = defaultReadingWPM
+    }
+    setTaskDefaults(&c.AI.Summarize, "gpt-3.5-turbo", 400, 0.3)
+    setTaskDefaults(&c.AI.Analyze, "gpt-3.5-turbo", 500, 0.2)
+    setTaskDefaults(&c.AI.Categorize, "gpt-3.5-turbo", 10, 0)
+    if c.AI.CostPer1KTokens <= 0 {
+        c.AI.CostPer1KTokens = 0.002
+    }
+}
+
+// setTaskDefaults fills in unset fields of an AI task configuration
+func setTaskDefaults(t *AITaskConfig, model string, maxTokens int, temperature float32) {
+    if t.Model == "" {
+        t.Model = model
+    }
+    if t.MaxTokens <= 0 {
+        t.MaxTokens = maxTokens
+    }
+    if t.Temperature == nil {
+        t.Temperature = &temperature
+    }
 }
 
 // createDirectories ensures all necessary directories exist
@@ -166,6 +467,14 @@ func createDirectories(c *Config) error {
 
 // SaveConfig saves the current configuration to file
 func SaveConfig(path string) error {
+    return SaveConfigBy(path, "")
+}
+
+// SaveConfigBy saves the configuration and records actor (a Discord user ID) in the config history
+func SaveConfigBy(path, actor string) error {
+    configSaveMutex.Lock()
+    defer configSaveMutex.Unlock()
+
     if cfg == nil {
         return fmt.Errorf("no configuration loaded")
     }
@@ -175,9 +484,13 @@ func SaveConfig(path string) error {
         return fmt.Errorf("failed to marshal config: %v", err)
     }
 
-    if err := os.WriteFile(path, data, 0644); err != nil {
+    if err := writeFileAtomic(path, data, 0644); err != nil {
         return fmt.Errorf("failed to write config file: %v", err)
     }
+    if err := recordSnapshot(SnapshotKindConfig, actor, cfg); err != nil {
+        Logger().Printf("Failed to record config history: %v", err)
+    }
+    auditTrail.Record(&AuditEvent{Actor: actor, Type: AuditTypeConfig, Action: "config saved", Target: path})
 
     return nil
 }
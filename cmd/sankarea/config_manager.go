@@ -21,6 +21,11 @@ type ConfigManager struct {
 	watcher        *fsnotify.Watcher
 	mutex          sync.RWMutex
 	onReload       func(*Config)
+
+	// Sources file watching
+	sourcesPath     string
+	sourcesModified time.Time
+	onSourcesReload func()
 }
 
 // NewConfigManager creates a new configuration manager
@@ -63,6 +68,26 @@ func (cm *ConfigManager) SetReloadHandler(handler func(*Config)) {
 	cm.onReload = handler
 }
 
+// WatchSources also watches the sources file and calls handler when it changes
+func (cm *ConfigManager) WatchSources(sourcesPath string, handler func()) error {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	sourcesPath = filepath.Clean(sourcesPath)
+	if dir := filepath.Dir(sourcesPath); dir != filepath.Dir(cm.configPath) {
+		if err := cm.watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch directory %s: %v", dir, err)
+		}
+	}
+
+	if fileInfo, err := os.Stat(sourcesPath); err == nil {
+		cm.sourcesModified = fileInfo.ModTime()
+	}
+	cm.sourcesPath = sourcesPath
+	cm.onSourcesReload = handler
+	return nil
+}
+
 // StartWatching starts watching for configuration changes
 func (cm *ConfigManager) StartWatching() {
 	go cm.watchForChanges()
@@ -85,9 +110,16 @@ func (cm *ConfigManager) watchForChanges() {
 				return
 			}
 
-			// Check if this is our config file
-			if event.Name == cm.configPath && (event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create) {
+			if event.Op&fsnotify.Write != fsnotify.Write && event.Op&fsnotify.Create != fsnotify.Create {
+				continue
+			}
+
+			// Check if this is our config or sources file
+			switch filepath.Clean(event.Name) {
+			case filepath.Clean(cm.configPath):
 				cm.checkAndReload()
+			case cm.sourcesPath:
+				cm.checkSources()
 			}
 
 		case err, ok := <-cm.watcher.Errors:
@@ -107,9 +139,36 @@ func (cm *ConfigManager) periodicCheck() {
 	for {
 		<-ticker.C
 		cm.checkAndReload()
+		cm.checkSources()
 	}
 }
 
+// checkSources calls the sources handler if the sources file changed since it was last seen
+func (cm *ConfigManager) checkSources() {
+	cm.mutex.Lock()
+	if cm.sourcesPath == "" || cm.onSourcesReload == nil {
+		cm.mutex.Unlock()
+		return
+	}
+
+	fileInfo, err := os.Stat(cm.sourcesPath)
+	if err != nil {
+		cm.mutex.Unlock()
+		Logger().Printf("Error checking sources file: %v", err)
+		return
+	}
+	if !fileInfo.ModTime().After(cm.sourcesModified) {
+		cm.mutex.Unlock()
+		return
+	}
+	cm.sourcesModified = fileInfo.ModTime()
+	handler := cm.onSourcesReload
+	cm.mutex.Unlock()
+
+	Logger().Printf("Sources file changed, reloading...")
+	handler()
+}
+
 // checkAndReload checks if the config file has changed and reloads it if necessary
 func (cm *ConfigManager) checkAndReload() {
 	cm.mutex.Lock()
@@ -26,6 +26,8 @@ const (
     StateBackupInterval = 1 * time.Hour
     
     // Rate limits
+    MaxConcurrentFeeds = 5
+    MaxSourcePostLimit = 25 // highest per-source max_posts override
     MaxRequestsPerMinute = 60
     MaxNewsUpdatesPerHour = 30
     MaxDigestsPerDay = 24
@@ -39,6 +41,8 @@ const (
     MaxMessageLength    = 2000
     MaxEmbedFields     = 25
     MaxEmbedLength     = 4096
+    MaxEmbedsPerMessage = 10
+    MaxMessageEmbedSize = 6000 // combined characters across all embeds in a message
     DefaultPrefix      = "!"
     
     // API-related constants
@@ -63,6 +67,13 @@ const (
     CategoryUtility   = "utility"
 )
 
+// Source priority tiers; lower values win when digest slots are limited
+const (
+    SourcePriorityHigh   = 1
+    SourcePriorityNormal = 2
+    SourcePriorityLow    = 3
+)
+
 // Permission levels
 const (
     PermissionUser  = iota
@@ -73,10 +84,11 @@ const (
 
 // Status codes
 const (
-    StatusOK       = "ok"
-    StatusDegraded = "degraded"
-    StatusError    = "error"
-    StatusStarting = "starting"
+    StatusOK        = "ok"
+    StatusDegraded  = "degraded"
+    StatusError     = "error"
+    StatusUnhealthy = "unhealthy"
+    StatusStarting  = "starting"
 )
 
 // Event types
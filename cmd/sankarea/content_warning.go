@@ -0,0 +1,129 @@
+// cmd/sankarea/content_warning.go
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+    "sync"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+)
+
+// ContentWarningRule puts articles mentioning any of its keywords behind a
+// content warning labelled with the rule's label
+type ContentWarningRule struct {
+    Label    string   `json:"label"`    // shown in the warning, e.g. "graphic violence"
+    Keywords []string `json:"keywords"` // words or phrases, matched case-insensitively as whole words
+}
+
+// compiledWarning is a content warning rule with its keywords as one pattern
+type compiledWarning struct {
+    label   string
+    pattern *regexp.Regexp
+}
+
+// contentWarnings caches the compiled content_warnings rules
+var contentWarnings struct {
+    mu    sync.Mutex
+    key   string
+    rules []compiledWarning
+}
+
+// compileWarningKeywords builds a case-insensitive pattern matching any of
+// the keywords as whole words. Go's \b only knows ASCII, so boundaries are
+// spelled out to work for accented words too.
+func compileWarningKeywords(keywords []string) (*regexp.Regexp, error) {
+    var quoted []string
+    for _, keyword := range keywords {
+        keyword = strings.TrimSpace(keyword)
+        if keyword == "" {
+            return nil, fmt.Errorf("empty keyword")
+        }
+        quoted = append(quoted, regexp.QuoteMeta(keyword))
+    }
+    if len(quoted) == 0 {
+        return nil, fmt.Errorf("no keywords")
+    }
+    return regexp.Compile(`(?i)(?:^|[^\p{L}\p{N}_])(` + strings.Join(quoted, "|") + `)(?:$|[^\p{L}\p{N}_])`)
+}
+
+// validateContentWarnings checks that every content warning rule compiles
+func validateContentWarnings(rules []ContentWarningRule) error {
+    for idx, rule := range rules {
+        if _, err := compileWarningKeywords(rule.Keywords); err != nil {
+            return fmt.Errorf("content_warnings rule %d: %v", idx+1, err)
+        }
+    }
+    return nil
+}
+
+// compiledContentWarnings returns the configured rules, compiling them again
+// only after the config changed
+func compiledContentWarnings() []compiledWarning {
+    if cfg == nil || len(cfg.ContentWarnings) == 0 {
+        return nil
+    }
+    var key strings.Builder
+    for _, rule := range cfg.ContentWarnings {
+        key.WriteString(rule.Label + "\x00" + strings.Join(rule.Keywords, "\x00") + "\x01")
+    }
+
+    contentWarnings.mu.Lock()
+    defer contentWarnings.mu.Unlock()
+    if contentWarnings.key == key.String() {
+        return contentWarnings.rules
+    }
+    var rules []compiledWarning
+    for _, rule := range cfg.ContentWarnings {
+        pattern, err := compileWarningKeywords(rule.Keywords)
+        if err != nil {
+            Logger().Printf("Skipping content warning %q: %v", rule.Label, err)
+            continue
+        }
+        rules = append(rules, compiledWarning{label: rule.Label, pattern: pattern})
+    }
+    contentWarnings.key = key.String()
+    contentWarnings.rules = rules
+    return rules
+}
+
+// keywordWarning returns the content warning for an item that mentions a
+// content_warnings keyword, or "". Matches are logged for review.
+func keywordWarning(item *gofeed.Item) string {
+    text := item.Title + "\n" + item.Description + "\n" + item.Content
+    for _, rule := range compiledContentWarnings() {
+        match := rule.pattern.FindStringSubmatch(text)
+        if match == nil {
+            continue
+        }
+        label := rule.label
+        if label == "" {
+            label = strings.ToLower(match[1])
+        }
+        Logger().Printf("Content warning %q on %s: matched %q", label, item.Link, match[1])
+        return label
+    }
+    return ""
+}
+
+// spoilerEmbeds hides the text of article embeds behind spoilers. Titles
+// can't hold spoilers, so the headline moves into the description.
+func spoilerEmbeds(embeds []*discordgo.MessageEmbed, reason string) {
+    for _, embed := range embeds {
+        description := embed.Description
+        if embed.Title != "" {
+            description = strings.TrimSpace("**" + embed.Title + "**\n" + description)
+            embed.Title = truncateString("⚠️ Content warning: "+reason, 256)
+        }
+        if description != "" {
+            embed.Description = "||" + truncateString(description, 4000) + "||"
+        }
+        for _, field := range embed.Fields {
+            if field.Value != "" {
+                field.Value = "||" + truncateString(field.Value, 1000) + "||"
+            }
+        }
+    }
+}
@@ -0,0 +1,59 @@
+// cmd/sankarea/cooldown.go
+package main
+
+import (
+    "sync"
+    "time"
+)
+
+// defaultCommandCooldowns applies to expensive commands when no cooldowns are configured
+var defaultCommandCooldowns = map[string]int{
+    "digest":    60,
+    "factcheck": 30,
+}
+
+// CooldownManager tracks per-user, per-command cooldowns in memory
+type CooldownManager struct {
+    mutex   sync.Mutex
+    expires map[string]time.Time
+}
+
+// NewCooldownManager creates an empty cooldown tracker
+func NewCooldownManager() *CooldownManager {
+    return &CooldownManager{
+        expires: make(map[string]time.Time),
+    }
+}
+
+// Acquire starts a cooldown for the user and command if none is active.
+// It returns the remaining wait and false when the user is still cooling down.
+func (cm *CooldownManager) Acquire(userID, command string, cooldown time.Duration) (time.Duration, bool) {
+    if cooldown <= 0 {
+        return 0, true
+    }
+
+    cm.mutex.Lock()
+    defer cm.mutex.Unlock()
+
+    key := userID + ":" + command
+    now := time.Now()
+    if until, ok := cm.expires[key]; ok && now.Before(until) {
+        return until.Sub(now), false
+    }
+
+    cm.expires[key] = now.Add(cooldown)
+    return 0, true
+}
+
+// Cleanup drops expired cooldown entries
+func (cm *CooldownManager) Cleanup() {
+    cm.mutex.Lock()
+    defer cm.mutex.Unlock()
+
+    now := time.Now()
+    for key, until := range cm.expires {
+        if !now.Before(until) {
+            delete(cm.expires, key)
+        }
+    }
+}
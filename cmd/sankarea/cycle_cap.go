@@ -0,0 +1,53 @@
+// cmd/sankarea/cycle_cap.go
+package main
+
+import (
+    "fmt"
+    "sort"
+)
+
+// StageCycleCap is the decision stage for articles held back by the cap
+const StageCycleCap = "cycle cap"
+
+// capCycleArticles keeps at most max_articles_per_cycle articles, preferring
+// higher-priority sources and then newer articles, and queues the rest for
+// the next cycle. The kept articles stay in their original order.
+func (s *Scheduler) capCycleArticles(articles []*NewsArticle, priorities map[string]int) []*NewsArticle {
+    limit := s.bot.config.MaxArticlesPerCycle
+    if limit <= 0 || len(articles) <= limit {
+        return articles
+    }
+
+    ranked := make([]*NewsArticle, len(articles))
+    copy(ranked, articles)
+    sort.SliceStable(ranked, func(i, j int) bool {
+        ti, tj := sourceTier(priorities[ranked[i].Source]), sourceTier(priorities[ranked[j].Source])
+        if ti != tj {
+            return ti < tj
+        }
+        return ranked[i].PublishedAt.After(ranked[j].PublishedAt)
+    })
+
+    keep := make(map[*NewsArticle]bool, limit)
+    for _, article := range ranked[:limit] {
+        keep[article] = true
+    }
+
+    kept := make([]*NewsArticle, 0, limit)
+    deferred := 0
+    for _, article := range articles {
+        if keep[article] {
+            kept = append(kept, article)
+            continue
+        }
+        if err := s.bot.database.SavePendingPost(article.ID, article.FetchedAt); err != nil {
+            s.bot.logger.Error("Failed to defer %s: %v", article.URL, err)
+            continue
+        }
+        traceDecision(article.URL, StageCycleCap, "deferred", fmt.Sprintf("over max_articles_per_cycle (%d); queued for the next cycle", limit))
+        deferred++
+    }
+
+    s.bot.logger.Warn("Cycle capped at %d articles: %d deferred to the next cycle", limit, deferred)
+    return kept
+}
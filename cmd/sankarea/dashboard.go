@@ -21,6 +21,7 @@ type Dashboard struct {
     templates  *template.Template
     metrics    *Metrics
     lastUpdate time.Time
+    refresh    func() error // runs a feed refresh for the signed webhook
 }
 
 // DashboardData represents the data passed to dashboard templates
@@ -31,44 +32,45 @@ type DashboardData struct {
     BuildTime    string
     LastUpdate   string
     HealthStatus string
+    Leader       LeaderStatus
 }
 
-var (
-    dashboard     *Dashboard
-    dashboardOnce sync.Once
-)
+// NewDashboard creates the dashboard server the bot starts
+func NewDashboard() (*Dashboard, error) {
+    // Parse templates
+    tmpl, err := template.ParseFS(dashboardTemplates, "templates/*.html")
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse dashboard templates: %v", err)
+    }
 
-// initDashboard initializes the dashboard server
-func initDashboard() error {
-    var err error
-    dashboardOnce.Do(func() {
-        // Parse templates
-        tmpl, err := template.ParseFS(dashboardTemplates, "templates/*.html")
-        if err != nil {
-            err = fmt.Errorf("failed to parse dashboard templates: %v", err)
-            return
-        }
-
-        dashboard = &Dashboard{
-            templates: tmpl,
-            metrics:   GetCurrentMetrics(),
-        }
-
-        // Initialize HTTP server
-        mux := http.NewServeMux()
-        mux.HandleFunc("/", dashboard.handleIndex)
-        mux.HandleFunc("/api/metrics", dashboard.handleMetrics)
-        mux.HandleFunc("/api/sources", dashboard.handleSources)
-        mux.HandleFunc("/api/health", dashboard.handleHealth)
-
-        dashboard.server = &http.Server{
-            Addr:         fmt.Sprintf(":%d", cfg.DashboardPort),
-            Handler:      mux,
-            ReadTimeout:  10 * time.Second,
-            WriteTimeout: 10 * time.Second,
-        }
-    })
-    return err
+    dashboard := &Dashboard{
+        templates: tmpl,
+        metrics:   GetCurrentMetrics(),
+    }
+
+    // Initialize HTTP server
+    dashboard.server = &http.Server{
+        Addr:         fmt.Sprintf(":%d", cfg.DashboardPort),
+        Handler:      dashboard.routes(),
+        ReadTimeout:  10 * time.Second,
+        WriteTimeout: 10 * time.Second,
+    }
+    return dashboard, nil
+}
+
+// routes returns the dashboard's page and API handlers
+func (d *Dashboard) routes() *http.ServeMux {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/", d.handleIndex)
+    mux.HandleFunc("/api/metrics", d.handleMetrics)
+    mux.HandleFunc("/api/metrics/history", d.handleMetricsHistory)
+    mux.HandleFunc("/api/sources", d.handleSources)
+    mux.HandleFunc("/api/sources/export", d.handleSourcesExport)
+    mux.HandleFunc("/api/factchecks", d.handleFactChecks)
+    mux.HandleFunc("/api/health", d.handleHealth)
+    mux.HandleFunc("/api/webhook/refresh", d.handleWebhookRefresh)
+    mux.HandleFunc("/api/audit", d.handleAuditExport)
+    return mux
 }
 
 // Start starts the dashboard server
@@ -92,6 +94,13 @@ func (d *Dashboard) UpdateMetrics(metrics *Metrics) error {
     return nil
 }
 
+// SetRefreshFunc sets what the refresh webhook runs
+func (d *Dashboard) SetRefreshFunc(refresh func() error) {
+    d.mutex.Lock()
+    defer d.mutex.Unlock()
+    d.refresh = refresh
+}
+
 // HTTP Handlers
 
 func (d *Dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
@@ -103,6 +112,7 @@ func (d *Dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
         BuildTime:    buildTime,
         LastUpdate:   d.lastUpdate.Format(time.RFC3339),
         HealthStatus: getHealthStatus(),
+        Leader:       leaderElection.Status(),
     }
     d.mutex.RUnlock()
 
@@ -124,6 +134,32 @@ func (d *Dashboard) handleMetrics(w http.ResponseWriter, r *http.Request) {
     }
 }
 
+// handleMetricsHistory returns article, error and API call counts over a
+// range of 24h, 7d (the default), 30d or 90d
+func (d *Dashboard) handleMetricsHistory(w http.ResponseWriter, r *http.Request) {
+    rangeName := r.URL.Query().Get("range")
+    if rangeName == "" {
+        rangeName = "7d"
+    }
+    if _, ok := metricsRanges[rangeName]; !ok {
+        http.Error(w, "range must be 24h, 7d, 30d or 90d", http.StatusBadRequest)
+        return
+    }
+
+    points, err := analytics.History(rangeName)
+    if err != nil {
+        http.Error(w, "Failed to load metrics history", http.StatusInternalServerError)
+        Logger().Printf("Failed to load metrics history: %v", err)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    if err := json.NewEncoder(w).Encode(points); err != nil {
+        http.Error(w, "Failed to encode metrics history", http.StatusInternalServerError)
+        Logger().Printf("Failed to encode metrics history: %v", err)
+    }
+}
+
 func (d *Dashboard) handleSources(w http.ResponseWriter, r *http.Request) {
     sources, err := LoadSources()
     if err != nil {
@@ -132,6 +168,11 @@ func (d *Dashboard) handleSources(w http.ResponseWriter, r *http.Request) {
         return
     }
 
+    // Never expose feed credentials
+    for idx := range sources {
+        sources[idx] = sources[idx].Redacted()
+    }
+
     w.Header().Set("Content-Type", "application/json")
     if err := json.NewEncoder(w).Encode(sources); err != nil {
         http.Error(w, "Failed to encode sources", http.StatusInternalServerError)
@@ -139,6 +180,25 @@ func (d *Dashboard) handleSources(w http.ResponseWriter, r *http.Request) {
     }
 }
 
+// handleFactChecks lists articles waiting for a fact-check retry
+func (d *Dashboard) handleFactChecks(w http.ResponseWriter, r *http.Request) {
+    backlog, err := factCheckQueue.Backlog()
+    if err != nil {
+        http.Error(w, "Failed to load fact check backlog", http.StatusInternalServerError)
+        Logger().Printf("Failed to load fact check backlog: %v", err)
+        return
+    }
+    if backlog == nil {
+        backlog = []*PendingFactCheck{}
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    if err := json.NewEncoder(w).Encode(backlog); err != nil {
+        http.Error(w, "Failed to encode fact check backlog", http.StatusInternalServerError)
+        Logger().Printf("Failed to encode fact check backlog: %v", err)
+    }
+}
+
 func (d *Dashboard) handleHealth(w http.ResponseWriter, r *http.Request) {
     state, err := LoadState()
     if err != nil {
@@ -147,17 +207,34 @@ func (d *Dashboard) handleHealth(w http.ResponseWriter, r *http.Request) {
         return
     }
 
+    sources, err := LoadSources()
+    if err != nil {
+        http.Error(w, "Failed to load sources", http.StatusInternalServerError)
+        Logger().Printf("Failed to load sources: %v", err)
+        return
+    }
+
+    status, components := CheckFeedHealth(sources, state.LastArticleTime)
+
     health := struct {
-        Status    string    `json:"status"`
-        Timestamp time.Time `json:"timestamp"`
-        Uptime    string    `json:"uptime"`
+        Status     string            `json:"status"`
+        Timestamp  time.Time         `json:"timestamp"`
+        Uptime     string            `json:"uptime"`
+        Components map[string]Status `json:"components"`
+        Leader     LeaderStatus      `json:"leader"`
     }{
-        Status:    state.HealthStatus,
-        Timestamp: time.Now(),
-        Uptime:    time.Since(state.StartupTime).String(),
+        Status:     status,
+        Timestamp:  time.Now(),
+        Uptime:     time.Since(state.StartupTime).String(),
+        Components: components,
+        Leader:     leaderElection.Status(),
     }
 
+    // Let orchestrators detect an unhealthy bot from the status code alone
     w.Header().Set("Content-Type", "application/json")
+    if status == StatusUnhealthy {
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }
     if err := json.NewEncoder(w).Encode(health); err != nil {
         http.Error(w, "Failed to encode health status", http.StatusInternalServerError)
         Logger().Printf("Failed to encode health status: %v", err)
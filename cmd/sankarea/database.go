@@ -2,9 +2,11 @@
 package main
 
 import (
+    "context"
     "database/sql"
     "encoding/json"
     "fmt"
+    "strings"
     "time"
     
     _ "github.com/mattn/go-sqlite3"
@@ -53,6 +55,12 @@ func NewDatabase(path string) (*Database, error) {
         return nil, fmt.Errorf("failed to initialize tables: %v", err)
     }
 
+    // Bring tables created by older versions up to date
+    if err := migrateTables(db); err != nil {
+        db.Close()
+        return nil, fmt.Errorf("failed to migrate tables: %v", err)
+    }
+
     return &Database{db: db}, nil
 }
 
@@ -71,6 +79,10 @@ func initializeTables(db *sql.DB) error {
             image_url TEXT,
             citations TEXT,
             fact_check_result TEXT,
+            content_hash TEXT,
+            word_count INTEGER NOT NULL DEFAULT 0,
+            audio_url TEXT,
+            audio_duration TEXT,
             FOREIGN KEY(source) REFERENCES sources(name)
         )`,
         `CREATE TABLE IF NOT EXISTS sources (
@@ -90,10 +102,60 @@ func initializeTables(db *sql.DB) error {
             severity TEXT NOT NULL,
             timestamp DATETIME NOT NULL
         )`,
+        `CREATE TABLE IF NOT EXISTS summaries (
+            url TEXT PRIMARY KEY,
+            content_hash TEXT NOT NULL,
+            summary TEXT NOT NULL,
+            created_at DATETIME NOT NULL
+        )`,
+        `CREATE TABLE IF NOT EXISTS fact_check_queue (
+            article_id TEXT PRIMARY KEY,
+            attempts INTEGER NOT NULL DEFAULT 0,
+            next_attempt DATETIME NOT NULL,
+            last_error TEXT,
+            queued_at DATETIME NOT NULL
+        )`,
+        `CREATE TABLE IF NOT EXISTS article_messages (
+            article_id TEXT NOT NULL,
+            channel_id TEXT NOT NULL,
+            message_id TEXT NOT NULL,
+            embed_index INTEGER NOT NULL DEFAULT 0,
+            embed_count INTEGER NOT NULL DEFAULT 1,
+            posted_at DATETIME NOT NULL,
+            source TEXT NOT NULL DEFAULT '',
+            PRIMARY KEY (article_id, channel_id, message_id)
+        )`,
+        `CREATE TABLE IF NOT EXISTS pending_posts (
+            article_id TEXT PRIMARY KEY,
+            queued_at DATETIME NOT NULL
+        )`,
+        `CREATE TABLE IF NOT EXISTS leader_lock (
+            name TEXT PRIMARY KEY,
+            holder TEXT NOT NULL,
+            expires_at DATETIME NOT NULL
+        )`,
+        `CREATE TABLE IF NOT EXISTS metrics_history (
+            hour DATETIME PRIMARY KEY,
+            articles INTEGER NOT NULL,
+            errors INTEGER NOT NULL,
+            api_calls INTEGER NOT NULL
+        )`,
+        `CREATE TABLE IF NOT EXISTS audit_log (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            at DATETIME NOT NULL,
+            actor TEXT NOT NULL DEFAULT '',
+            type TEXT NOT NULL,
+            action TEXT NOT NULL,
+            target TEXT NOT NULL DEFAULT '',
+            result TEXT NOT NULL DEFAULT '',
+            details TEXT NOT NULL DEFAULT ''
+        )`,
         `CREATE INDEX IF NOT EXISTS idx_articles_published ON articles(published_at DESC)`,
         `CREATE INDEX IF NOT EXISTS idx_articles_source ON articles(source)`,
         `CREATE INDEX IF NOT EXISTS idx_articles_category ON articles(category)`,
         `CREATE INDEX IF NOT EXISTS idx_errors_timestamp ON errors(timestamp DESC)`,
+        `CREATE INDEX IF NOT EXISTS idx_fact_check_queue_next ON fact_check_queue(next_attempt)`,
+        `CREATE INDEX IF NOT EXISTS idx_audit_log_at ON audit_log(at)`,
     }
 
     tx, err := db.Begin()
@@ -111,6 +173,126 @@ func initializeTables(db *sql.DB) error {
     return tx.Commit()
 }
 
+// migrateTables adds columns introduced after a table was first created
+func migrateTables(db *sql.DB) error {
+    columns := []struct {
+        table, column, definition string
+    }{
+        {"articles", "content_hash", "TEXT"},
+        {"articles", "word_count", "INTEGER NOT NULL DEFAULT 0"},
+        {"articles", "audio_url", "TEXT"},
+        {"articles", "audio_duration", "TEXT"},
+        {"article_messages", "source", "TEXT NOT NULL DEFAULT ''"},
+    }
+
+    for _, c := range columns {
+        if err := addColumnIfMissing(db, c.table, c.column, c.definition); err != nil {
+            return err
+        }
+    }
+
+    // Posts recorded before the source column take their article's source
+    if _, err := db.Exec(`
+        UPDATE article_messages SET source = COALESCE(
+            (SELECT source FROM articles WHERE articles.id = article_messages.article_id), '')
+        WHERE source = ''`); err != nil {
+        return fmt.Errorf("failed to backfill article message sources: %v", err)
+    }
+    return nil
+}
+
+// addColumnIfMissing adds a column to a table unless it already exists
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+    rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+    if err != nil {
+        return fmt.Errorf("failed to read %s schema: %v", table, err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var (
+            cid       int
+            name      string
+            colType   string
+            notNull   int
+            dfltValue sql.NullString
+            pk        int
+        )
+        if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+            return fmt.Errorf("failed to scan %s schema: %v", table, err)
+        }
+        if name == column {
+            return nil
+        }
+    }
+    if err := rows.Err(); err != nil {
+        return fmt.Errorf("failed to read %s schema: %v", table, err)
+    }
+
+    if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+        return fmt.Errorf("failed to add %s.%s: %v", table, column, err)
+    }
+    return nil
+}
+
+// articleColumns lists the article columns in the order scanArticle reads them
+const articleColumns = `id, title, content, url, source, category,
+               published_at, fetched_at, image_url, citations, fact_check_result, content_hash, word_count,
+               audio_url, audio_duration`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+    Scan(dest ...interface{}) error
+}
+
+// scanArticle reads a single article row selected with articleColumns
+func scanArticle(row rowScanner) (*NewsArticle, error) {
+    var article NewsArticle
+    var imageURL, citationsJSON, factCheckJSON, contentHash sql.NullString
+    var audioURL, audioDuration sql.NullString
+
+    if err := row.Scan(
+        &article.ID,
+        &article.Title,
+        &article.Content,
+        &article.URL,
+        &article.Source,
+        &article.Category,
+        &article.PublishedAt,
+        &article.FetchedAt,
+        &imageURL,
+        &citationsJSON,
+        &factCheckJSON,
+        &contentHash,
+        &article.WordCount,
+        &audioURL,
+        &audioDuration,
+    ); err != nil {
+        return nil, err
+    }
+    article.ImageURL = imageURL.String
+    article.ContentHash = contentHash.String
+    article.AudioURL = audioURL.String
+    article.AudioDuration = audioDuration.String
+
+    // Parse citations if present
+    if citationsJSON.Valid && citationsJSON.String != "" {
+        if err := json.Unmarshal([]byte(citationsJSON.String), &article.Citations); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal citations: %v", err)
+        }
+    }
+
+    // Parse fact check result if present
+    if factCheckJSON.Valid && factCheckJSON.String != "" {
+        article.FactCheckResult = &FactCheckResult{}
+        if err := json.Unmarshal([]byte(factCheckJSON.String), article.FactCheckResult); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal fact check result: %v", err)
+        }
+    }
+
+    return &article, nil
+}
+
 // SaveArticle stores a news article in the database
 func (db *Database) SaveArticle(article *NewsArticle) error {
     // Convert citations to JSON if present
@@ -136,8 +318,9 @@ func (db *Database) SaveArticle(article *NewsArticle) error {
     query := `
         INSERT OR REPLACE INTO articles (
             id, title, content, url, source, category,
-            published_at, fetched_at, image_url, citations, fact_check_result
-        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+            published_at, fetched_at, image_url, citations, fact_check_result, content_hash, word_count,
+            audio_url, audio_duration
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
     tx, err := db.db.Begin()
@@ -157,6 +340,10 @@ func (db *Database) SaveArticle(article *NewsArticle) error {
         article.ImageURL,
         citationsJSON,
         factCheckJSON,
+        article.ContentHash,
+        article.WordCount,
+        article.AudioURL,
+        article.AudioDuration,
     )
 
     if err != nil {
@@ -169,30 +356,9 @@ func (db *Database) SaveArticle(article *NewsArticle) error {
 
 // GetArticle retrieves an article by its ID
 func (db *Database) GetArticle(id string) (*NewsArticle, error) {
-    query := `
-        SELECT id, title, content, url, source, category, 
-               published_at, fetched_at, image_url, citations, fact_check_result
-        FROM articles 
-        WHERE id = ?
-    `
-    
-    var article NewsArticle
-    var citationsJSON, factCheckJSON sql.NullString
-    
-    err := db.db.QueryRow(query, id).Scan(
-        &article.ID,
-        &article.Title,
-        &article.Content,
-        &article.URL,
-        &article.Source,
-        &article.Category,
-        &article.PublishedAt,
-        &article.FetchedAt,
-        &article.ImageURL,
-        &citationsJSON,
-        &factCheckJSON,
-    )
+    query := `SELECT ` + articleColumns + ` FROM articles WHERE id = ?`
 
+    article, err := scanArticle(db.db.QueryRow(query, id))
     if err == sql.ErrNoRows {
         return nil, nil
     }
@@ -200,22 +366,22 @@ func (db *Database) GetArticle(id string) (*NewsArticle, error) {
         return nil, fmt.Errorf("failed to get article: %v", err)
     }
 
-    // Parse citations if present
-    if citationsJSON.Valid && citationsJSON.String != "" {
-        if err := json.Unmarshal([]byte(citationsJSON.String), &article.Citations); err != nil {
-            return nil, fmt.Errorf("failed to unmarshal citations: %v", err)
-        }
-    }
+    return article, nil
+}
 
-    // Parse fact check result if present
-    if factCheckJSON.Valid && factCheckJSON.String != "" {
-        article.FactCheckResult = &FactCheckResult{}
-        if err := json.Unmarshal([]byte(factCheckJSON.String), article.FactCheckResult); err != nil {
-            return nil, fmt.Errorf("failed to unmarshal fact check result: %v", err)
-        }
+// GetArticleByURL retrieves an article by its link
+func (db *Database) GetArticleByURL(url string) (*NewsArticle, error) {
+    query := `SELECT ` + articleColumns + ` FROM articles WHERE url = ?`
+
+    article, err := scanArticle(db.db.QueryRow(query, url))
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get article: %v", err)
     }
 
-    return &article, nil
+    return article, nil
 }
 
 // SaveSource stores or updates a news source in the database
@@ -310,6 +476,295 @@ func (db *Database) LogError(event *ErrorEvent) error {
     return nil
 }
 
+// SelfTest writes a row and reads it back inside a transaction that is
+// always rolled back, so nothing is left behind
+func (db *Database) SelfTest(ctx context.Context) error {
+    tx, err := db.db.BeginTx(ctx, nil)
+    if err != nil {
+        return fmt.Errorf("failed to begin transaction: %v", err)
+    }
+    defer tx.Rollback()
+
+    marker := fmt.Sprintf("selftest-%d", time.Now().UnixNano())
+    if _, err := tx.ExecContext(ctx,
+        `INSERT INTO errors (component, message, severity, timestamp) VALUES (?, ?, ?, ?)`,
+        "selftest", marker, ErrorSeverityLow, time.Now(),
+    ); err != nil {
+        return fmt.Errorf("failed to write: %v", err)
+    }
+
+    var message string
+    if err := tx.QueryRowContext(ctx,
+        `SELECT message FROM errors WHERE component = ? AND message = ?`, "selftest", marker,
+    ).Scan(&message); err != nil {
+        return fmt.Errorf("failed to read back: %v", err)
+    }
+    return nil
+}
+
+// CachedSummary is an AI summary stored for reuse
+type CachedSummary struct {
+    URL         string
+    ContentHash string
+    Summary     string
+    CreatedAt   time.Time
+}
+
+// GetSummary retrieves the cached summary for an article URL, or nil if none
+func (db *Database) GetSummary(url string) (*CachedSummary, error) {
+    cached := &CachedSummary{}
+    err := db.db.QueryRow(
+        `SELECT url, content_hash, summary, created_at FROM summaries WHERE url = ?`, url,
+    ).Scan(&cached.URL, &cached.ContentHash, &cached.Summary, &cached.CreatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get summary: %v", err)
+    }
+    return cached, nil
+}
+
+// SaveSummary stores or replaces the cached summary for an article URL
+func (db *Database) SaveSummary(cached *CachedSummary) error {
+    _, err := db.db.Exec(
+        `INSERT OR REPLACE INTO summaries (url, content_hash, summary, created_at) VALUES (?, ?, ?, ?)`,
+        cached.URL, cached.ContentHash, cached.Summary, cached.CreatedAt,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to save summary: %v", err)
+    }
+    return nil
+}
+
+// SaveFactCheckRetry queues or reschedules a fact-check retry
+func (db *Database) SaveFactCheckRetry(pending *PendingFactCheck) error {
+    _, err := db.db.Exec(`
+        INSERT INTO fact_check_queue (article_id, attempts, next_attempt, last_error, queued_at)
+        VALUES (?, ?, ?, ?, ?)
+        ON CONFLICT(article_id) DO UPDATE SET
+            attempts = excluded.attempts,
+            next_attempt = excluded.next_attempt,
+            last_error = excluded.last_error`,
+        pending.ArticleID, pending.Attempts, pending.NextAttempt, pending.LastError, pending.QueuedAt,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to save fact check retry: %v", err)
+    }
+    return nil
+}
+
+// GetFactCheckRetries lists queued fact checks due by the given time, soonest
+// first. A zero time lists all of them and a limit of 0 means no limit.
+func (db *Database) GetFactCheckRetries(due time.Time, limit int) ([]*PendingFactCheck, error) {
+    query := `
+        SELECT q.article_id, COALESCE(a.title, ''), COALESCE(a.url, ''),
+               q.attempts, q.next_attempt, COALESCE(q.last_error, ''), q.queued_at
+        FROM fact_check_queue q
+        LEFT JOIN articles a ON a.id = q.article_id`
+    var args []interface{}
+    if !due.IsZero() {
+        query += ` WHERE q.next_attempt <= ?`
+        args = append(args, due)
+    }
+    query += ` ORDER BY q.next_attempt`
+    if limit > 0 {
+        query += ` LIMIT ?`
+        args = append(args, limit)
+    }
+
+    rows, err := db.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query fact check retries: %v", err)
+    }
+    defer rows.Close()
+
+    var pending []*PendingFactCheck
+    for rows.Next() {
+        p := &PendingFactCheck{}
+        if err := rows.Scan(&p.ArticleID, &p.Title, &p.URL, &p.Attempts, &p.NextAttempt, &p.LastError, &p.QueuedAt); err != nil {
+            return nil, fmt.Errorf("failed to scan fact check retry: %v", err)
+        }
+        pending = append(pending, p)
+    }
+    return pending, rows.Err()
+}
+
+// DeleteFactCheckRetry removes an article from the retry queue
+func (db *Database) DeleteFactCheckRetry(articleID string) error {
+    if _, err := db.db.Exec(`DELETE FROM fact_check_queue WHERE article_id = ?`, articleID); err != nil {
+        return fmt.Errorf("failed to delete fact check retry: %v", err)
+    }
+    return nil
+}
+
+// SavePendingPost queues a stored article to be posted on the next start
+func (db *Database) SavePendingPost(articleID string, queuedAt time.Time) error {
+    _, err := db.db.Exec(`INSERT OR IGNORE INTO pending_posts (article_id, queued_at) VALUES (?, ?)`, articleID, queuedAt)
+    if err != nil {
+        return fmt.Errorf("failed to save pending post: %v", err)
+    }
+    return nil
+}
+
+// GetPendingPosts returns the queued articles that still exist, oldest first
+func (db *Database) GetPendingPosts() ([]*NewsArticle, error) {
+    query := `SELECT ` + articleColumns + ` FROM articles
+        WHERE id IN (SELECT article_id FROM pending_posts)
+        ORDER BY published_at`
+
+    rows, err := db.db.Query(query)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query pending posts: %v", err)
+    }
+    defer rows.Close()
+
+    var articles []*NewsArticle
+    for rows.Next() {
+        article, err := scanArticle(rows)
+        if err != nil {
+            return nil, fmt.Errorf("failed to scan pending post: %v", err)
+        }
+        articles = append(articles, article)
+    }
+    return articles, rows.Err()
+}
+
+// ClearPendingPosts empties the pending post queue
+func (db *Database) ClearPendingPosts() error {
+    if _, err := db.db.Exec(`DELETE FROM pending_posts`); err != nil {
+        return fmt.Errorf("failed to clear pending posts: %v", err)
+    }
+    return nil
+}
+
+// SaveArticleMessage records or updates where an article was posted
+func (db *Database) SaveArticleMessage(m *ArticleMessage) error {
+    _, err := db.db.Exec(`
+        INSERT OR REPLACE INTO article_messages (
+            article_id, channel_id, message_id, embed_index, embed_count, posted_at, source
+        ) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+        m.ArticleID, m.ChannelID, m.MessageID, m.EmbedIndex, m.EmbedCount, m.PostedAt, m.Source,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to save article message: %v", err)
+    }
+    return nil
+}
+
+// DeleteArticleMessage forgets a recorded post
+func (db *Database) DeleteArticleMessage(m *ArticleMessage) error {
+    _, err := db.db.Exec(
+        `DELETE FROM article_messages WHERE article_id = ? AND channel_id = ? AND message_id = ?`,
+        m.ArticleID, m.ChannelID, m.MessageID,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to delete article message: %v", err)
+    }
+    return nil
+}
+
+// DeleteMessageRecords forgets every article recorded in a message
+func (db *Database) DeleteMessageRecords(channelID, messageID string) error {
+    _, err := db.db.Exec(`DELETE FROM article_messages WHERE channel_id = ? AND message_id = ?`, channelID, messageID)
+    if err != nil {
+        return fmt.Errorf("failed to delete message records: %v", err)
+    }
+    return nil
+}
+
+// GetArticleMessages lists the messages an article was posted in
+func (db *Database) GetArticleMessages(articleID string) ([]*ArticleMessage, error) {
+    rows, err := db.db.Query(`
+        SELECT article_id, channel_id, message_id, embed_index, embed_count, posted_at, source
+        FROM article_messages WHERE article_id = ? ORDER BY posted_at`, articleID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query article messages: %v", err)
+    }
+    return scanArticleMessages(rows)
+}
+
+// GetSourceMessages lists every record of the messages holding a source's
+// posts from before a time, including the records of other sources'
+// articles batched into the same messages
+func (db *Database) GetSourceMessages(source string, before time.Time) ([]*ArticleMessage, error) {
+    rows, err := db.db.Query(`
+        SELECT article_id, channel_id, message_id, embed_index, embed_count, posted_at, source
+        FROM article_messages
+        WHERE message_id IN (
+            SELECT message_id FROM article_messages
+            WHERE source = ? COLLATE NOCASE AND posted_at < ?)
+        ORDER BY channel_id, posted_at, message_id, embed_index`, source, before)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query source messages: %v", err)
+    }
+    return scanArticleMessages(rows)
+}
+
+// scanArticleMessages reads and closes rows of article_messages
+func scanArticleMessages(rows *sql.Rows) ([]*ArticleMessage, error) {
+    defer rows.Close()
+
+    var messages []*ArticleMessage
+    for rows.Next() {
+        m := &ArticleMessage{}
+        if err := rows.Scan(&m.ArticleID, &m.ChannelID, &m.MessageID, &m.EmbedIndex, &m.EmbedCount, &m.PostedAt, &m.Source); err != nil {
+            return nil, fmt.Errorf("failed to scan article message: %v", err)
+        }
+        messages = append(messages, m)
+    }
+    return messages, rows.Err()
+}
+
+// CleanOldArticleMessages forgets posts recorded longer ago than the
+// retention period and returns the number of records removed
+func (db *Database) CleanOldArticleMessages(age time.Duration) (int64, error) {
+    query := `DELETE FROM article_messages WHERE posted_at < ?`
+
+    result, err := db.db.Exec(query, time.Now().UTC().Add(-age))
+    if err != nil {
+        return 0, fmt.Errorf("failed to clean old article messages: %v", err)
+    }
+
+    rows, err := result.RowsAffected()
+    if err != nil {
+        return 0, fmt.Errorf("failed to get affected rows: %v", err)
+    }
+
+    return rows, nil
+}
+
+// AcquireLeaderLock takes a named lock for holder, or renews it if holder
+// already has it, unless another holder's lock is still live. It returns
+// the lock's holder and expiry after the attempt.
+func (db *Database) AcquireLeaderLock(name, holder string, ttl time.Duration) (string, time.Time, error) {
+    now := time.Now().UTC()
+    _, err := db.db.Exec(`
+        INSERT INTO leader_lock (name, holder, expires_at) VALUES (?, ?, ?)
+        ON CONFLICT(name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+        WHERE leader_lock.holder = excluded.holder OR leader_lock.expires_at < ?`,
+        name, holder, now.Add(ttl), now,
+    )
+    if err != nil {
+        return "", time.Time{}, fmt.Errorf("failed to acquire leader lock: %v", err)
+    }
+
+    var current string
+    var expires time.Time
+    if err := db.db.QueryRow(`SELECT holder, expires_at FROM leader_lock WHERE name = ?`, name).Scan(&current, &expires); err != nil {
+        return "", time.Time{}, fmt.Errorf("failed to read leader lock: %v", err)
+    }
+    return current, expires, nil
+}
+
+// ReleaseLeaderLock gives up a named lock if holder has it
+func (db *Database) ReleaseLeaderLock(name, holder string) error {
+    if _, err := db.db.Exec(`DELETE FROM leader_lock WHERE name = ? AND holder = ?`, name, holder); err != nil {
+        return fmt.Errorf("failed to release leader lock: %v", err)
+    }
+    return nil
+}
+
 // GetRecentErrors retrieves recent error events
 func (db *Database) GetRecentErrors(limit int) ([]*ErrorEvent, error) {
     query := `
@@ -388,37 +843,166 @@ func (db *Database) GetSourceStats() (map[string]SourceStats, error) {
     return stats, nil
 }
 
-// CleanOldArticles removes articles older than the specified duration
-func (db *Database) CleanOldArticles(age time.Duration) error {
+// CleanOldArticles removes articles older than the specified duration and
+// returns the number of rows deleted
+func (db *Database) CleanOldArticles(age time.Duration) (int64, error) {
     query := `DELETE FROM articles WHERE published_at < datetime('now', '-' || ? || ' seconds')`
     
     result, err := db.db.Exec(query, int64(age.Seconds()))
     if err != nil {
-        return fmt.Errorf("failed to clean old articles: %v", err)
+        return 0, fmt.Errorf("failed to clean old articles: %v", err)
     }
 
     rows, err := result.RowsAffected()
     if err != nil {
-        return fmt.Errorf("failed to get affected rows: %v", err)
+        return 0, fmt.Errorf("failed to get affected rows: %v", err)
     }
 
-    return nil
+    return rows, nil
+}
+
+// CountSourceArticles returns how many articles are stored for a source
+func (db *Database) CountSourceArticles(source string) (int, error) {
+    var count int
+    err := db.db.QueryRow("SELECT COUNT(*) FROM articles WHERE source = ? COLLATE NOCASE", source).Scan(&count)
+    if err != nil {
+        return 0, fmt.Errorf("failed to count source articles: %v", err)
+    }
+    return count, nil
+}
+
+// PurgeSourceArticles deletes a source's articles along with their queued
+// fact checks and post records, returning the number of articles removed
+func (db *Database) PurgeSourceArticles(source string) (int64, error) {
+    tx, err := db.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("failed to begin transaction: %v", err)
+    }
+
+    const ids = `SELECT id FROM articles WHERE source = ? COLLATE NOCASE`
+    for _, table := range []string{"fact_check_queue", "article_messages", "pending_posts"} {
+        if _, err := tx.Exec(`DELETE FROM `+table+` WHERE article_id IN (`+ids+`)`, source); err != nil {
+            tx.Rollback()
+            return 0, fmt.Errorf("failed to purge %s: %v", table, err)
+        }
+    }
+
+    result, err := tx.Exec(`DELETE FROM articles WHERE source = ? COLLATE NOCASE`, source)
+    if err != nil {
+        tx.Rollback()
+        return 0, fmt.Errorf("failed to purge articles: %v", err)
+    }
+    rows, err := result.RowsAffected()
+    if err != nil {
+        tx.Rollback()
+        return 0, fmt.Errorf("failed to get affected rows: %v", err)
+    }
+
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("failed to commit purge: %v", err)
+    }
+    return rows, nil
 }
 
-// CleanOldErrors removes error logs older than the specified duration
-func (db *Database) CleanOldErrors(age time.Duration) error {
+// Feed errors are logged as feedErrorPrefix + source name + ": " + cause
+const (
+    feedErrorComponent = "NewsProcessor"
+    feedErrorPrefix    = "Error processing feed "
+)
+
+// CountFeedErrors returns how many fetch errors a source logged since a time
+func (db *Database) CountFeedErrors(source string, since time.Time) (int, error) {
+    pattern := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(feedErrorPrefix+source+": ") + "%"
+    var count int
+    err := db.db.QueryRow(
+        `SELECT COUNT(*) FROM errors WHERE component = ? AND message LIKE ? ESCAPE '\' AND timestamp >= ?`,
+        feedErrorComponent, pattern, since,
+    ).Scan(&count)
+    if err != nil {
+        return 0, fmt.Errorf("failed to count feed errors: %v", err)
+    }
+    return count, nil
+}
+
+// CleanOldErrors removes error logs older than the specified duration and
+// returns the number of rows deleted
+func (db *Database) CleanOldErrors(age time.Duration) (int64, error) {
     query := `DELETE FROM errors WHERE timestamp < datetime('now', '-' || ? || ' seconds')`
     
     result, err := db.db.Exec(query, int64(age.Seconds()))
     if err != nil {
-        return fmt.Errorf("failed to clean old errors: %v", err)
+        return 0, fmt.Errorf("failed to clean old errors: %v", err)
     }
 
     rows, err := result.RowsAffected()
     if err != nil {
-        return fmt.Errorf("failed to get affected rows: %v", err)
+        return 0, fmt.Errorf("failed to get affected rows: %v", err)
     }
 
+    return rows, nil
+}
+
+// SaveMetricsSample stores the counters for an hour, replacing an earlier
+// sample from the same hour
+func (db *Database) SaveMetricsSample(sample *MetricsSample) error {
+    _, err := db.db.Exec(`
+        INSERT INTO metrics_history (hour, articles, errors, api_calls)
+        VALUES (?, ?, ?, ?)
+        ON CONFLICT(hour) DO UPDATE SET
+            articles = excluded.articles,
+            errors = excluded.errors,
+            api_calls = excluded.api_calls`,
+        sample.Hour, sample.Articles, sample.Errors, sample.APICalls,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to save metrics sample: %v", err)
+    }
+    return nil
+}
+
+// GetMetricsSamples returns the samples since a time, oldest first
+func (db *Database) GetMetricsSamples(since time.Time) ([]*MetricsSample, error) {
+    rows, err := db.db.Query(`
+        SELECT hour, articles, errors, api_calls
+        FROM metrics_history
+        WHERE hour >= ?
+        ORDER BY hour`, since)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query metrics history: %v", err)
+    }
+    defer rows.Close()
+
+    var samples []*MetricsSample
+    for rows.Next() {
+        sample := &MetricsSample{}
+        if err := rows.Scan(&sample.Hour, &sample.Articles, &sample.Errors, &sample.APICalls); err != nil {
+            return nil, fmt.Errorf("failed to scan metrics sample: %v", err)
+        }
+        samples = append(samples, sample)
+    }
+    return samples, rows.Err()
+}
+
+// CleanOldMetricsSamples removes samples older than the given age
+func (db *Database) CleanOldMetricsSamples(age time.Duration) (int64, error) {
+    result, err := db.db.Exec(`DELETE FROM metrics_history WHERE hour < ?`, time.Now().UTC().Add(-age))
+    if err != nil {
+        return 0, fmt.Errorf("failed to clean old metrics samples: %v", err)
+    }
+
+    rows, err := result.RowsAffected()
+    if err != nil {
+        return 0, fmt.Errorf("failed to get affected rows: %v", err)
+    }
+
+    return rows, nil
+}
+
+// Vacuum rebuilds the database file to reclaim space freed by deletions
+func (db *Database) Vacuum() error {
+    if _, err := db.db.Exec("VACUUM"); err != nil {
+        return fmt.Errorf("failed to vacuum database: %v", err)
+    }
     return nil
 }
 
@@ -435,8 +1019,7 @@ func (db *Database) GetArticleCount() (int, error) {
 // GetRecentArticles retrieves recent articles with optional filtering
 func (db *Database) GetRecentArticles(limit int, category string) ([]*NewsArticle, error) {
     query := `
-        SELECT id, title, content, url, source, category, 
-               published_at, fetched_at, image_url, citations, fact_check_result
+        SELECT ` + articleColumns + `
         FROM articles
         WHERE category = COALESCE(?, category)
         ORDER BY published_at DESC
@@ -451,41 +1034,149 @@ func (db *Database) GetRecentArticles(limit int, category string) ([]*NewsArticl
 
     var articles []*NewsArticle
     for rows.Next() {
-        var article NewsArticle
-        var citationsJSON, factCheckJSON sql.NullString
-        
-        if err := rows.Scan(
-            &article.ID,
-            &article.Title,
-            &article.Content,
-            &article.URL,
-            &article.Source,
-            &article.Category,
-            &article.PublishedAt,
-            &article.FetchedAt,
-            &article.ImageURL,
-            &citationsJSON,
-            &factCheckJSON,
-        ); err != nil {
+        article, err := scanArticle(rows)
+        if err != nil {
+            return nil, fmt.Errorf("failed to scan article: %v", err)
+        }
+        articles = append(articles, article)
+    }
+
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error iterating articles: %v", err)
+    }
+
+    return articles, nil
+}
+
+// GetArticlesBySource retrieves a source's most recent articles, newest first
+func (db *Database) GetArticlesBySource(source string, limit int) ([]*NewsArticle, error) {
+    query := `
+        SELECT ` + articleColumns + `
+        FROM articles
+        WHERE source = ? COLLATE NOCASE
+        ORDER BY published_at DESC
+        LIMIT ?
+    `
+
+    rows, err := db.db.Query(query, source, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query source articles: %v", err)
+    }
+    defer rows.Close()
+
+    var articles []*NewsArticle
+    for rows.Next() {
+        article, err := scanArticle(rows)
+        if err != nil {
             return nil, fmt.Errorf("failed to scan article: %v", err)
         }
+        articles = append(articles, article)
+    }
+
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error iterating articles: %v", err)
+    }
+
+    return articles, nil
+}
+
+// SearchArticles retrieves a source's articles whose title or content
+// mentions topic, newest first
+func (db *Database) SearchArticles(source, topic string, limit int) ([]*NewsArticle, error) {
+    pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(topic) + "%"
+    query := `
+        SELECT ` + articleColumns + `
+        FROM articles
+        WHERE source = ? COLLATE NOCASE
+          AND (title LIKE ? ESCAPE '\' OR content LIKE ? ESCAPE '\')
+        ORDER BY published_at DESC
+        LIMIT ?
+    `
 
-        // Parse citations if present
-        if citationsJSON.Valid && citationsJSON.String != "" {
-            if err := json.Unmarshal([]byte(citationsJSON.String), &article.Citations); err != nil {
-                return nil, fmt.Errorf("failed to unmarshal citations: %v", err)
-            }
+    rows, err := db.db.Query(query, source, pattern, pattern, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to search articles: %v", err)
+    }
+    defer rows.Close()
+
+    var articles []*NewsArticle
+    for rows.Next() {
+        article, err := scanArticle(rows)
+        if err != nil {
+            return nil, fmt.Errorf("failed to scan article: %v", err)
         }
+        articles = append(articles, article)
+    }
+
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error iterating articles: %v", err)
+    }
 
-        // Parse fact check result if present
-        if factCheckJSON.Valid && factCheckJSON.String != "" {
-            article.FactCheckResult = &FactCheckResult{}
-            if err := json.Unmarshal([]byte(factCheckJSON.String), article.FactCheckResult); err != nil {
-                return nil, fmt.Errorf("failed to unmarshal fact check result: %v", err)
-            }
+    return articles, nil
+}
+
+// GetArticleIDs returns the IDs of a source's articles published since a
+// time, newest first; an empty source matches every source
+func (db *Database) GetArticleIDs(source string, since time.Time) ([]string, error) {
+    query := `SELECT id FROM articles WHERE published_at >= ?`
+    args := []interface{}{since}
+    if source != "" {
+        query += ` AND source = ? COLLATE NOCASE`
+        args = append(args, source)
+    }
+    query += ` ORDER BY published_at DESC`
+
+    rows, err := db.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query article IDs: %v", err)
+    }
+    defer rows.Close()
+
+    var ids []string
+    for rows.Next() {
+        var id string
+        if err := rows.Scan(&id); err != nil {
+            return nil, fmt.Errorf("failed to scan article ID: %v", err)
         }
+        ids = append(ids, id)
+    }
+    return ids, rows.Err()
+}
 
-        articles = append(articles, &article)
+// UpdateFactCheckResult replaces an article's stored fact check result
+func (db *Database) UpdateFactCheckResult(id string, result *FactCheckResult) error {
+    data, err := json.Marshal(result)
+    if err != nil {
+        return fmt.Errorf("failed to marshal fact check result: %v", err)
+    }
+    if _, err := db.db.Exec(`UPDATE articles SET fact_check_result = ? WHERE id = ?`, data, id); err != nil {
+        return fmt.Errorf("failed to update fact check result: %v", err)
+    }
+    return nil
+}
+
+// GetArticlesByTimeRange retrieves articles published within the given range, newest first
+func (db *Database) GetArticlesByTimeRange(start, end time.Time) ([]*NewsArticle, error) {
+    query := `
+        SELECT ` + articleColumns + `
+        FROM articles
+        WHERE published_at >= ? AND published_at < ?
+        ORDER BY published_at DESC
+    `
+
+    rows, err := db.db.Query(query, start, end)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query articles: %v", err)
+    }
+    defer rows.Close()
+
+    var articles []*NewsArticle
+    for rows.Next() {
+        article, err := scanArticle(rows)
+        if err != nil {
+            return nil, fmt.Errorf("failed to scan article: %v", err)
+        }
+        articles = append(articles, article)
     }
 
     if err := rows.Err(); err != nil {
@@ -499,3 +1190,74 @@ func (db *Database) GetRecentArticles(limit int, category string) ([]*NewsArticl
 func (db *Database) Close() error {
     return db.db.Close()
 }
+
+// SaveAuditEvent appends an event to the audit log
+func (db *Database) SaveAuditEvent(event *AuditEvent) error {
+    result, err := db.db.Exec(`
+        INSERT INTO audit_log (at, actor, type, action, target, result, details)
+        VALUES (?, ?, ?, ?, ?, ?, ?)`,
+        event.At, event.Actor, event.Type, event.Action, event.Target, event.Result, event.Details,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to save audit event: %v", err)
+    }
+    if id, err := result.LastInsertId(); err == nil {
+        event.ID = id
+    }
+    return nil
+}
+
+// DeleteAuditEvents removes every audit event recorded for an actor
+func (db *Database) DeleteAuditEvents(actor string) (int64, error) {
+    result, err := db.db.Exec(`DELETE FROM audit_log WHERE actor = ?`, actor)
+    if err != nil {
+        return 0, fmt.Errorf("failed to delete audit events: %v", err)
+    }
+
+    rows, err := result.RowsAffected()
+    if err != nil {
+        return 0, fmt.Errorf("failed to get affected rows: %v", err)
+    }
+
+    return rows, nil
+}
+
+// GetAuditEvents returns the audit events in [filter.From, filter.To)
+// matching its actor and type, oldest first
+func (db *Database) GetAuditEvents(filter AuditFilter) ([]*AuditEvent, error) {
+    query := `
+        SELECT id, at, actor, type, action, target, result, details
+        FROM audit_log
+        WHERE at >= ? AND at < ?`
+    args := []interface{}{filter.From, filter.To}
+    if filter.Actor != "" {
+        query += ` AND actor = ?`
+        args = append(args, filter.Actor)
+    }
+    if filter.Type != "" {
+        query += ` AND type = ?`
+        args = append(args, filter.Type)
+    }
+    query += ` ORDER BY at, id`
+    if filter.Limit > 0 {
+        query += ` LIMIT ?`
+        args = append(args, filter.Limit)
+    }
+
+    rows, err := db.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query audit log: %v", err)
+    }
+    defer rows.Close()
+
+    var events []*AuditEvent
+    for rows.Next() {
+        event := &AuditEvent{}
+        if err := rows.Scan(&event.ID, &event.At, &event.Actor, &event.Type, &event.Action,
+            &event.Target, &event.Result, &event.Details); err != nil {
+            return nil, fmt.Errorf("failed to scan audit event: %v", err)
+        }
+        events = append(events, event)
+    }
+    return events, rows.Err()
+}
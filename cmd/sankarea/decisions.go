@@ -0,0 +1,204 @@
+// cmd/sankarea/decisions.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// maxDecisionSteps caps how many steps one article's trace keeps
+const maxDecisionSteps = 30
+
+// Decision stages, in pipeline order
+const (
+    StageSource     = "source"
+    StageLanguage   = "language"
+    StageDedup      = "dedup"
+    StageCategory   = "category"
+    StageFactCheck  = "fact check"
+    StageTrustFloor = "trust floor"
+    StageImageDedup = "image dedup"
+    StageMode       = "mode"
+    StageBreaking   = "breaking"
+    StageRouting    = "routing"
+)
+
+// DecisionStep is one check an article went through
+type DecisionStep struct {
+    Stage   string
+    Outcome string
+    Detail  string
+    At      time.Time
+}
+
+// DecisionTrace records why an article was or wasn't posted
+type DecisionTrace struct {
+    mu     sync.Mutex
+    URL    string
+    Title  string
+    Source string
+    Steps  []DecisionStep
+}
+
+var (
+    decisionTraces     *LRUCache // article URL -> *DecisionTrace
+    decisionTracesOnce sync.Once
+)
+
+// decisionCache returns the trace store, sized like the dedup caches
+func decisionCache() *LRUCache {
+    decisionTracesOnce.Do(func() {
+        decisionTraces = NewLRUCache("decision_traces", dedupCacheSize(), dedupCacheTTL())
+    })
+    return decisionTraces
+}
+
+// startDecisionTrace begins a fresh trace for an article being processed,
+// replacing any earlier one, and records the source it came from
+func startDecisionTrace(article *NewsArticle, source NewsSource) {
+    if article.URL == "" {
+        return
+    }
+    trace := &DecisionTrace{URL: article.URL, Title: article.Title, Source: source.Name}
+    decisionCache().Add(article.URL, trace)
+    traceDecision(article.URL, StageSource, "matched",
+        fmt.Sprintf("%s (category %s, trust %.2f)", source.Name, source.Category, sourceTrust(source)))
+}
+
+// hasDecisionTrace reports whether an article has a trace
+func hasDecisionTrace(url string) bool {
+    return decisionCache().Contains(url)
+}
+
+// traceDecision appends a step to an article's trace; articles without a
+// trace are ignored
+func traceDecision(url, stage, outcome, detail string) {
+    value, ok := decisionCache().Get(url)
+    if !ok {
+        return
+    }
+    trace := value.(*DecisionTrace)
+    trace.mu.Lock()
+    defer trace.mu.Unlock()
+    if len(trace.Steps) >= maxDecisionSteps {
+        return
+    }
+    trace.Steps = append(trace.Steps, DecisionStep{Stage: stage, Outcome: outcome, Detail: detail, At: time.Now()})
+}
+
+// decisionTrace returns a copy of an article's trace, or nil
+func decisionTrace(url string) *DecisionTrace {
+    value, ok := decisionCache().Get(url)
+    if !ok {
+        return nil
+    }
+    trace := value.(*DecisionTrace)
+    trace.mu.Lock()
+    defer trace.mu.Unlock()
+    return &DecisionTrace{
+        URL:    trace.URL,
+        Title:  trace.Title,
+        Source: trace.Source,
+        Steps:  append([]DecisionStep(nil), trace.Steps...),
+    }
+}
+
+// handleWhyCommand explains why an article was or wasn't posted
+func (b *Bot) handleWhyCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    url := strings.TrimSpace(getOptionString(i.ApplicationCommandData().Options, "url"))
+    if url == "" {
+        respondWithError(s, i, "Please provide the article URL")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    trace := decisionTrace(url)
+    article, err := b.database.GetArticleByURL(url)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to look up the article")
+        return fmt.Errorf("failed to load article %s: %v", url, err)
+    }
+    if trace == nil && article == nil {
+        editResponse(s, i, "No record of that article. It hasn't been fetched, or its trace has expired.")
+        return nil
+    }
+
+    editResponseWithEmbed(s, i, b.whyEmbed(url, trace, article))
+    return nil
+}
+
+// whyEmbed renders an article's decision trace with its stored state
+func (b *Bot) whyEmbed(url string, trace *DecisionTrace, article *NewsArticle) *discordgo.MessageEmbed {
+    title := url
+    switch {
+    case article != nil:
+        title = article.Title
+    case trace != nil && trace.Title != "":
+        title = trace.Title
+    }
+    embed := &discordgo.MessageEmbed{
+        Title: "🔎 " + truncateString(title, 240),
+        URL:   url,
+        Color: 0x7289DA,
+    }
+
+    if trace != nil {
+        lines := make([]string, 0, len(trace.Steps))
+        for _, step := range trace.Steps {
+            line := fmt.Sprintf("`%s` **%s**", step.Stage, step.Outcome)
+            if step.Detail != "" {
+                line += " - " + step.Detail
+            }
+            lines = append(lines, fmt.Sprintf("%s <t:%d:R>", line, step.At.Unix()))
+        }
+        embed.Description = truncateString(strings.Join(lines, "\n"), MaxEmbedLength)
+    } else {
+        embed.Description = "No decision trace; it was processed before the last restart or too long ago."
+    }
+
+    stored := "Not stored"
+    if article != nil {
+        stored = fmt.Sprintf("From %s, fetched <t:%d:R>", article.Source, article.FetchedAt.Unix())
+    }
+    check := "Not checked"
+    if article != nil && article.FactCheckResult != nil {
+        check = fmt.Sprintf("%s (%.2f)", article.FactCheckResult.ReliabilityTier, article.FactCheckResult.Score)
+    }
+    if floor := trustFloor(); floor > 0 {
+        check += fmt.Sprintf("\nGlobal floor %.2f", floor)
+    }
+    posts := "None recorded"
+    if article != nil {
+        if records, err := b.database.GetArticleMessages(article.ID); err == nil && len(records) > 0 {
+            channels := make([]string, 0, len(records))
+            for _, record := range records {
+                channels = append(channels, fmt.Sprintf("<#%s>", record.ChannelID))
+            }
+            posts = strings.Join(channels, ", ")
+        }
+    }
+    embed.Fields = []*discordgo.MessageEmbedField{
+        {Name: "Stored", Value: stored, Inline: true},
+        {Name: "Fact check", Value: check, Inline: true},
+        {Name: "Posted in", Value: truncateString(posts, 1024), Inline: true},
+    }
+    return embed
+}
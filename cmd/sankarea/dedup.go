@@ -0,0 +1,167 @@
+// cmd/sankarea/dedup.go
+package main
+
+import (
+    "fmt"
+    "net/url"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// Dedup scopes: an article is posted once overall, or once per channel so
+// mirror channels each get their copy
+const (
+    DedupScopeGlobal  = "global"
+    DedupScopeChannel = "channel"
+)
+
+// dedupScope returns the configured dedup scope, global by default
+func dedupScope() string {
+    if cfg != nil && cfg.DedupScope == DedupScopeChannel {
+        return DedupScopeChannel
+    }
+    return DedupScopeGlobal
+}
+
+// imageSizeSuffix matches size variants like "-1024x768" that CDNs append before the extension
+var imageSizeSuffix = regexp.MustCompile(`-\d+x\d+(\.[a-zA-Z]+)$`)
+
+// normalizeImageURL reduces an image URL to a form that matches across rehosted copies
+func normalizeImageURL(raw string) string {
+    u, err := url.Parse(strings.TrimSpace(raw))
+    if err != nil || u.Host == "" {
+        return strings.ToLower(strings.TrimSpace(raw))
+    }
+
+    host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+    path := imageSizeSuffix.ReplaceAllString(u.Path, "$1")
+    return host + strings.ToLower(path)
+}
+
+// titleSimilarity returns the Jaccard similarity of the words in two titles
+func titleSimilarity(a, b string) float64 {
+    wordsA := strings.Fields(normalizeTitle(a))
+    wordsB := strings.Fields(normalizeTitle(b))
+    if len(wordsA) == 0 || len(wordsB) == 0 {
+        return 0
+    }
+
+    set := make(map[string]bool, len(wordsA))
+    for _, w := range wordsA {
+        set[w] = true
+    }
+
+    shared := 0
+    union := len(set)
+    seen := make(map[string]bool, len(wordsB))
+    for _, w := range wordsB {
+        if seen[w] {
+            continue
+        }
+        seen[w] = true
+        if set[w] {
+            shared++
+        } else {
+            union++
+        }
+    }
+    return float64(shared) / float64(union)
+}
+
+// articleReliability returns the fact-check score used to choose between duplicates
+func articleReliability(a *NewsArticle) float64 {
+    if a.FactCheckResult == nil {
+        return 0
+    }
+    return a.FactCheckResult.Score
+}
+
+// dedupByImage drops articles that reuse the lead image of another article
+// within the configured window and have similar titles. Within a batch the
+// more reliable copy is kept; copies of already stored articles are dropped.
+func (b *Bot) dedupByImage(articles []*NewsArticle) []*NewsArticle {
+    cfg := b.config.ImageDedup
+    if !cfg.Enabled {
+        return articles
+    }
+
+    batchIDs := make(map[string]bool, len(articles))
+    for _, a := range articles {
+        batchIDs[a.ID] = true
+    }
+
+    // Index recently stored articles by image
+    now := time.Now().UTC()
+    window := time.Duration(cfg.WindowHours) * time.Hour
+    stored := make(map[string][]*NewsArticle)
+    recent, err := b.database.GetArticlesByTimeRange(now.Add(-window), now.Add(time.Minute))
+    if err != nil {
+        b.logger.Error("Image dedup: failed to load recent articles: %v", err)
+    }
+    for _, a := range recent {
+        if a.ImageURL != "" && !batchIDs[a.ID] {
+            key := normalizeImageURL(a.ImageURL)
+            stored[key] = append(stored[key], a)
+        }
+    }
+
+    isDuplicate := func(a, other *NewsArticle) bool {
+        return titleSimilarity(a.Title, other.Title) >= cfg.MinTitleSimilarity
+    }
+
+    storedCopy := func(a *NewsArticle, key string) *NewsArticle {
+        for _, other := range stored[key] {
+            if isDuplicate(a, other) {
+                return other
+            }
+        }
+        return nil
+    }
+
+    kept := make([]*NewsArticle, 0, len(articles))
+    byImage := make(map[string][]int) // image key -> indexes in kept
+
+    for _, a := range articles {
+        if a.ImageURL == "" {
+            kept = append(kept, a)
+            continue
+        }
+        key := normalizeImageURL(a.ImageURL)
+
+        // Copies of stored articles are dropped whatever their score, so
+        // this runs before a copy can replace another one from the batch
+        if other := storedCopy(a, key); other != nil {
+            b.logger.Info("Image dedup: skipping %q from %s, already have %q from %s",
+                a.Title, a.Source, other.Title, other.Source)
+            traceDecision(a.URL, StageImageDedup, "skipped", fmt.Sprintf("same image as stored %q from %s", other.Title, other.Source))
+            continue
+        }
+
+        // A batch can hold several stories sharing an image, e.g. a stock
+        // photo, so each kept article with the image is compared
+        handled := false
+        for _, idx := range byImage[key] {
+            if !isDuplicate(a, kept[idx]) {
+                continue
+            }
+            if articleReliability(a) > articleReliability(kept[idx]) {
+                b.logger.Info("Image dedup: preferring %s over %s for %q", a.Source, kept[idx].Source, a.Title)
+                traceDecision(kept[idx].URL, StageImageDedup, "skipped", fmt.Sprintf("same story from %s with a higher fact-check score", a.Source))
+                kept[idx] = a
+            } else {
+                traceDecision(a.URL, StageImageDedup, "skipped", fmt.Sprintf("same story as %q from %s in this batch", kept[idx].Title, kept[idx].Source))
+            }
+            handled = true
+            break
+        }
+        if handled {
+            continue
+        }
+
+        byImage[key] = append(byImage[key], len(kept))
+        kept = append(kept, a)
+    }
+
+    return kept
+}
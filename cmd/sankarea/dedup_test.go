@@ -0,0 +1,65 @@
+// cmd/sankarea/dedup_test.go
+package main
+
+import (
+    "math"
+    "testing"
+)
+
+func TestNormalizeImageURL(t *testing.T) {
+    tests := []struct {
+        name string
+        a, b string
+        same bool
+    }{
+        {"size suffix", "https://cdn.example.com/img/photo-1024x768.jpg", "https://cdn.example.com/img/photo.jpg", true},
+        {"different size suffixes", "https://cdn.example.com/img/photo-300x200.png", "https://cdn.example.com/img/photo-1200x800.png", true},
+        {"www prefix", "https://www.example.com/photo.jpg", "https://example.com/photo.jpg", true},
+        {"host and path case", "https://CDN.Example.com/Img/Photo.JPG", "https://cdn.example.com/img/photo.jpg", true},
+        {"query and scheme ignored", "http://example.com/photo.jpg?w=640", "https://example.com/photo.jpg", true},
+        {"surrounding space", "  https://example.com/photo.jpg ", "https://example.com/photo.jpg", true},
+        {"size inside the name kept", "https://example.com/1024x768-photo.jpg", "https://example.com/photo.jpg", false},
+        {"different image", "https://example.com/photo-1.jpg", "https://example.com/photo-2.jpg", false},
+        {"different host", "https://a.example.com/photo.jpg", "https://b.example.com/photo.jpg", false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            a, b := normalizeImageURL(tt.a), normalizeImageURL(tt.b)
+            if (a == b) != tt.same {
+                t.Errorf("normalizeImageURL(%q) = %q, normalizeImageURL(%q) = %q, want same: %v", tt.a, a, tt.b, b, tt.same)
+            }
+        })
+    }
+
+    if got, want := normalizeImageURL("https://www.Example.com/a/Photo-640x480.webp"), "example.com/a/photo.webp"; got != want {
+        t.Errorf("normalizeImageURL = %q, want %q", got, want)
+    }
+    // Relative or unparsable URLs are only trimmed and lowercased
+    if got, want := normalizeImageURL(" /Images/Photo.jpg "), "/images/photo.jpg"; got != want {
+        t.Errorf("normalizeImageURL of a relative URL = %q, want %q", got, want)
+    }
+}
+
+func TestTitleSimilarity(t *testing.T) {
+    tests := []struct {
+        a, b string
+        want float64
+    }{
+        {"Storm hits the coast", "Storm hits the coast", 1},
+        {"Storm Hits  the COAST", "storm hits the coast", 1},
+        {"Storm hits the coast", "Storm hits the city", 3.0 / 5},
+        {"Storm hits coast", "Markets rally on earnings", 0},
+        {"storm storm storm", "storm", 1},
+        {"", "Storm hits the coast", 0},
+        {"Storm hits the coast", "   ", 0},
+    }
+    for _, tt := range tests {
+        got := titleSimilarity(tt.a, tt.b)
+        if math.Abs(got-tt.want) > 1e-9 {
+            t.Errorf("titleSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+        }
+        if back := titleSimilarity(tt.b, tt.a); math.Abs(back-got) > 1e-9 {
+            t.Errorf("titleSimilarity isn't symmetric for %q and %q: %v vs %v", tt.a, tt.b, got, back)
+        }
+    }
+}
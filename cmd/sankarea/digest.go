@@ -3,6 +3,7 @@ package main
 
 import (
     "fmt"
+    "math"
     "sort"
     "strings"
     "time"
@@ -10,29 +11,21 @@ import (
     "github.com/bwmarrin/discordgo"
 )
 
+// digestSectionSize is the most articles one category's embed lists
+const digestSectionSize = 10
+
 // DigestResult represents a formatted news digest
 type DigestResult struct {
     Embeds     []*discordgo.MessageEmbed
+    Files      []*discordgo.File // attachments referenced by the first embed
     TotalNews  int
     Categories map[string]int
 }
 
-// generateDigest creates a news digest for the specified time range
-func generateDigest(startTime, endTime time.Time) (*DigestResult, error) {
-    // Load state for recent articles
-    state, err := LoadState()
-    if err != nil {
-        return nil, fmt.Errorf("failed to load state: %v", err)
-    }
-
-    // Filter articles within time range
-    var articles []*NewsArticle
-    for _, article := range state.RecentArticles {
-        if article.PublishedAt.After(startTime) && article.PublishedAt.Before(endTime) {
-            articles = append(articles, article)
-        }
-    }
-
+// buildDigest lays out articles published between startTime and endTime as a
+// digest, with labels in lang. Every digest goes through here, so the category
+// caps and the per-source balancing of pickTop apply to all of them.
+func buildDigest(articles []*NewsArticle, startTime, endTime time.Time, lang string) *DigestResult {
     // Sort articles by category and then by publish date
     sort.Slice(articles, func(i, j int) bool {
         if articles[i].Category == articles[j].Category {
@@ -49,27 +42,38 @@ func generateDigest(startTime, endTime time.Time) (*DigestResult, error) {
         categoryCount[article.Category]++
     }
 
+    // Each category gets a fair share of the article budget
+    priorities := sourcePriorities()
+    caps := digestCategoryCaps(categoryArticles, digestMaxArticles(), digestSectionSize, digestShareMode(), priorities)
+
+    templates := digestTemplates()
+    summaryData := &DigestSummaryData{
+        Lang:  lang,
+        Start: startTime,
+        End:   endTime,
+        Total: len(articles),
+    }
+    for category, count := range categoryCount {
+        summaryData.Categories = append(summaryData.Categories, DigestCategoryData{
+            Lang:  lang,
+            Name:  category,
+            Emoji: getCategoryEmoji(category),
+            Count: count,
+            Shown: caps[category],
+        })
+    }
+
     // Create digest embeds
     var embeds []*discordgo.MessageEmbed
 
     // Summary embed
     summaryEmbed := &discordgo.MessageEmbed{
-        Title: "📰 News Digest Summary",
-        Description: fmt.Sprintf("News from %s to %s",
-            startTime.Format("2006-01-02 15:04 MST"),
-            endTime.Format("2006-01-02 15:04 MST")),
-        Fields: make([]*discordgo.MessageEmbedField, 0),
-        Color:  0x7289DA,
-    }
-
-    // Add category summaries
-    for category, count := range categoryCount {
-        summaryEmbed.Fields = append(summaryEmbed.Fields, &discordgo.MessageEmbedField{
-            Name:   getCategoryEmoji(category) + " " + category,
-            Value:  fmt.Sprintf("%d articles", count),
-            Inline: true,
-        })
+        Title:       tr(lang, "digest.summary_title"),
+        Description: templates.RenderSummary(summaryData),
+        Fields:      digestCategoryFields(summaryData),
+        Color:       0x7289DA,
     }
+    files := applyDigestHeader(summaryEmbed, summaryData)
 
     embeds = append(embeds, summaryEmbed)
 
@@ -80,36 +84,37 @@ func generateDigest(startTime, endTime time.Time) (*DigestResult, error) {
             continue
         }
 
+        // Add top articles up to the category's share, balanced across sources
+        picked := pickTop(articles, caps[category], digestMaxSourceShare(), priorities)
+
         // Create category embed
         categoryEmbed := &discordgo.MessageEmbed{
-            Title: fmt.Sprintf("%s %s News", getCategoryEmoji(category), category),
-            Color: getCategoryColor(category),
+            Title: getCategoryEmoji(category) + " " + tr(lang, "digest.category_news", category),
+            Description: templates.RenderCategory(&DigestCategoryData{
+                Lang:  lang,
+                Name:  category,
+                Emoji: getCategoryEmoji(category),
+                Count: len(articles),
+                Shown: len(picked),
+            }),
+            Color:  getCategoryColor(category),
             Fields: make([]*discordgo.MessageEmbedField, 0),
         }
 
-        // Add top articles
-        maxArticles := 10 // Maximum articles per category
-        for i, article := range articles {
-            if i >= maxArticles {
-                break
-            }
-
+        for _, article := range picked {
             // Create article field
             field := &discordgo.MessageEmbedField{
-                Name: truncateString(article.Title, 256),
-                Value: fmt.Sprintf("Source: %s\n%s\n%s",
-                    article.Source,
-                    article.URL,
-                    getReliabilityBadge(article)),
+                Name:   truncateString(article.Title, 256),
+                Value:  templates.RenderArticle(newDigestArticleData(article, lang)),
                 Inline: false,
             }
             categoryEmbed.Fields = append(categoryEmbed.Fields, field)
         }
 
         // Add overflow message if needed
-        if len(articles) > maxArticles {
+        if len(articles) > len(picked) {
             categoryEmbed.Footer = &discordgo.MessageEmbedFooter{
-                Text: fmt.Sprintf("And %d more articles...", len(articles)-maxArticles),
+                Text: tr(lang, "digest.more", len(articles)-len(picked)),
             }
         }
 
@@ -118,27 +123,145 @@ func generateDigest(startTime, endTime time.Time) (*DigestResult, error) {
 
     return &DigestResult{
         Embeds:     embeds,
+        Files:      files,
         TotalNews:  len(articles),
         Categories: categoryCount,
-    }, nil
+    }
+}
+
+// Messages splits the digest's embeds into messages within Discord's embed
+// count and size limits. The first message carries the header attachments.
+func (d *DigestResult) Messages() []*discordgo.MessageSend {
+    var messages []*discordgo.MessageSend
+    current := &discordgo.MessageSend{Files: d.Files}
+    size := 0
+    for _, embed := range d.Embeds {
+        embedLength := embedSize(embed)
+        if len(current.Embeds) > 0 && (len(current.Embeds) >= MaxEmbedsPerMessage || size+embedLength > MaxMessageEmbedSize) {
+            messages = append(messages, current)
+            current = &discordgo.MessageSend{}
+            size = 0
+        }
+        current.Embeds = append(current.Embeds, embed)
+        size += embedLength
+    }
+    if len(current.Embeds) > 0 {
+        messages = append(messages, current)
+    }
+    return messages
+}
+
+// digestLimitProblems reports digest embeds that Discord would reject
+func digestLimitProblems(embeds []*discordgo.MessageEmbed) []string {
+    var problems []string
+    for _, embed := range embeds {
+        size := embedSize(embed)
+        for _, field := range embed.Fields {
+            if len(field.Value) > 1024 {
+                problems = append(problems, fmt.Sprintf("%q has a field over 1024 characters", embed.Title))
+            }
+        }
+        if len(embed.Fields) > MaxEmbedFields {
+            problems = append(problems, fmt.Sprintf("%q has %d fields (max %d)", embed.Title, len(embed.Fields), MaxEmbedFields))
+        }
+        if len(embed.Description) > MaxEmbedLength {
+            problems = append(problems, fmt.Sprintf("%q description exceeds %d characters", embed.Title, MaxEmbedLength))
+        }
+        if size > MaxMessageEmbedSize {
+            problems = append(problems, fmt.Sprintf("%q totals %d characters (max %d)", embed.Title, size, MaxMessageEmbedSize))
+        }
+    }
+    return problems
+}
+
+// pickTop selects up to max articles, taking them round-robin across sources so
+// one chatty feed can't dominate. Each round favors higher priority tiers, then
+// the newest and most trusted article, and no source gets more than maxShare of
+// the slots.
+func pickTop(articles []*NewsArticle, max int, maxShare float64, priorities map[string]int) []*NewsArticle {
+    // Bucket articles by source, newest first
+    bySource := make(map[string][]*NewsArticle)
+    for _, article := range articles {
+        bySource[article.Source] = append(bySource[article.Source], article)
+    }
+    for _, bucket := range bySource {
+        sort.Slice(bucket, func(i, j int) bool {
+            return bucket[i].PublishedAt.After(bucket[j].PublishedAt)
+        })
+    }
+
+    perSource := max
+    if maxShare > 0 && maxShare < 1 {
+        perSource = int(math.Ceil(float64(max) * maxShare))
+    }
+
+    var picked []*NewsArticle
+    for round := 0; round < perSource && len(picked) < max; round++ {
+        // Gather this round's candidates: the next article from each source
+        var candidates []*NewsArticle
+        for _, bucket := range bySource {
+            if round < len(bucket) {
+                candidates = append(candidates, bucket[round])
+            }
+        }
+        if len(candidates) == 0 {
+            break
+        }
+
+        sort.Slice(candidates, func(i, j int) bool {
+            a, b := candidates[i], candidates[j]
+            tierA, tierB := articleTier(a, priorities), articleTier(b, priorities)
+            if tierA != tierB {
+                return tierA < tierB
+            }
+            if !a.PublishedAt.Equal(b.PublishedAt) {
+                return a.PublishedAt.After(b.PublishedAt)
+            }
+            return articleReliability(a) > articleReliability(b)
+        })
+        for _, article := range candidates {
+            if len(picked) >= max {
+                break
+            }
+            picked = append(picked, article)
+        }
+    }
+
+    return picked
+}
+
+// articleTier returns the priority tier of an article's source
+func articleTier(article *NewsArticle, priorities map[string]int) int {
+    if tier, ok := priorities[article.Source]; ok {
+        return tier
+    }
+    return SourcePriorityNormal
+}
+
+// digestMaxSourceShare returns the configured cap on a single source's share of a digest section
+func digestMaxSourceShare() float64 {
+    if cfg != nil && cfg.DigestMaxSourceShare > 0 {
+        return cfg.DigestMaxSourceShare
+    }
+    return 0.3
 }
 
 // getReliabilityBadge returns a formatted reliability indicator
-func getReliabilityBadge(article *NewsArticle) string {
+func getReliabilityBadge(article *NewsArticle, lang string) string {
     if article.FactCheckResult == nil {
-        return "🔄 Fact check pending"
+        return "🔄 " + tr(lang, "digest.check_pending")
     }
 
     var badge string
     switch article.FactCheckResult.ReliabilityTier {
     case "High":
-        badge = "🟢 High reliability"
+        badge = "🟢 " + tr(lang, "reliability.high")
     case "Medium":
-        badge = "🟡 Medium reliability"
+        badge = "🟡 " + tr(lang, "reliability.medium")
     case "Low":
-        badge = "🔴 Low reliability"
+        badge = "🔴 " + tr(lang, "reliability.low")
     default:
-        badge = "⚫ Unknown reliability"
+        badge = "⚫ " + tr(lang, "reliability.unknown")
     }
 
     return fmt.Sprintf("%s (%.1f/1.0)", badge, article.FactCheckResult.Score)
@@ -0,0 +1,116 @@
+// cmd/sankarea/digest_category_cap.go
+package main
+
+import (
+    "sort"
+)
+
+// Ways to divide the digest's article budget between categories
+const (
+    DigestSharesEqual    = "equal"    // every category gets the same share
+    DigestSharesPriority = "priority" // categories from higher-priority sources get more
+)
+
+// defaultDigestMaxArticles is the digest's article budget when digest_max_articles is unset
+const defaultDigestMaxArticles = 40
+
+// digestMaxArticles returns how many articles a digest lists across all categories
+func digestMaxArticles() int {
+    if cfg != nil && cfg.DigestMaxArticles > 0 {
+        return cfg.DigestMaxArticles
+    }
+    return defaultDigestMaxArticles
+}
+
+// digestShareMode returns how the article budget is split between categories
+func digestShareMode() string {
+    if cfg != nil && cfg.DigestCategoryShares == DigestSharesPriority {
+        return DigestSharesPriority
+    }
+    return DigestSharesEqual
+}
+
+// categoryShareWeight weighs a category for its share of the budget. With
+// priority shares it ranges from 1 to 2 with the average priority of the
+// category's sources, so no category is starved.
+func categoryShareWeight(articles []*NewsArticle, mode string, priorities map[string]int) float64 {
+    if mode != DigestSharesPriority || len(articles) == 0 {
+        return 1
+    }
+    total := 0.0
+    for _, article := range articles {
+        total += priorityScore(articleTier(article, priorities))
+    }
+    return 1 + total/float64(len(articles))
+}
+
+// digestCategoryCaps divides a budget of total articles between categories
+// by weight, never giving a category more than it has or more than
+// perCategory (0 for no limit). Slots a small category can't use go to the
+// others, so the budget is only left unused when every category is shown.
+func digestCategoryCaps(categories map[string][]*NewsArticle, total, perCategory int, mode string, priorities map[string]int) map[string]int {
+    caps := make(map[string]int, len(categories))
+    need := make(map[string]int, len(categories))
+    weights := make(map[string]float64, len(categories))
+    var open []string
+    for category, articles := range categories {
+        caps[category] = 0
+        need[category] = len(articles)
+        if perCategory > 0 && need[category] > perCategory {
+            need[category] = perCategory
+        }
+        if need[category] > 0 {
+            weights[category] = categoryShareWeight(articles, mode, priorities)
+            open = append(open, category)
+        }
+    }
+    // Heaviest first, so rounding leftovers go to the categories that weigh most
+    sort.Slice(open, func(i, j int) bool {
+        if weights[open[i]] != weights[open[j]] {
+            return weights[open[i]] > weights[open[j]]
+        }
+        return open[i] < open[j]
+    })
+
+    remaining := total
+    for remaining > 0 && len(open) > 0 {
+        sum := 0.0
+        for _, category := range open {
+            sum += weights[category]
+        }
+
+        // Categories that fit in their share are filled and drop out; their
+        // unused share is divided again in the next round
+        var still []string
+        freed := 0
+        for _, category := range open {
+            share := int(float64(remaining) * weights[category] / sum)
+            if want := need[category] - caps[category]; want <= share {
+                caps[category] = need[category]
+                freed += want
+                continue
+            }
+            still = append(still, category)
+        }
+        if len(still) < len(open) {
+            remaining -= freed
+            open = still
+            continue
+        }
+
+        // Every category wants more than its share: hand out the shares and
+        // the rounding leftovers, heaviest first
+        given := 0
+        for _, category := range open {
+            share := int(float64(remaining) * weights[category] / sum)
+            caps[category] += share
+            given += share
+        }
+        for idx := 0; given < remaining; idx++ {
+            caps[open[idx%len(open)]]++
+            given++
+        }
+        break
+    }
+    return caps
+}
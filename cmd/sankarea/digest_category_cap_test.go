@@ -0,0 +1,98 @@
+// cmd/sankarea/digest_category_cap_test.go
+package main
+
+import (
+    "fmt"
+    "testing"
+)
+
+// digestCategories returns categories holding the given numbers of
+// articles, each from a source named after its category
+func digestCategories(sizes map[string]int) map[string][]*NewsArticle {
+    categories := make(map[string][]*NewsArticle, len(sizes))
+    for category, size := range sizes {
+        categories[category] = digestArticles(category, size, 0)
+    }
+    return categories
+}
+
+func TestDigestCategoryCaps(t *testing.T) {
+    tests := []struct {
+        name        string
+        sizes       map[string]int
+        total       int
+        perCategory int
+        mode        string
+        priorities  map[string]int
+        want        map[string]int
+    }{
+        {
+            name:  "equal shares",
+            sizes: map[string]int{"a": 10, "b": 10, "c": 10},
+            total: 12, mode: DigestSharesEqual,
+            want: map[string]int{"a": 4, "b": 4, "c": 4},
+        },
+        {
+            name:  "small category's slots go to the others",
+            sizes: map[string]int{"a": 2, "b": 10, "c": 10},
+            total: 12, mode: DigestSharesEqual,
+            want: map[string]int{"a": 2, "b": 5, "c": 5},
+        },
+        {
+            name:  "rounding leftover goes to the first category",
+            sizes: map[string]int{"a": 10, "b": 10, "c": 10},
+            total: 13, mode: DigestSharesEqual,
+            want: map[string]int{"a": 5, "b": 4, "c": 4},
+        },
+        {
+            name:  "per-category limit leaves the budget unused",
+            sizes: map[string]int{"a": 10, "b": 10},
+            total: 12, perCategory: 3, mode: DigestSharesEqual,
+            want: map[string]int{"a": 3, "b": 3},
+        },
+        {
+            name:  "budget larger than every category",
+            sizes: map[string]int{"a": 2, "b": 3, "empty": 0},
+            total: 20, mode: DigestSharesEqual,
+            want: map[string]int{"a": 2, "b": 3, "empty": 0},
+        },
+        {
+            name:  "priority shares favour higher-priority sources",
+            sizes: map[string]int{"a": 10, "b": 10},
+            total: 9, mode: DigestSharesPriority,
+            priorities: map[string]int{"a": SourcePriorityHigh, "b": SourcePriorityLow},
+            want:       map[string]int{"a": 6, "b": 3},
+        },
+        {
+            name:  "priorities ignored with equal shares",
+            sizes: map[string]int{"a": 10, "b": 10},
+            total: 8, mode: DigestSharesEqual,
+            priorities: map[string]int{"a": SourcePriorityHigh, "b": SourcePriorityLow},
+            want:       map[string]int{"a": 4, "b": 4},
+        },
+        {
+            name:  "no budget",
+            sizes: map[string]int{"a": 10},
+            total: 0, mode: DigestSharesEqual,
+            want: map[string]int{"a": 0},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            caps := digestCategoryCaps(digestCategories(tt.sizes), tt.total, tt.perCategory, tt.mode, tt.priorities)
+            if fmt.Sprint(caps) != fmt.Sprint(tt.want) {
+                t.Errorf("digestCategoryCaps = %v, want %v", caps, tt.want)
+            }
+            used := 0
+            for category, limit := range caps {
+                used += limit
+                if limit > tt.sizes[category] {
+                    t.Errorf("%s got %d slots for %d articles", category, limit, tt.sizes[category])
+                }
+            }
+            if used > tt.total {
+                t.Errorf("used %d slots of a budget of %d", used, tt.total)
+            }
+        })
+    }
+}
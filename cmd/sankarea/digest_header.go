@@ -0,0 +1,205 @@
+// cmd/sankarea/digest_header.go
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "image"
+    "image/color"
+    "image/draw"
+    "image/png"
+    "net/url"
+    "sort"
+    "strings"
+    "text/template"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// digestChartFilename is the attachment name the chart is referenced by
+const digestChartFilename = "digest-chart.png"
+
+// Chart geometry, in pixels
+const (
+    digestChartWidth   = 320
+    digestChartBar     = 16
+    digestChartGap     = 6
+    digestChartPadding = 8
+)
+
+// DigestHeaderConfig dresses up the digest's first embed; every field is optional
+type DigestHeaderConfig struct {
+    ImageURL string `json:"image_url,omitempty"` // banner shown as the embed image
+    Intro    string `json:"intro,omitempty"`     // text/template placed above the summary
+    Chart    bool   `json:"chart,omitempty"`     // attach a per-category article count chart
+}
+
+// digestChartColors are the bar colors, matched by the legend squares below
+var digestChartColors = []color.RGBA{
+    {221, 46, 68, 255},   // red
+    {244, 144, 12, 255},  // orange
+    {253, 203, 88, 255},  // yellow
+    {120, 177, 89, 255},  // green
+    {85, 172, 238, 255},  // blue
+    {170, 142, 214, 255}, // purple
+    {193, 105, 79, 255},  // brown
+    {49, 55, 61, 255},    // black
+    {230, 231, 232, 255}, // white
+}
+
+// digestChartLegend labels each bar's category field with its color
+var digestChartLegend = []string{"🟥", "🟧", "🟨", "🟩", "🟦", "🟪", "🟫", "⬛", "⬜"}
+
+// ValidateDigestHeader checks the header image URL and intro template
+func ValidateDigestHeader(c DigestHeaderConfig) error {
+    if c.ImageURL != "" {
+        u, err := url.Parse(c.ImageURL)
+        if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+            return fmt.Errorf("image_url must be an http(s) URL")
+        }
+    }
+    if _, err := parseDigestIntro(c.Intro); err != nil {
+        return err
+    }
+    return nil
+}
+
+// parseDigestIntro parses the intro template; an empty intro yields nil
+func parseDigestIntro(text string) (*template.Template, error) {
+    if strings.TrimSpace(text) == "" {
+        return nil, nil
+    }
+    t, err := template.New("intro").Funcs(digestTemplateFuncs).Option("missingkey=error").Parse(text)
+    if err != nil {
+        return nil, fmt.Errorf("invalid intro template: %v", err)
+    }
+    return t, nil
+}
+
+// digestHeader returns the configured header
+func digestHeader() DigestHeaderConfig {
+    if cfg == nil {
+        return DigestHeaderConfig{}
+    }
+    return cfg.DigestHeader
+}
+
+// sortDigestCategories orders categories largest first, then by name
+func sortDigestCategories(categories []DigestCategoryData) {
+    sort.Slice(categories, func(i, j int) bool {
+        if categories[i].Count == categories[j].Count {
+            return categories[i].Name < categories[j].Name
+        }
+        return categories[i].Count > categories[j].Count
+    })
+}
+
+// digestCategoryFields builds the summary embed's per-category fields, with
+// a color legend when the chart is enabled
+func digestCategoryFields(data *DigestSummaryData) []*discordgo.MessageEmbedField {
+    sortDigestCategories(data.Categories)
+    chart := digestHeader().Chart
+
+    fields := make([]*discordgo.MessageEmbedField, 0, len(data.Categories))
+    for idx, category := range data.Categories {
+        if len(fields) >= MaxEmbedFields {
+            break
+        }
+        name := category.Emoji + " " + category.Name
+        if chart && idx < len(digestChartLegend) {
+            name = digestChartLegend[idx] + " " + name
+        }
+        value := tr(data.Lang, "digest.articles", category.Count)
+        if category.Shown < category.Count {
+            value += " · " + tr(data.Lang, "digest.overflow", category.Count-category.Shown)
+        }
+        fields = append(fields, &discordgo.MessageEmbedField{
+            Name:   name,
+            Value:  value,
+            Inline: true,
+        })
+    }
+    return fields
+}
+
+// applyDigestHeader adds the configured image, intro and chart to the
+// digest's first embed; it returns any files the embed now references
+func applyDigestHeader(embed *discordgo.MessageEmbed, data *DigestSummaryData) []*discordgo.File {
+    header := digestHeader()
+
+    if intro, err := parseDigestIntro(header.Intro); err != nil {
+        Logger().Printf("Skipping digest intro: %v", err)
+    } else if intro != nil {
+        if text := renderDigestTemplate(intro, data, MaxEmbedLength); text != "" {
+            if embed.Description != "" {
+                text += "\n\n" + embed.Description
+            }
+            embed.Description = truncateString(text, MaxEmbedLength)
+        }
+    }
+
+    if header.ImageURL != "" {
+        embed.Image = &discordgo.MessageEmbedImage{URL: header.ImageURL}
+    }
+
+    if !header.Chart || len(data.Categories) == 0 {
+        return nil
+    }
+    chart, err := renderDigestChart(data.Categories)
+    if err != nil {
+        Logger().Printf("Failed to render digest chart: %v", err)
+        return nil
+    }
+
+    // The banner keeps the large slot; the chart drops to the thumbnail
+    ref := "attachment://" + digestChartFilename
+    if embed.Image == nil {
+        embed.Image = &discordgo.MessageEmbedImage{URL: ref}
+    } else {
+        embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: ref}
+    }
+    return []*discordgo.File{{
+        Name:        digestChartFilename,
+        ContentType: "image/png",
+        Reader:      bytes.NewReader(chart),
+    }}
+}
+
+// renderDigestChart draws a horizontal bar per category, in the order of the
+// summary fields, as a PNG
+func renderDigestChart(categories []DigestCategoryData) ([]byte, error) {
+    if len(categories) > len(digestChartColors) {
+        categories = categories[:len(digestChartColors)]
+    }
+
+    max := 0
+    for _, category := range categories {
+        if category.Count > max {
+            max = category.Count
+        }
+    }
+    if max == 0 {
+        return nil, fmt.Errorf("no articles to chart")
+    }
+
+    height := 2*digestChartPadding + len(categories)*digestChartBar + (len(categories)-1)*digestChartGap
+    img := image.NewRGBA(image.Rect(0, 0, digestChartWidth, height))
+    draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{47, 49, 54, 255}}, image.Point{}, draw.Src)
+
+    span := digestChartWidth - 2*digestChartPadding
+    for idx, category := range categories {
+        width := span * category.Count / max
+        if width < 2 {
+            width = 2
+        }
+        top := digestChartPadding + idx*(digestChartBar+digestChartGap)
+        bar := image.Rect(digestChartPadding, top, digestChartPadding+width, top+digestChartBar)
+        draw.Draw(img, bar, &image.Uniform{digestChartColors[idx]}, image.Point{}, draw.Src)
+    }
+
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, img); err != nil {
+        return nil, fmt.Errorf("failed to encode chart: %v", err)
+    }
+    return buf.Bytes(), nil
+}
@@ -0,0 +1,204 @@
+// cmd/sankarea/digest_template.go
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "strings"
+    "sync"
+    "text/template"
+    "time"
+)
+
+// Default digest templates; each reproduces the built-in layout
+const (
+    defaultDigestSummaryTemplate  = `{{if .Start.IsZero}}{{tr .Lang "digest.summary_for" (fmtTime .End)}}{{else}}{{tr .Lang "digest.range" (fmtTime .Start) (fmtTime .End)}}{{end}}`
+    defaultDigestCategoryTemplate = ``
+    defaultDigestArticleTemplate  = `{{tr .Lang "label.source"}}: {{.Source}} | {{fmtTime .Published}}
+{{.URL}}
+{{.Reliability}}`
+)
+
+// DigestTemplateConfig holds operator-supplied text/template sources for the
+// digest; empty fields use the defaults
+type DigestTemplateConfig struct {
+    Summary  string `json:"summary,omitempty"`  // summary embed description
+    Category string `json:"category,omitempty"` // category embed description
+    Article  string `json:"article,omitempty"`  // each article's field in a category
+}
+
+// DigestSummaryData is what the summary template sees
+type DigestSummaryData struct {
+    Lang       string
+    Start      time.Time // zero for digests without a fixed window
+    End        time.Time
+    Total      int
+    Categories []DigestCategoryData
+}
+
+// DigestCategoryData is what the category template sees
+type DigestCategoryData struct {
+    Lang  string
+    Name  string
+    Emoji string
+    Count int // articles in the category
+    Shown int // articles listed in the section
+}
+
+// DigestArticleData is what the article template sees
+type DigestArticleData struct {
+    Lang      string
+    Title     string
+    URL       string
+    Source    string
+    Category  string
+    Published time.Time
+    Content   string
+    Score     float64 // fact check score, 0 if unchecked
+    Tier      string  // fact check tier, "" if unchecked
+
+    article *NewsArticle
+}
+
+// newDigestArticleData wraps an article for the article template
+func newDigestArticleData(article *NewsArticle, lang string) *DigestArticleData {
+    data := &DigestArticleData{
+        Lang:      lang,
+        Title:     article.Title,
+        URL:       article.URL,
+        Source:    article.Source,
+        Category:  article.Category,
+        Published: article.PublishedAt,
+        Content:   article.Content,
+        article:   article,
+    }
+    if article.FactCheckResult != nil {
+        data.Score = article.FactCheckResult.Score
+        data.Tier = article.FactCheckResult.ReliabilityTier
+    }
+    return data
+}
+
+// Reliability renders the localized reliability badge
+func (d *DigestArticleData) Reliability() string {
+    return getReliabilityBadge(d.article, d.Lang)
+}
+
+// Sentiment analyzes the article on demand, so templates that don't use it
+// cost no API calls; it is "" when analysis is unavailable
+func (d *DigestArticleData) Sentiment() string {
+    analysis, err := AnalyzeArticleSentiment(&Article{
+        Title:   d.article.Title,
+        Content: d.article.Content,
+        URL:     d.article.URL,
+        Source:  d.article.Source,
+    })
+    if err != nil || analysis == nil {
+        return ""
+    }
+    return analysis.Sentiment
+}
+
+// digestTemplateFuncs are available to every digest template
+var digestTemplateFuncs = template.FuncMap{
+    "tr":       tr,
+    "truncate": truncateString,
+    "upper":    strings.ToUpper,
+    "lower":    strings.ToLower,
+    "fmtTime": func(t time.Time) string {
+        return t.Format("2006-01-02 15:04 MST")
+    },
+}
+
+// DigestTemplates is a parsed set of digest templates
+type DigestTemplates struct {
+    summary  *template.Template
+    category *template.Template
+    article  *template.Template
+}
+
+// compiledDigestTemplates caches the parsed templates for the config they came from
+var compiledDigestTemplates struct {
+    sync.Mutex
+    source DigestTemplateConfig
+    parsed *DigestTemplates
+}
+
+// ParseDigestTemplates parses a template config, filling in defaults
+func ParseDigestTemplates(c DigestTemplateConfig) (*DigestTemplates, error) {
+    parse := func(name, text, fallback string) (*template.Template, error) {
+        if strings.TrimSpace(text) == "" {
+            text = fallback
+        }
+        t, err := template.New(name).Funcs(digestTemplateFuncs).Option("missingkey=error").Parse(text)
+        if err != nil {
+            return nil, fmt.Errorf("invalid %s template: %v", name, err)
+        }
+        return t, nil
+    }
+
+    var (
+        t   DigestTemplates
+        err error
+    )
+    if t.summary, err = parse("summary", c.Summary, defaultDigestSummaryTemplate); err != nil {
+        return nil, err
+    }
+    if t.category, err = parse("category", c.Category, defaultDigestCategoryTemplate); err != nil {
+        return nil, err
+    }
+    if t.article, err = parse("article", c.Article, defaultDigestArticleTemplate); err != nil {
+        return nil, err
+    }
+    return &t, nil
+}
+
+// digestTemplates returns the configured templates, falling back to the
+// defaults if they fail to parse (config validation normally catches that)
+func digestTemplates() *DigestTemplates {
+    var source DigestTemplateConfig
+    if cfg != nil {
+        source = cfg.DigestTemplates
+    }
+
+    compiledDigestTemplates.Lock()
+    defer compiledDigestTemplates.Unlock()
+    if compiledDigestTemplates.parsed != nil && compiledDigestTemplates.source == source {
+        return compiledDigestTemplates.parsed
+    }
+
+    parsed, err := ParseDigestTemplates(source)
+    if err != nil {
+        Logger().Printf("Using default digest templates: %v", err)
+        parsed, _ = ParseDigestTemplates(DigestTemplateConfig{})
+    }
+    compiledDigestTemplates.source = source
+    compiledDigestTemplates.parsed = parsed
+    return parsed
+}
+
+// RenderSummary renders the summary embed description
+func (t *DigestTemplates) RenderSummary(data *DigestSummaryData) string {
+    return renderDigestTemplate(t.summary, data, MaxEmbedLength)
+}
+
+// RenderCategory renders a category embed description
+func (t *DigestTemplates) RenderCategory(data *DigestCategoryData) string {
+    return renderDigestTemplate(t.category, data, MaxEmbedLength)
+}
+
+// RenderArticle renders an article's field value
+func (t *DigestTemplates) RenderArticle(data *DigestArticleData) string {
+    return renderDigestTemplate(t.article, data, 1024)
+}
+
+// renderDigestTemplate executes a template and trims the result to fit an
+// embed; a template that fails at runtime renders as an error note
+func renderDigestTemplate(t *template.Template, data interface{}, maxLen int) string {
+    var buf bytes.Buffer
+    if err := t.Execute(&buf, data); err != nil {
+        Logger().Printf("Failed to render %s digest template: %v", t.Name(), err)
+        return "⚠️ template error"
+    }
+    return truncateString(strings.TrimSpace(buf.String()), maxLen)
+}
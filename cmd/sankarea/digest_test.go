@@ -0,0 +1,157 @@
+// cmd/sankarea/digest_test.go
+package main
+
+import (
+    "fmt"
+    "math"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// digestArticles returns count articles from source, the first published
+// newest minutes ago and each next one a minute before it
+func digestArticles(source string, count, newest int) []*NewsArticle {
+    now := time.Now()
+    var articles []*NewsArticle
+    for n := 0; n < count; n++ {
+        articles = append(articles, &NewsArticle{
+            ID:          fmt.Sprintf("%s-%d", source, n+1),
+            Title:       fmt.Sprintf("%s story %d", source, n+1),
+            Source:      source,
+            PublishedAt: now.Add(-time.Duration(newest+n) * time.Minute),
+        })
+    }
+    return articles
+}
+
+func pickedIDs(articles []*NewsArticle) []string {
+    ids := make([]string, len(articles))
+    for idx, article := range articles {
+        ids[idx] = article.ID
+    }
+    return ids
+}
+
+func TestPickTopCapsSourceShare(t *testing.T) {
+    tests := []struct {
+        max   int
+        share float64
+    }{
+        {10, 0.3},
+        {10, 0.25},
+        {7, 0.5},
+        {5, 0.1},
+    }
+    for _, tt := range tests {
+        articles := digestArticles("chatty", 20, 0)
+        articles = append(articles, digestArticles("quiet", 1, 5)...)
+        articles = append(articles, digestArticles("other", 1, 6)...)
+
+        picked := pickTop(articles, tt.max, tt.share, nil)
+
+        limit := int(math.Ceil(float64(tt.max) * tt.share))
+        counts := make(map[string]int)
+        for _, article := range picked {
+            counts[article.Source]++
+        }
+        if counts["chatty"] != limit {
+            t.Errorf("max %d share %v: chatty got %d slots, want %d", tt.max, tt.share, counts["chatty"], limit)
+        }
+        if len(picked) > tt.max {
+            t.Errorf("max %d share %v: picked %d articles", tt.max, tt.share, len(picked))
+        }
+    }
+}
+
+func TestPickTopRoundRobin(t *testing.T) {
+    // By recency alone the digest would be all "a"
+    articles := digestArticles("a", 3, 1)
+    articles = append(articles, digestArticles("b", 2, 10)...)
+
+    got := pickedIDs(pickTop(articles, 4, 0, nil))
+    want := []string{"a-1", "b-1", "a-2", "b-2"}
+    if fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("pickTop order = %v, want %v", got, want)
+    }
+
+    // A higher priority tier goes first within each round
+    priorities := map[string]int{"b": SourcePriorityHigh}
+    got = pickedIDs(pickTop(articles, 4, 0, priorities))
+    want = []string{"b-1", "a-1", "b-2", "a-2"}
+    if fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("pickTop order with priorities = %v, want %v", got, want)
+    }
+}
+
+func TestPickTopEdgeCases(t *testing.T) {
+    articles := digestArticles("a", 3, 0)
+    articles = append(articles, digestArticles("b", 3, 0)...)
+
+    if picked := pickTop(articles, 0, 0.3, nil); len(picked) != 0 {
+        t.Errorf("max 0: picked %v", pickedIDs(picked))
+    }
+    if picked := pickTop(nil, 5, 0.3, nil); len(picked) != 0 {
+        t.Errorf("no articles: picked %v", pickedIDs(picked))
+    }
+
+    // A lone source still gets only its share of the slots
+    single := digestArticles("only", 10, 0)
+    got := pickedIDs(pickTop(single, 10, 0.3, nil))
+    want := []string{"only-1", "only-2", "only-3"}
+    if fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("single source with share 0.3 = %v, want %v", got, want)
+    }
+
+    // Without a cap it fills every slot, newest first
+    got = pickedIDs(pickTop(single, 4, 0, nil))
+    want = []string{"only-1", "only-2", "only-3", "only-4"}
+    if fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("single source without a cap = %v, want %v", got, want)
+    }
+}
+
+func TestDigestMessagesSplit(t *testing.T) {
+    field := &discordgo.MessageEmbedField{Name: "story", Value: strings.Repeat("x", 1000)}
+    var embeds []*discordgo.MessageEmbed
+    for n := 0; n < 14; n++ {
+        embeds = append(embeds, &discordgo.MessageEmbed{Title: fmt.Sprintf("embed %d", n)})
+    }
+    // Two large embeds of 5000 characters can't share a message
+    embeds = append(embeds,
+        &discordgo.MessageEmbed{Title: "large 1", Fields: []*discordgo.MessageEmbedField{field, field, field, field, field}},
+        &discordgo.MessageEmbed{Title: "large 2", Fields: []*discordgo.MessageEmbedField{field, field, field, field, field}},
+    )
+    files := []*discordgo.File{{Name: "header.png"}}
+
+    messages := (&DigestResult{Embeds: embeds, Files: files}).Messages()
+    if len(messages) != 3 {
+        t.Fatalf("got %d messages, want 3", len(messages))
+    }
+    total := 0
+    for idx, msg := range messages {
+        if len(msg.Embeds) > MaxEmbedsPerMessage {
+            t.Errorf("message %d has %d embeds", idx, len(msg.Embeds))
+        }
+        size := 0
+        for _, embed := range msg.Embeds {
+            size += embedSize(embed)
+        }
+        if size > MaxMessageEmbedSize {
+            t.Errorf("message %d totals %d characters", idx, size)
+        }
+        if (idx == 0) != (len(msg.Files) > 0) {
+            t.Errorf("message %d has %d files; only the first should carry the header", idx, len(msg.Files))
+        }
+        total += len(msg.Embeds)
+    }
+    if total != len(embeds) {
+        t.Errorf("messages hold %d embeds, want %d", total, len(embeds))
+    }
+
+    if messages := (&DigestResult{}).Messages(); len(messages) != 0 {
+        t.Errorf("empty digest gave %d messages", len(messages))
+    }
+}
@@ -1,13 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/mmcdole/gofeed"
 )
 
+// Output format styles for news posts
+const (
+	FormatStyleCompact  = "compact"
+	FormatStyleDetailed = "detailed"
+	FormatStyleEmbed    = "embed"
+)
+
 // ChannelConfiguration defines news delivery settings for channels
 type ChannelConfiguration struct {
 	ChannelID    string
@@ -19,7 +29,24 @@ type ChannelConfiguration struct {
 	MaxArticlesPerUpdate int // Maximum articles per update
 	UseSummaries bool // Whether to use summaries instead of full content
 	UseFactChecking bool // Whether to add fact checking to posts
-	FormatStyle string // "compact", "detailed", "embed"
+	FormatStyle string // "compact", "detailed", "embed"; empty uses the global default
+	Language string // Language for labels; empty uses the global default
+}
+
+// defaultFormatStyle returns the globally configured format style
+func defaultFormatStyle() string {
+	if cfg != nil && cfg.DefaultFormatStyle != "" {
+		return cfg.DefaultFormatStyle
+	}
+	return FormatStyleEmbed
+}
+
+// ResolveFormatStyle returns the channel's format style, falling back to the global default
+func (c ChannelConfiguration) ResolveFormatStyle() string {
+	if c.FormatStyle != "" {
+		return c.FormatStyle
+	}
+	return defaultFormatStyle()
 }
 
 // NewsDeliverySystem manages delivering news to Discord channels
@@ -145,50 +172,179 @@ func (nds *NewsDeliverySystem) DeliverNewsItem(
 	}
 	
 	channels := nds.GetTargetChannels(sourceName, category, trustScore, sentimentStr)
+	reason, spoiler := sensitiveReason(source != nil && source.Sensitive, item)
 	
 	// Send to each channel with appropriate formatting
 	for _, channelID := range channels {
-		var messageContent string
-		var embeds []*discordgo.MessageEmbed
-		
-		// Get channel config if exists
+		// Channels without their own configuration use the global default
 		config, hasConfig := nds.channelConfigs[channelID]
-		if !hasConfig {
-			// Use default simple format
-			messageContent = formatNewsSimple(item, sourceName, category, false, false)
-		} else {
-			// Use channel-specific formatting
-			switch config.FormatStyle {
-			case "compact":
-				messageContent = formatNewsSimple(item, sourceName, category, false, false)
-			case "detailed":
-				messageContent = formatNewsDetailed(item, sourceName, category, summary, factCheck, sentiment, config.UseFactChecking, config.UseSummaries)
-			case "embed":
-				embeds = formatNewsEmbed(item, sourceName, category, summary, factCheck, sentiment, config.UseFactChecking, config.UseSummaries)
-			default:
-				messageContent = formatNewsSimple(item, sourceName, category, config.UseFactChecking, config.UseSummaries)
+		style := defaultFormatStyle()
+		lang := defaultLanguage()
+		includeFactCheck, includeSummary := true, true
+		if hasConfig {
+			style = config.ResolveFormatStyle()
+			includeFactCheck, includeSummary = config.UseFactChecking, config.UseSummaries
+			if config.Language != "" {
+				lang = config.Language
 			}
 		}
-		
-		// Send the message to this channel
-		if len(embeds) > 0 {
-			_, err := nds.session.ChannelMessageSendEmbeds(channelID, embeds)
-			if err != nil {
-				Logger().Printf("Error sending news to channel %s: %v", channelID, err)
-			}
-		} else if messageContent != "" {
-			_, err := nds.session.ChannelMessageSend(channelID, messageContent)
-			if err != nil {
-				Logger().Printf("Error sending news to channel %s: %v", channelID, err)
-			}
+
+		messageContent, embeds := FormatNewsItem(item, sourceName, category, summary, factCheck, sentiment, style, includeFactCheck, includeSummary, lang)
+		if reason != "" {
+			messageContent, embeds = applySensitive(messageContent, embeds, itemImageURL(item), reason, spoiler)
+		}
+		if source != nil && source.Paywalled {
+			messageContent, embeds = applyPaywall(messageContent, embeds, item.Link)
+		}
+		if episode, ok := episodeFromItem(item); ok && source != nil && source.Type == SourceTypePodcast {
+			messageContent, embeds = applyPodcast(messageContent, embeds, episode.AudioURL, episode.Duration)
+		}
+
+		if err := sendFormattedNewsWithContent(nds.session, channelID, messageContent, embeds); err != nil {
+			Logger().Printf("Error sending news to channel %s: %v", channelID, err)
 		}
 	}
 	
 	return nil
 }
 
+// FormatNewsItem renders a news item in the given style. Embed styles return
+// embeds and an empty string; text styles return only the message content.
+func FormatNewsItem(
+	item *gofeed.Item,
+	sourceName,
+	category string,
+	summary string,
+	factCheck string,
+	sentiment *SentimentAnalysis,
+	style string,
+	includeFactCheck bool,
+	includeSummary bool,
+	lang string,
+) (string, []*discordgo.MessageEmbed) {
+	switch style {
+	case FormatStyleEmbed:
+		return "", formatNewsEmbed(item, sourceName, category, summary, factCheck, sentiment, includeFactCheck, includeSummary, lang)
+	case FormatStyleDetailed:
+		return formatNewsDetailed(item, sourceName, category, summary, factCheck, sentiment, includeFactCheck, includeSummary, lang), nil
+	default:
+		return formatNewsSimple(item, sourceName, category, summary, factCheck, includeFactCheck, includeSummary, lang), nil
+	}
+}
+
+// sendFormattedNews sends the output of FormatNewsItem to a channel
+func sendFormattedNews(s *discordgo.Session, channelID, content string, embeds []*discordgo.MessageEmbed) error {
+	_, err := postFormattedNews(context.Background(), s, channelID, content, embeds)
+	return err
+}
+
+// postFormattedNews sends the output of FormatNewsItem to a channel and
+// returns the message, or nil when there was nothing to send. ctx cancels
+// the wait for a slow mode slot.
+func postFormattedNews(ctx context.Context, s *discordgo.Session, channelID, content string, embeds []*discordgo.MessageEmbed) (*discordgo.Message, error) {
+	if len(embeds) == 0 && content == "" {
+		return nil, nil
+	}
+	if err := slowModePacer.Wait(ctx, s, channelID, slowModeMaxWait); err != nil {
+		return nil, err
+	}
+	if len(embeds) > 0 {
+		return s.ChannelMessageSendEmbeds(channelID, embeds)
+	}
+	return sendTextPost(s, channelID, content)
+}
+
+// previewLink matches a link, with the angle brackets around it if it has them
+var previewLink = regexp.MustCompile(`<?https?://[^\s<>)]+>?`)
+
+// suppressPreviews wraps the bare and masked links in content in angle
+// brackets, which stops Discord from showing a preview embed for them
+func suppressPreviews(content string) string {
+	return previewLink.ReplaceAllStringFunc(content, func(link string) string {
+		if strings.HasPrefix(link, "<") && strings.HasSuffix(link, ">") {
+			return link
+		}
+		return "<" + strings.Trim(link, "<>") + ">"
+	})
+}
+
+// sendTextPost sends a text news post. With suppress_link_previews set,
+// Discord doesn't attach its own preview embeds for the links in it.
+func sendTextPost(s *discordgo.Session, channelID, content string) (*discordgo.Message, error) {
+	if cfg != nil && cfg.SuppressPreviews {
+		content = suppressPreviews(content)
+	}
+	return s.ChannelMessageSend(channelID, content)
+}
+
+// embedSize returns the characters an embed counts against Discord's per-message limit
+func embedSize(embed *discordgo.MessageEmbed) int {
+	size := len(embed.Title) + len(embed.Description)
+	if embed.Footer != nil {
+		size += len(embed.Footer.Text)
+	}
+	if embed.Author != nil {
+		size += len(embed.Author.Name)
+	}
+	for _, field := range embed.Fields {
+		size += len(field.Name) + len(field.Value)
+	}
+	return size
+}
+
+// maxEmbedsPerMessage returns how many article embeds to batch into one message
+func maxEmbedsPerMessage() int {
+	if cfg == nil || cfg.MaxEmbedsPerMessage <= 0 || cfg.MaxEmbedsPerMessage > MaxEmbedsPerMessage {
+		return MaxEmbedsPerMessage
+	}
+	return cfg.MaxEmbedsPerMessage
+}
+
+// batchEmbeds splits embeds into messages of at most max embeds whose combined
+// size stays within Discord's limit. An embed over the limit on its own gets a
+// message to itself.
+func batchEmbeds(embeds []*discordgo.MessageEmbed, max int) [][]*discordgo.MessageEmbed {
+	var batches [][]*discordgo.MessageEmbed
+	var current []*discordgo.MessageEmbed
+	currentSize := 0
+	for _, embed := range embeds {
+		size := embedSize(embed)
+		if len(current) > 0 && (len(current) >= max || currentSize+size > MaxMessageEmbedSize) {
+			batches = append(batches, current)
+			current, currentSize = nil, 0
+		}
+		current = append(current, embed)
+		currentSize += size
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// sendEmbedBatches sends embeds to a channel in as few messages as the limits allow
+func sendEmbedBatches(s *discordgo.Session, channelID string, embeds []*discordgo.MessageEmbed) error {
+	var lastErr error
+	for i, batch := range batchEmbeds(embeds, maxEmbedsPerMessage()) {
+		if i > 0 {
+			// Space out messages to avoid rate limiting
+			time.Sleep(500 * time.Millisecond)
+		}
+		if err := waitForSlowMode(s, channelID); err != nil {
+			Logger().Printf("Failed to send %d embeds to channel %s: %v", len(batch), channelID, err)
+			lastErr = err
+			continue
+		}
+		if _, err := s.ChannelMessageSendEmbeds(channelID, batch); err != nil {
+			Logger().Printf("Failed to send %d embeds to channel %s: %v", len(batch), channelID, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
 // formatNewsSimple formats a news item in a simple format
-func formatNewsSimple(item *gofeed.Item, sourceName, category string, includeFactCheck, includeSummary bool) string {
+func formatNewsSimple(item *gofeed.Item, sourceName, category, summary, factCheck string, includeFactCheck, includeSummary bool, lang string) string {
 	var sb strings.Builder
 	
 	// Source and title
@@ -206,11 +362,17 @@ func formatNewsSimple(item *gofeed.Item, sourceName, category string, includeFac
 		sb.WriteString(fmt.Sprintf("🔗 %s\n", item.Link))
 	}
 	
-	// Publication date
-	if item.PublishedParsed != nil {
-		sb.WriteString(fmt.Sprintf("📅 Published <t:%d:R>\n", item.PublishedParsed.Unix()))
+	// Summary and fact check on one line each to keep the post compact
+	if includeSummary && summary != "" {
+		sb.WriteString(fmt.Sprintf("📝 %s\n", truncateString(summary, 200)))
+	}
+	if includeFactCheck && factCheck != "" {
+		sb.WriteString(fmt.Sprintf("✅ %s\n", truncateString(factCheck, 200)))
 	}
 	
+	// Publication date
+	sb.WriteString(fmt.Sprintf("📅 %s <t:%d:R>\n", tr(lang, "label.published"), getPublishedTime(item).Unix()))
+	
 	return sb.String()
 }
 
@@ -224,6 +386,7 @@ func formatNewsDetailed(
 	sentiment *SentimentAnalysis,
 	includeFactCheck bool, 
 	includeSummary bool,
+	lang string,
 ) string {
 	var sb strings.Builder
 	
@@ -246,7 +409,7 @@ func formatNewsDetailed(
 	
 	// Summary
 	if includeSummary && summary != "" {
-		sb.WriteString("**Summary:**\n")
+		sb.WriteString(fmt.Sprintf("**%s:**\n", tr(lang, "label.summary")))
 		sb.WriteString(summary)
 		sb.WriteString("\n\n")
 	}
@@ -260,24 +423,22 @@ func formatNewsDetailed(
 			sentimentEmoji = "🔴"
 		}
 		
-		sb.WriteString(fmt.Sprintf("%s **Sentiment:** %s\n", sentimentEmoji, sentiment.Sentiment))
+		sb.WriteString(fmt.Sprintf("%s **%s:** %s\n", sentimentEmoji, tr(lang, "label.sentiment"), tr(lang, "sentiment."+sentiment.Sentiment)))
 		
 		if len(sentiment.Topics) > 0 {
-			sb.WriteString(fmt.Sprintf("🏷️ **Topics:** %s\n", strings.Join(sentiment.Topics, ", ")))
+			sb.WriteString(fmt.Sprintf("🏷️ **%s:** %s\n", tr(lang, "label.topics"), strings.Join(sentiment.Topics, ", ")))
 		}
 	}
 	
 	// Fact check
 	if includeFactCheck && factCheck != "" {
-		sb.WriteString("\n**Fact Check:**\n")
+		sb.WriteString(fmt.Sprintf("\n**%s:**\n", tr(lang, "label.fact_check")))
 		sb.WriteString(factCheck)
 		sb.WriteString("\n")
 	}
 	
 	// Publication date
-	if item.PublishedParsed != nil {
-		sb.WriteString(fmt.Sprintf("\n📅 Published <t:%d:R>\n", item.PublishedParsed.Unix()))
-	}
+	sb.WriteString(fmt.Sprintf("\n📅 %s <t:%d:R>\n", tr(lang, "label.published"), getPublishedTime(item).Unix()))
 	
 	return sb.String()
 }
@@ -292,12 +453,12 @@ func formatNewsEmbed(
 	sentiment *SentimentAnalysis,
 	includeFactCheck bool, 
 	includeSummary bool,
+	lang string,
 ) []*discordgo.MessageEmbed {
 	// Create the main embed
 	embed := &discordgo.MessageEmbed{
 		Title:       item.Title,
 		URL:         item.Link,
-		Description: summary,
 		Color:       0x4B9CD3, // Blue
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: fmt.Sprintf("%s • %s", sourceName, category),
@@ -305,9 +466,16 @@ func formatNewsEmbed(
 		Fields: []*discordgo.MessageEmbedField{},
 	}
 	
-	// Add timestamp
-	if item.PublishedParsed != nil {
-		embed.Timestamp = item.PublishedParsed.Format(time.RFC3339)
+	// Add summary as the description if requested
+	if includeSummary {
+		embed.Description = summary
+	}
+	
+	// Add timestamp; items are formatted right after they are fetched
+	timestamp, fetchedNote := embedTimes(getPublishedTime(item), time.Now())
+	embed.Timestamp = timestamp
+	if fetchedNote != "" {
+		embed.Footer.Text += " • " + fetchedNote
 	}
 	
 	// Add thumbnail if available
@@ -329,7 +497,7 @@ func formatNewsEmbed(
 		// Add topics as a field
 		if len(sentiment.Topics) > 0 {
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:   "Topics",
+				Name:   tr(lang, "label.topics"),
 				Value:  strings.Join(sentiment.Topics, ", "),
 				Inline: true,
 			})
@@ -337,8 +505,8 @@ func formatNewsEmbed(
 		
 		// Add sentiment field
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "Sentiment",
-			Value:  fmt.Sprintf("%s (%.1f/1.0)", strings.Title(sentiment.Sentiment), sentiment.Score),
+			Name:   tr(lang, "label.sentiment"),
+			Value:  fmt.Sprintf("%s (%.1f/1.0)", tr(lang, "sentiment."+sentiment.Sentiment), sentiment.Score),
 			Inline: true,
 		})
 	}
@@ -346,7 +514,7 @@ func formatNewsEmbed(
 	// Add fact check if requested
 	if includeFactCheck && factCheck != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "Fact Check",
+			Name:   tr(lang, "label.fact_check"),
 			Value:  factCheck,
 			Inline: false,
 		})
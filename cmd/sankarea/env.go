@@ -85,6 +85,7 @@ func LoadEnvConfig() *Config {
         OpenAIAPIKey:         GetEnvString("OPENAI_API_KEY", ""),
         GoogleFactCheckAPIKey: GetEnvString("GOOGLE_FACT_CHECK_API_KEY", ""),
         ClaimBustersAPIKey:   GetEnvString("CLAIM_BUSTERS_API_KEY", ""),
+        WebhookSecret:        GetEnvString("WEBHOOK_SECRET", ""),
     }
 }
 
@@ -1,11 +1,17 @@
-// cmd/sankarea/errors.go
+// cmd/sankarea/error.go
 package main
 
 import (
+    "database/sql/driver"
+    "encoding/json"
     "fmt"
     "runtime"
+    "strconv"
     "strings"
+    "sync"
     "time"
+
+    "github.com/bwmarrin/discordgo"
 )
 
 // ErrorType represents different categories of errors
@@ -22,14 +28,97 @@ const (
     ErrorTypeInternal  ErrorType = "internal"
 )
 
+// ErrorSeverity ranks how serious an error is. It is stored and serialized
+// by name ("low", "medium", "high", "critical").
+type ErrorSeverity int
+
+const (
+    ErrorSeverityLow ErrorSeverity = iota + 1
+    ErrorSeverityMedium
+    ErrorSeverityHigh
+    ErrorSeverityCritical
+)
+
+var errorSeverityNames = map[ErrorSeverity]string{
+    ErrorSeverityLow:      "low",
+    ErrorSeverityMedium:   "medium",
+    ErrorSeverityHigh:     "high",
+    ErrorSeverityCritical: "critical",
+}
+
+// String returns the severity name
+func (s ErrorSeverity) String() string {
+    if name, ok := errorSeverityNames[s]; ok {
+        return name
+    }
+    return "unknown"
+}
+
+// ParseErrorSeverity reads a severity name or number. Log-level style names
+// such as "warning" and "error" are accepted for older records.
+func ParseErrorSeverity(value string) ErrorSeverity {
+    switch strings.ToLower(strings.TrimSpace(value)) {
+    case "low", "info", "1":
+        return ErrorSeverityLow
+    case "medium", "warning", "warn", "2":
+        return ErrorSeverityMedium
+    case "high", "error", "3":
+        return ErrorSeverityHigh
+    case "critical", "fatal", "4":
+        return ErrorSeverityCritical
+    default:
+        return ErrorSeverityMedium
+    }
+}
+
+// MarshalJSON encodes the severity by name
+func (s ErrorSeverity) MarshalJSON() ([]byte, error) {
+    return json.Marshal(s.String())
+}
+
+// UnmarshalJSON accepts a severity name or number
+func (s *ErrorSeverity) UnmarshalJSON(data []byte) error {
+    var name string
+    if err := json.Unmarshal(data, &name); err != nil {
+        var level int
+        if err := json.Unmarshal(data, &level); err != nil {
+            return fmt.Errorf("invalid severity: %s", data)
+        }
+        name = strconv.Itoa(level)
+    }
+    *s = ParseErrorSeverity(name)
+    return nil
+}
+
+// Value stores the severity by name in the errors table
+func (s ErrorSeverity) Value() (driver.Value, error) {
+    return s.String(), nil
+}
+
+// Scan reads a severity stored by name or number
+func (s *ErrorSeverity) Scan(src interface{}) error {
+    switch v := src.(type) {
+    case string:
+        *s = ParseErrorSeverity(v)
+    case []byte:
+        *s = ParseErrorSeverity(string(v))
+    case int64:
+        *s = ParseErrorSeverity(strconv.FormatInt(v, 10))
+    default:
+        return fmt.Errorf("cannot scan %T into ErrorSeverity", src)
+    }
+    return nil
+}
+
 // ErrorEvent represents a recorded error event
 type ErrorEvent struct {
-    Type      ErrorType `json:"type"`
-    Code      string    `json:"code"`
-    Message   string    `json:"message"`
-    Component string    `json:"component"`
-    Stack     string    `json:"stack,omitempty"`
-    Time      time.Time `json:"time"`
+    Type      ErrorType     `json:"type,omitempty"`
+    Code      string        `json:"code,omitempty"`
+    Message   string        `json:"message"`
+    Component string        `json:"component"`
+    Severity  ErrorSeverity `json:"severity"`
+    Stack     string        `json:"stack,omitempty"`
+    Time      time.Time     `json:"time"`
 }
 
 // SankareaError is the custom error type for the application
@@ -105,55 +194,162 @@ const (
     ErrSchedulerTimeout = "SCHED_002"
 )
 
-// ErrorHandler handles and records errors
-type ErrorHandler struct {
-    buffer *ErrorBuffer
+// ErrorBuffer is a fixed-size, thread-safe ring buffer of recent errors
+type ErrorBuffer struct {
+    mu     sync.RWMutex
+    events []*ErrorEvent
+    next   int
+    count  int
 }
 
-// NewErrorHandler creates a new error handler
-func NewErrorHandler(bufferSize int) *ErrorHandler {
-    return &ErrorHandler{
-        buffer: NewErrorBuffer(bufferSize),
+// NewErrorBuffer creates a new error buffer with specified size
+func NewErrorBuffer(size int) *ErrorBuffer {
+    if size <= 0 {
+        size = 1
     }
+    return &ErrorBuffer{events: make([]*ErrorEvent, size)}
 }
 
-// Handle processes and records an error
-func (h *ErrorHandler) Handle(err error, component string) {
-    if err == nil {
-        return
+// Add records an event, overwriting the oldest once the buffer is full
+func (eb *ErrorBuffer) Add(event *ErrorEvent) {
+    eb.mu.Lock()
+    defer eb.mu.Unlock()
+
+    eb.events[eb.next] = event
+    eb.next = (eb.next + 1) % len(eb.events)
+    if eb.count < len(eb.events) {
+        eb.count++
+    }
+}
+
+// GetRecent returns up to n of the most recent events, oldest first
+func (eb *ErrorBuffer) GetRecent(n int) []*ErrorEvent {
+    eb.mu.RLock()
+    defer eb.mu.RUnlock()
+
+    if n <= 0 || n > eb.count {
+        n = eb.count
     }
+    result := make([]*ErrorEvent, 0, n)
+    start := (eb.next - n + len(eb.events)) % len(eb.events)
+    for i := 0; i < n; i++ {
+        result = append(result, eb.events[(start+i)%len(eb.events)])
+    }
+    return result
+}
+
+// GetErrors returns all stored errors in chronological order
+func (eb *ErrorBuffer) GetErrors() []*ErrorEvent {
+    return eb.GetRecent(0)
+}
 
-    // Create error event
+// Clear removes all stored errors
+func (eb *ErrorBuffer) Clear() {
+    eb.mu.Lock()
+    defer eb.mu.Unlock()
+
+    eb.events = make([]*ErrorEvent, len(eb.events))
+    eb.next, eb.count = 0, 0
+}
+
+// ErrorSystem is the single place errors are recorded: it logs them, keeps
+// recent ones in memory, persists them and reports serious ones
+type ErrorSystem struct {
+    buffer *ErrorBuffer
+
+    mu        sync.RWMutex
+    database  *Database
+    session   *discordgo.Session
+    channelID string
+}
+
+// errorSystem is the process-wide error system used by HandleError
+var errorSystem = NewErrorSystem(defaultErrorBufferSize)
+
+// NewErrorSystem creates an error system keeping bufferSize recent errors
+func NewErrorSystem(bufferSize int) *ErrorSystem {
+    return &ErrorSystem{buffer: NewErrorBuffer(bufferSize)}
+}
+
+// SetDatabase enables persisting errors to the errors table
+func (es *ErrorSystem) SetDatabase(db *Database) {
+    es.mu.Lock()
+    defer es.mu.Unlock()
+    es.database = db
+}
+
+// SetDiscord enables posting high and critical errors to a channel
+func (es *ErrorSystem) SetDiscord(session *discordgo.Session, channelID string) {
+    es.mu.Lock()
+    defer es.mu.Unlock()
+    es.session = session
+    es.channelID = channelID
+}
+
+// HandleError records an error with the global error system
+func HandleError(message string, err error, component string, severity ErrorSeverity) *ErrorEvent {
+    return errorSystem.HandleError(message, err, component, severity)
+}
+
+// HandleError logs, buffers and persists an error, then reports it to the
+// error channel and webhook when it is serious enough
+func (es *ErrorSystem) HandleError(message string, err error, component string, severity ErrorSeverity) *ErrorEvent {
     event := &ErrorEvent{
-        Time:      time.Now(),
+        Type:      ErrorTypeInternal,
+        Message:   message,
         Component: component,
-        Stack:     getStackTrace(),
+        Severity:  severity,
+        Time:      time.Now(),
     }
 
     // Extract error details
     if se, ok := err.(*SankareaError); ok {
         event.Type = se.Type
         event.Code = se.Code
-        event.Message = se.Message
-    } else {
-        event.Type = ErrorTypeInternal
-        event.Code = "INTERNAL_001"
-        event.Message = err.Error()
+    }
+    if err != nil {
+        if event.Message == "" {
+            event.Message = err.Error()
+        } else {
+            event.Message = fmt.Sprintf("%s: %v", event.Message, err)
+        }
+    }
+    if severity >= ErrorSeverityHigh {
+        event.Stack = getStackTrace()
     }
 
-    // Add to buffer
-    h.buffer.Add(event)
+    Logger().Printf("[%s] %s: %s", strings.ToUpper(severity.String()), component, event.Message)
+    es.buffer.Add(event)
+    IncrementCounter("error")
+    if component != "" && err != nil {
+        UpdateComponentStatus(component, "error", err)
+    }
 
-    // Log the error
-    Logger().Printf("[ERROR] %s: %v", component, err)
+    es.mu.RLock()
+    db, session, channelID := es.database, es.session, es.channelID
+    es.mu.RUnlock()
 
-    // Update error metrics
-    IncrementCounter("error")
+    // Persist; a failure here is only logged, reporting it would loop
+    if db != nil {
+        if dbErr := db.LogError(event); dbErr != nil {
+            Logger().Printf("Failed to persist error: %v", dbErr)
+        }
+    }
 
-    // Update component status if applicable
-    if component != "" {
-        UpdateComponentStatus(component, "error", err)
+    if session != nil && channelID != "" && severity >= ErrorSeverityHigh {
+        msg := fmt.Sprintf("🚨 **%s** error in `%s`: %s", severity, component, event.Message)
+        if _, sendErr := session.ChannelMessageSend(channelID, truncateString(msg, MaxMessageLength)); sendErr != nil {
+            Logger().Printf("Failed to post error to channel: %v", sendErr)
+        }
     }
+
+    notifyErrorWebhook(event)
+    return event
+}
+
+// GetRecentErrors returns recent error events
+func (es *ErrorSystem) GetRecentErrors(count int) []*ErrorEvent {
+    return es.buffer.GetRecent(count)
 }
 
 // getStackTrace returns the current stack trace
@@ -191,13 +387,3 @@ func IsTransient(err error) bool {
     }
     return false
 }
-
-// GetErrorBuffer returns the error buffer
-func (h *ErrorHandler) GetErrorBuffer() *ErrorBuffer {
-    return h.buffer
-}
-
-// GetRecentErrors returns recent error events
-func (h *ErrorHandler) GetRecentErrors(count int) []*ErrorEvent {
-    return h.buffer.GetRecent(count)
-}
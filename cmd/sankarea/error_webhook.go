@@ -0,0 +1,133 @@
+// cmd/sankarea/error_webhook.go
+package main
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Payload formats for the outbound error webhook
+const (
+    ErrorWebhookGeneric   = "generic"   // plain JSON event
+    ErrorWebhookSlack     = "slack"     // Slack incoming webhook message
+    ErrorWebhookPagerDuty = "pagerduty" // PagerDuty Events API v2 trigger
+)
+
+// errorWebhookDebounce remembers when each distinct error was last sent
+var errorWebhookDebounce = struct {
+    sync.Mutex
+    sent map[string]time.Time
+}{sent: make(map[string]time.Time)}
+
+// notifyErrorWebhook pushes an error event to the configured webhook if it is
+// severe enough and the same error wasn't sent within the debounce window
+func notifyErrorWebhook(event *ErrorEvent) {
+    if cfg == nil || cfg.ErrorWebhookURL == "" || event == nil {
+        return
+    }
+    if event.Severity < ParseErrorSeverity(cfg.ErrorWebhookMinSeverity) {
+        return
+    }
+
+    key := event.Component + "|" + event.Message
+    window := time.Duration(cfg.ErrorWebhookDebounceMinutes) * time.Minute
+    errorWebhookDebounce.Lock()
+    if last, ok := errorWebhookDebounce.sent[key]; ok && time.Since(last) < window {
+        errorWebhookDebounce.Unlock()
+        return
+    }
+    errorWebhookDebounce.sent[key] = time.Now()
+    for k, t := range errorWebhookDebounce.sent {
+        if time.Since(t) >= window {
+            delete(errorWebhookDebounce.sent, k)
+        }
+    }
+    errorWebhookDebounce.Unlock()
+
+    go func() {
+        if err := sendErrorWebhook(event); err != nil {
+            // Logged only; reporting this through the error path would loop
+            Logger().Printf("Failed to send error webhook: %v", err)
+        }
+    }()
+}
+
+// sendErrorWebhook POSTs an error event in the configured format
+func sendErrorWebhook(event *ErrorEvent) error {
+    body, err := json.Marshal(errorWebhookPayload(event))
+    if err != nil {
+        return fmt.Errorf("failed to encode payload: %v", err)
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+
+    req, err := http.NewRequestWithContext(ctx, "POST", cfg.ErrorWebhookURL, bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("failed to create request: %v", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := GetHTTPClient().Do(req)
+    if err != nil {
+        return fmt.Errorf("failed to post: %v", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+    }
+    return nil
+}
+
+// errorWebhookPayload shapes an error event for the configured receiver
+func errorWebhookPayload(event *ErrorEvent) interface{} {
+    timestamp := event.Time.UTC().Format(time.RFC3339)
+
+    switch cfg.ErrorWebhookFormat {
+    case ErrorWebhookSlack:
+        return map[string]string{
+            "text": fmt.Sprintf("*[%s] %s*: %s (%s)", strings.ToUpper(event.Severity.String()), event.Component, event.Message, timestamp),
+        }
+    case ErrorWebhookPagerDuty:
+        return map[string]interface{}{
+            "routing_key":  cfg.ErrorWebhookRoutingKey,
+            "event_action": "trigger",
+            "dedup_key":    event.Component + ":" + event.Message,
+            "payload": map[string]string{
+                "summary":   fmt.Sprintf("%s: %s", event.Component, event.Message),
+                "source":    AppName,
+                "severity":  pagerDutySeverity(event.Severity),
+                "component": event.Component,
+                "timestamp": timestamp,
+            },
+        }
+    default:
+        return map[string]string{
+            "component": event.Component,
+            "message":   event.Message,
+            "severity":  event.Severity.String(),
+            "timestamp": timestamp,
+        }
+    }
+}
+
+// pagerDutySeverity maps a severity onto PagerDuty's fixed set
+func pagerDutySeverity(severity ErrorSeverity) string {
+    switch severity {
+    case ErrorSeverityCritical:
+        return "critical"
+    case ErrorSeverityHigh:
+        return "error"
+    case ErrorSeverityMedium:
+        return "warning"
+    default:
+        return "info"
+    }
+}
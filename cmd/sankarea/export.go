@@ -0,0 +1,128 @@
+// cmd/sankarea/export.go
+package main
+
+import (
+    "encoding/csv"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "time"
+)
+
+// csvColumns maps export column names to the article value they hold. The
+// old export's sentiment and thread columns have no counterpart: sentiment
+// is only computed while a post is formatted and never stored, and articles
+// aren't grouped into threads.
+var csvColumns = map[string]func(a *NewsArticle) string{
+    "id":           func(a *NewsArticle) string { return a.ID },
+    "title":        func(a *NewsArticle) string { return a.Title },
+    "url":          func(a *NewsArticle) string { return a.URL },
+    "source":       func(a *NewsArticle) string { return a.Source },
+    "category":     func(a *NewsArticle) string { return a.Category },
+    "published_at": func(a *NewsArticle) string { return a.PublishedAt.Format(time.RFC3339) },
+    "fetched_at":   func(a *NewsArticle) string { return a.FetchedAt.Format(time.RFC3339) },
+    "content":      func(a *NewsArticle) string { return a.Content },
+    "image_url":    func(a *NewsArticle) string { return a.ImageURL },
+    "citations":    func(a *NewsArticle) string { return strings.Join(a.Citations, " ") },
+    "summary":      csvSummary,
+    "reliability": func(a *NewsArticle) string {
+        if a.FactCheckResult == nil {
+            return ""
+        }
+        return a.FactCheckResult.ReliabilityTier
+    },
+    "trust_score": func(a *NewsArticle) string {
+        if a.FactCheckResult == nil {
+            return ""
+        }
+        return fmt.Sprintf("%.2f", a.FactCheckResult.Score)
+    },
+}
+
+// csvSummary returns the article's cached AI summary, or "" when it has none
+func csvSummary(a *NewsArticle) string {
+    summary, _ := summaryCache.Get(&Article{Title: a.Title, Content: a.Content, URL: a.URL, Source: a.Source})
+    return summary
+}
+
+// defaultCSVColumns is the column set used when none is configured
+var defaultCSVColumns = []string{"published_at", "source", "category", "title", "url", "reliability", "trust_score"}
+
+// validateCSVColumns checks that every configured column is known
+func validateCSVColumns(columns []string) error {
+    for _, col := range columns {
+        if _, ok := csvColumns[col]; !ok {
+            return fmt.Errorf("unknown CSV export column %q (known: %s)", col, strings.Join(csvColumnNames(), ", "))
+        }
+    }
+    return nil
+}
+
+// csvColumnNames returns the known column names in order
+func csvColumnNames() []string {
+    names := make([]string, 0, len(csvColumns))
+    for name := range csvColumns {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// WriteArticlesCSV writes articles to a timestamped CSV file in dir and returns its path
+func WriteArticlesCSV(articles []*NewsArticle, columns []string, dir string) (string, error) {
+    if err := validateCSVColumns(columns); err != nil {
+        return "", err
+    }
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return "", fmt.Errorf("failed to create export directory: %v", err)
+    }
+
+    path := filepath.Join(dir, fmt.Sprintf("articles_%s.csv", time.Now().Format("20060102_150405")))
+    file, err := os.Create(path)
+    if err != nil {
+        return "", fmt.Errorf("failed to create export file: %v", err)
+    }
+    defer file.Close()
+
+    w := csv.NewWriter(file)
+    if err := w.Write(columns); err != nil {
+        return "", fmt.Errorf("failed to write CSV header: %v", err)
+    }
+
+    record := make([]string, len(columns))
+    for _, article := range articles {
+        for i, col := range columns {
+            record[i] = csvColumns[col](article)
+        }
+        if err := w.Write(record); err != nil {
+            return "", fmt.Errorf("failed to write CSV record: %v", err)
+        }
+    }
+
+    w.Flush()
+    if err := w.Error(); err != nil {
+        return "", fmt.Errorf("failed to flush CSV export: %v", err)
+    }
+
+    return path, nil
+}
+
+// runCSVExport exports the last day's articles using the configured columns
+func (b *Bot) runCSVExport() {
+    end := time.Now().UTC()
+    articles, err := b.database.GetArticlesByTimeRange(end.Add(-24*time.Hour), end)
+    if err != nil {
+        b.logger.Error("CSV export failed: %v", err)
+        return
+    }
+
+    path, err := WriteArticlesCSV(articles, b.config.CSVExport.Columns, b.config.CSVExport.Dir)
+    if err != nil {
+        b.logger.Error("CSV export failed: %v", err)
+        return
+    }
+
+    b.logger.Info("Exported %d articles to %s", len(articles), path)
+}
@@ -0,0 +1,158 @@
+// cmd/sankarea/extractor.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+    "time"
+
+    "github.com/PuerkitoBio/goquery"
+)
+
+// Limits for article extraction
+const (
+    maxExtractPageSize  = 5 * 1024 * 1024 // bytes read from the article page
+    maxExtractedLength  = 100000          // characters of text kept
+    defaultTeaserLength = 300
+)
+
+// extractNoise are elements that never hold article text
+const extractNoise = "script, style, noscript, nav, header, footer, aside, form, iframe, figure figcaption"
+
+// ExtractedArticle is the readable content of an article page
+type ExtractedArticle struct {
+    Title     string
+    Content   string
+    ImageURL  string
+    WordCount int // words in the article text before truncation
+}
+
+// ArticleExtractor fetches article pages and pulls out their main text
+type ArticleExtractor struct {
+    client    *http.Client
+    userAgent string
+    timeout   time.Duration
+}
+
+// NewArticleExtractor creates an extractor using the shared HTTP client
+func NewArticleExtractor() *ArticleExtractor {
+    e := &ArticleExtractor{
+        client:    GetHTTPClient(),
+        userAgent: "Sankarea News Bot/1.0",
+        timeout:   20 * time.Second,
+    }
+    if cfg != nil && cfg.UserAgentString != "" {
+        e.userAgent = cfg.UserAgentString
+    }
+    return e
+}
+
+// Extract fetches an article page and returns its main text
+func (e *ArticleExtractor) Extract(pageURL string) (*ExtractedArticle, error) {
+    return e.ExtractContext(context.Background(), pageURL)
+}
+
+// ExtractContext is Extract bounded by ctx
+func (e *ArticleExtractor) ExtractContext(ctx context.Context, pageURL string) (*ExtractedArticle, error) {
+    ctx, cancel := context.WithTimeout(ctx, e.timeout)
+    defer cancel()
+
+    req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %v", err)
+    }
+    req.Header.Set("User-Agent", e.userAgent)
+    req.Header.Set("Accept", "text/html, application/xhtml+xml")
+
+    resp, err := e.client.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("failed to fetch article: %v", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+    }
+
+    return parseArticlePage(io.LimitReader(resp.Body, maxExtractPageSize))
+}
+
+// parseArticlePage picks the page's article body (an <article> element, a
+// main element, or the whole body) and joins its paragraphs
+func parseArticlePage(body io.Reader) (*ExtractedArticle, error) {
+    doc, err := goquery.NewDocumentFromReader(body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse HTML: %v", err)
+    }
+    doc.Find(extractNoise).Remove()
+
+    extracted := &ExtractedArticle{
+        Title: collapseSpace(doc.Find("title").First().Text()),
+    }
+    if image, ok := doc.Find(`meta[property="og:image"]`).Attr("content"); ok {
+        extracted.ImageURL = strings.TrimSpace(image)
+    }
+
+    root := doc.Find("article").First()
+    if root.Length() == 0 {
+        root = doc.Find("main, [role=main]").First()
+    }
+    if root.Length() == 0 {
+        root = doc.Find("body")
+    }
+
+    var paragraphs []string
+    root.Find("p").Each(func(_ int, p *goquery.Selection) {
+        if text := collapseSpace(p.Text()); text != "" {
+            paragraphs = append(paragraphs, text)
+        }
+    })
+    content := strings.Join(paragraphs, "\n\n")
+    if content == "" {
+        content = collapseSpace(root.Text())
+    }
+    if content == "" {
+        return nil, fmt.Errorf("no article text found")
+    }
+
+    extracted.WordCount = len(strings.Fields(content))
+    extracted.Content = truncateString(content, maxExtractedLength)
+    return extracted, nil
+}
+
+// storeFullContent reports whether full article text is extracted and stored
+func storeFullContent() bool {
+    return cfg != nil && cfg.StoreFullContent
+}
+
+// postedText is what a channel shows for an article: its summary, or a
+// teaser of the stored content when there is none
+func postedText(article *NewsArticle) string {
+    if article.Summary != "" || !storeFullContent() {
+        return article.Summary
+    }
+    return articleTeaser(article.Content)
+}
+
+// articleTeaser shortens text for posting when full content is stored; it
+// cuts at a word boundary so teasers don't end mid-word
+func articleTeaser(text string) string {
+    if !storeFullContent() {
+        return text
+    }
+    length := defaultTeaserLength
+    if cfg.TeaserLength > 0 {
+        length = cfg.TeaserLength
+    }
+    if len(text) <= length {
+        return text
+    }
+    cut := text[:length]
+    if idx := strings.LastIndexAny(cut, " \n"); idx > length/2 {
+        cut = cut[:idx]
+    }
+    return strings.TrimSpace(cut) + "…"
+}
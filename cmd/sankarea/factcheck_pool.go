@@ -0,0 +1,186 @@
+// cmd/sankarea/factcheck_pool.go
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sync"
+    "time"
+)
+
+// Fact-check worker pool defaults
+const (
+    defaultFactCheckWorkers = 2
+    factCheckPoolQueueSize  = 200
+    factCheckTimeout        = time.Minute
+)
+
+// defaultFactCheckRate is how many fact checks start per minute when
+// fact_check_rate_per_minute is unset
+const defaultFactCheckRate = 30
+
+// errFactCheckPoolFull is recorded when a check is deferred to the retry queue
+var errFactCheckPoolFull = errors.New("fact check pool queue full")
+
+// FactCheckPool checks articles on a bounded set of workers, so slow fact
+// check APIs don't hold up feed fetching. Results are saved to the database
+// and posts that already went out are edited to show them.
+type FactCheckPool struct {
+    bot     *Bot
+    workers int
+    jobs    chan string // article IDs
+    ctx     context.Context
+    cancel  context.CancelFunc
+    wg      sync.WaitGroup
+}
+
+// NewFactCheckPool creates a pool; workers <= 0 uses the default
+func NewFactCheckPool(bot *Bot, workers int) *FactCheckPool {
+    if workers <= 0 {
+        workers = defaultFactCheckWorkers
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    return &FactCheckPool{
+        bot:     bot,
+        workers: workers,
+        jobs:    make(chan string, factCheckPoolQueueSize),
+        ctx:     ctx,
+        cancel:  cancel,
+    }
+}
+
+// Start launches the workers
+func (p *FactCheckPool) Start() {
+    for n := 0; n < p.workers; n++ {
+        p.wg.Add(1)
+        go p.work()
+    }
+}
+
+// Stop cancels running checks and waits for the workers to exit; articles
+// still queued are handed to the retry queue
+func (p *FactCheckPool) Stop() {
+    p.cancel()
+    p.wg.Wait()
+    for {
+        select {
+        case id := <-p.jobs:
+            factCheckQueue.Enqueue(&NewsArticle{ID: id}, context.Canceled)
+        default:
+            return
+        }
+    }
+}
+
+// Submit queues a stored article for checking without blocking; when the
+// pool is backed up the article goes to the retry queue instead
+func (p *FactCheckPool) Submit(article *NewsArticle) {
+    select {
+    case p.jobs <- article.ID:
+    default:
+        factCheckQueue.Enqueue(article, errFactCheckPoolFull)
+    }
+}
+
+// Pending returns how many articles are waiting for a worker
+func (p *FactCheckPool) Pending() int {
+    return len(p.jobs)
+}
+
+// work checks queued articles until the pool stops
+func (p *FactCheckPool) work() {
+    defer p.wg.Done()
+    for {
+        select {
+        case <-p.ctx.Done():
+            return
+        case id := <-p.jobs:
+            p.check(id)
+        }
+    }
+}
+
+// check fact-checks one stored article and saves the result
+func (p *FactCheckPool) check(id string) {
+    defer RecoverFromPanic("fact-check-worker")
+
+    b := p.bot
+    article, err := b.database.GetArticle(id)
+    if err != nil {
+        b.logger.Error("Failed to load article %s for fact check: %v", id, err)
+        return
+    }
+    if article == nil {
+        return
+    }
+
+    if err := b.factCheckLimit.Wait(p.ctx); err != nil {
+        factCheckQueue.Enqueue(article, err)
+        return
+    }
+    ctx, cancel := context.WithTimeout(p.ctx, factCheckTimeout)
+    result, err := b.factChecker.CheckArticle(ctx, article)
+    cancel()
+    if err != nil {
+        if p.ctx.Err() == nil {
+            b.logger.Error("Fact check failed for %s, will retry: %v", article.Title, err)
+        }
+        factCheckQueue.Enqueue(article, err)
+        return
+    }
+
+    article.FactCheckResult = result
+    if err := b.database.SaveArticle(article); err != nil {
+        b.logger.Error("Failed to save fact check for %s: %v", article.URL, err)
+        return
+    }
+    traceDecision(article.URL, StageFactCheck, "checked", fmt.Sprintf("%s (%.2f)", result.ReliabilityTier, result.Score))
+    if reason := lowReliabilityReason(article); reason != "" {
+        traceDecision(article.URL, StageTrustFloor, "retracted", reason)
+        b.retractPostedArticle(article, reason)
+        return
+    }
+    b.updatePostedArticle(article)
+}
+
+// FactCheckLimiter spaces out fact checks so the workers, retries and
+// /refactcheck together stay under the fact-check APIs' rate limits
+type FactCheckLimiter struct {
+    mu       sync.Mutex
+    interval time.Duration
+    next     time.Time
+}
+
+// NewFactCheckLimiter allows perMinute checks a minute; <= 0 uses the default
+func NewFactCheckLimiter(perMinute int) *FactCheckLimiter {
+    if perMinute <= 0 {
+        perMinute = defaultFactCheckRate
+    }
+    return &FactCheckLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// Wait blocks until the next check may start, or ctx is done. A nil
+// limiter never waits.
+func (l *FactCheckLimiter) Wait(ctx context.Context) error {
+    if l == nil {
+        return nil
+    }
+    l.mu.Lock()
+    now := time.Now()
+    start := l.next
+    if start.Before(now) {
+        start = now
+    }
+    l.next = start.Add(l.interval)
+    l.mu.Unlock()
+
+    timer := time.NewTimer(time.Until(start))
+    defer timer.Stop()
+    select {
+    case <-timer.C:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
@@ -0,0 +1,159 @@
+// cmd/sankarea/factcheck_retry.go
+package main
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+// Fact-check retry defaults
+const (
+    defaultFactCheckMaxAttempts = 8
+    factCheckRetryBase          = 5 * time.Minute
+    factCheckRetryMax           = 6 * time.Hour
+    factCheckRetryBatch         = 20
+)
+
+// FactCheckRetryConfig controls retries of failed fact checks
+type FactCheckRetryConfig struct {
+    MaxAttempts int  `json:"max_attempts"` // give up after this many retries; default 8
+    EditPosts   bool `json:"edit_posts"`   // edit already-posted embeds once a result arrives
+}
+
+// PendingFactCheck is an article waiting for a fact-check retry
+type PendingFactCheck struct {
+    ArticleID   string    `json:"article_id"`
+    Title       string    `json:"title"`
+    URL         string    `json:"url"`
+    Attempts    int       `json:"attempts"`
+    NextAttempt time.Time `json:"next_attempt"`
+    LastError   string    `json:"last_error"`
+    QueuedAt    time.Time `json:"queued_at"`
+}
+
+// FactCheckQueue holds articles whose fact check failed. It is backed by the
+// fact_check_queue table and does nothing until a database is set.
+type FactCheckQueue struct {
+    mu sync.RWMutex
+    db *Database
+}
+
+// factCheckQueue is shared by the fetch path, the retry job and the dashboard
+var factCheckQueue = &FactCheckQueue{}
+
+// SetDatabase enables the queue
+func (q *FactCheckQueue) SetDatabase(db *Database) {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    q.db = db
+}
+
+// database returns the backing store, or nil before one is set
+func (q *FactCheckQueue) database() *Database {
+    q.mu.RLock()
+    defer q.mu.RUnlock()
+    return q.db
+}
+
+// Enqueue schedules a first retry for an article whose fact check failed
+func (q *FactCheckQueue) Enqueue(article *NewsArticle, cause error) {
+    db := q.database()
+    if db == nil {
+        return
+    }
+    pending := &PendingFactCheck{
+        ArticleID:   article.ID,
+        NextAttempt: time.Now().UTC().Add(factCheckBackoff(0)),
+        LastError:   cause.Error(),
+        QueuedAt:    time.Now().UTC(),
+    }
+    if err := db.SaveFactCheckRetry(pending); err != nil {
+        Logger().Printf("Failed to queue fact check for %s: %v", article.URL, err)
+    }
+}
+
+// Backlog lists every article waiting for a retry, soonest first
+func (q *FactCheckQueue) Backlog() ([]*PendingFactCheck, error) {
+    db := q.database()
+    if db == nil {
+        return nil, nil
+    }
+    return db.GetFactCheckRetries(time.Time{}, 0)
+}
+
+// factCheckBackoff returns the wait before retry number attempts+1
+func factCheckBackoff(attempts int) time.Duration {
+    wait := factCheckRetryBase
+    for n := 0; n < attempts && wait < factCheckRetryMax; n++ {
+        wait *= 2
+    }
+    if wait > factCheckRetryMax {
+        wait = factCheckRetryMax
+    }
+    return wait
+}
+
+// runFactCheckRetries retries due fact checks, storing results and
+// optionally editing posts that went out without reliability info
+func (b *Bot) runFactCheckRetries() {
+    due, err := b.database.GetFactCheckRetries(time.Now().UTC(), factCheckRetryBatch)
+    if err != nil {
+        b.logger.Error("Failed to load pending fact checks: %v", err)
+        return
+    }
+
+    maxAttempts := b.config.FactCheckRetry.MaxAttempts
+    if maxAttempts <= 0 {
+        maxAttempts = defaultFactCheckMaxAttempts
+    }
+
+    for _, pending := range due {
+        article, err := b.database.GetArticle(pending.ArticleID)
+        if err != nil {
+            b.logger.Error("Failed to load article %s for fact check: %v", pending.ArticleID, err)
+            continue
+        }
+        if article == nil {
+            // Cleaned up since it was queued
+            b.database.DeleteFactCheckRetry(pending.ArticleID)
+            continue
+        }
+
+        b.factCheckLimit.Wait(context.Background())
+        ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+        result, err := b.factChecker.CheckArticle(ctx, article)
+        cancel()
+
+        if err != nil {
+            pending.Attempts++
+            pending.LastError = err.Error()
+            if pending.Attempts >= maxAttempts {
+                b.logger.Warning("Giving up on fact check for %s after %d attempts: %v", article.URL, pending.Attempts, err)
+                b.database.DeleteFactCheckRetry(pending.ArticleID)
+                continue
+            }
+            pending.NextAttempt = time.Now().UTC().Add(factCheckBackoff(pending.Attempts))
+            if err := b.database.SaveFactCheckRetry(pending); err != nil {
+                b.logger.Error("Failed to reschedule fact check for %s: %v", article.URL, err)
+            }
+            continue
+        }
+
+        article.FactCheckResult = result
+        if err := b.database.SaveArticle(article); err != nil {
+            b.logger.Error("Failed to save fact check for %s: %v", article.URL, err)
+            continue
+        }
+        b.database.DeleteFactCheckRetry(pending.ArticleID)
+        b.logger.Info("Fact check for %s succeeded after %d retries", article.URL, pending.Attempts+1)
+
+        if reason := lowReliabilityReason(article); reason != "" {
+            b.retractPostedArticle(article, reason)
+            continue
+        }
+        if b.config.FactCheckRetry.EditPosts {
+            b.updatePostedArticle(article)
+        }
+    }
+}
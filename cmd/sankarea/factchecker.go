@@ -11,12 +11,12 @@ import (
     "time"
 )
 
-// FactChecker handles article reliability checking
+// FactChecker combines the configured reliability backends into one
+// weighted score and caches the results
 type FactChecker struct {
-    client    *http.Client
-    cache     map[string]*FactCheckResult
-    cacheMu   sync.RWMutex
-    cacheTime time.Duration
+    client   *http.Client
+    backends []weightedChecker
+    cache    *LRUCache // article URL -> *FactCheckResult
 }
 
 // FactCheckResult represents the result of a fact check
@@ -24,7 +24,7 @@ type FactCheckResult struct {
     Score           float64   `json:"score"`
     ReliabilityTier string    `json:"reliability_tier"`
     Claims          []Claim   `json:"claims,omitempty"`
-    Reasons         []string  `json:"reasons,omitempty"` // Added from factcheck.go
+    Reasons         []string  `json:"reasons,omitempty"`
     Timestamp       time.Time `json:"timestamp"`
 }
 
@@ -48,13 +48,52 @@ const (
 
 // NewFactChecker creates a new fact checker instance
 func NewFactChecker() *FactChecker {
-    return &FactChecker{
+    fc := &FactChecker{
         client: &http.Client{
             Timeout: 30 * time.Second,
         },
-        cache:     make(map[string]*FactCheckResult),
-        cacheTime: 24 * time.Hour,
+        cache: NewLRUCache("fact_checks", dedupCacheSize(), 24*time.Hour),
     }
+    fc.backends = reliabilityBackends(fc)
+    return fc
+}
+
+var (
+    defaultFactChecker     *FactChecker
+    defaultFactCheckerOnce sync.Once
+)
+
+// DefaultFactChecker returns the shared fact checker, so every caller reuses one cache
+func DefaultFactChecker() *FactChecker {
+    defaultFactCheckerOnce.Do(func() {
+        defaultFactChecker = NewFactChecker()
+    })
+    return defaultFactChecker
+}
+
+// FactCheckArticle checks a feed item that hasn't been stored as an article yet
+func FactCheckArticle(title, content, url, source string) (*FactCheckResult, error) {
+    article := &NewsArticle{
+        Title:     title,
+        Content:   content,
+        URL:       url,
+        Source:    source,
+        FetchedAt: time.Now(),
+    }
+    return DefaultFactChecker().CheckArticle(context.Background(), article)
+}
+
+// TopClaim returns the first claim found in the article, if any
+func (r *FactCheckResult) TopClaim() *Claim {
+    if len(r.Claims) == 0 {
+        return nil
+    }
+    return &r.Claims[0]
+}
+
+// Explanation summarizes the reasons behind the score
+func (r *FactCheckResult) Explanation() string {
+    return strings.Join(r.Reasons, "; ")
 }
 
 // CheckArticle performs fact checking on an article
@@ -64,19 +103,34 @@ func (fc *FactChecker) CheckArticle(ctx context.Context, article *NewsArticle) (
         return result, nil
     }
 
-    // Perform reliability analysis
-    score, reasons, err := fc.analyzeReliability(ctx, article)
-    if err != nil {
-        return nil, fmt.Errorf("reliability analysis failed: %v", err)
+    // Combine every backend that has something to say, weighted
+    var (
+        total, weights float64
+        reasons        []string
+        claims         = []Claim{}
+        failures       []string
+    )
+    for _, backend := range fc.backends {
+        report, err := backend.checker.Check(ctx, article)
+        if err == errNoReliabilityData {
+            continue
+        }
+        if err != nil {
+            failures = append(failures, fmt.Sprintf("%s: %v", backend.checker.Name(), err))
+            continue
+        }
+        total += report.Score * backend.weight
+        weights += backend.weight
+        reasons = append(reasons, report.Reasons...)
+        claims = append(claims, report.Claims...)
     }
-
-    // Extract and verify claims
-    claims, err := fc.extractClaims(ctx, article)
-    if err != nil {
-        Logger().Printf("Warning: claim extraction failed for %s: %v", article.URL, err)
-        // Continue with empty claims rather than failing completely
-        claims = []Claim{}
+    if weights == 0 {
+        return nil, fmt.Errorf("reliability analysis failed: %s", strings.Join(failures, "; "))
     }
+    if len(failures) > 0 {
+        Logger().Printf("Warning: some fact check backends failed for %s: %s", article.URL, strings.Join(failures, "; "))
+    }
+    score := total / weights
 
     // Determine reliability tier
     tier := fc.determineReliabilityTier(score)
@@ -95,6 +149,12 @@ func (fc *FactChecker) CheckArticle(ctx context.Context, article *NewsArticle) (
     return result, nil
 }
 
+// Recheck fact-checks an article again, ignoring any cached result
+func (fc *FactChecker) Recheck(ctx context.Context, article *NewsArticle) (*FactCheckResult, error) {
+    fc.cache.Remove(article.URL)
+    return fc.CheckArticle(ctx, article)
+}
+
 // analyzeReliability calculates a reliability score for an article
 func (fc *FactChecker) analyzeReliability(ctx context.Context, article *NewsArticle) (float64, []string, error) {
     var reasons []string
@@ -171,23 +231,14 @@ func (fc *FactChecker) determineReliabilityTier(score float64) string {
 // Cache management methods
 
 func (fc *FactChecker) getCachedResult(url string) *FactCheckResult {
-    fc.cacheMu.RLock()
-    defer fc.cacheMu.RUnlock()
-
-    if result, exists := fc.cache[url]; exists {
-        if time.Since(result.Timestamp) < fc.cacheTime {
-            return result
-        }
-        // Expired cache entry
-        delete(fc.cache, url)
+    if result, ok := fc.cache.Get(url); ok {
+        return result.(*FactCheckResult)
     }
     return nil
 }
 
 func (fc *FactChecker) cacheResult(url string, result *FactCheckResult) {
-    fc.cacheMu.Lock()
-    defer fc.cacheMu.Unlock()
-    fc.cache[url] = result
+    fc.cache.Add(url, result)
 }
 
 // Helper methods
@@ -0,0 +1,125 @@
+// cmd/sankarea/feed_dates.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/mmcdole/gofeed"
+)
+
+// Which time article embeds show
+const (
+    EmbedTimestampPublished = "published" // the feed's published time
+    EmbedTimestampFetched   = "fetched"   // when the bot fetched the item
+    EmbedTimestampBoth      = "both"      // published time, with the fetch time in the footer
+)
+
+// Thresholds for flagging feeds whose dates can't be trusted
+const (
+    minItemsForDateCheck = 5
+    sharedDateRatio      = 0.8 // this share of items with one identical timestamp is suspicious
+)
+
+// embedTimestampMode returns the configured embed timestamp mode
+func embedTimestampMode() string {
+    if cfg != nil && cfg.EmbedTimestamp != "" {
+        return cfg.EmbedTimestamp
+    }
+    return EmbedTimestampPublished
+}
+
+// embedTimes picks the embed timestamp and an optional footer note for an
+// item published and fetched at the given times
+func embedTimes(published, fetched time.Time) (string, string) {
+    switch embedTimestampMode() {
+    case EmbedTimestampFetched:
+        return fetched.Format(time.RFC3339), ""
+    case EmbedTimestampBoth:
+        return published.Format(time.RFC3339), "fetched " + fetched.UTC().Format("Jan 02 15:04 UTC")
+    default:
+        return published.Format(time.RFC3339), ""
+    }
+}
+
+// suspiciousDates reports why a feed's item dates look unreliable, or ""
+// if they look fine. Feeds that stamp every item with one time, or with
+// the time of the request, make a channel's timeline meaningless.
+func suspiciousDates(items []*gofeed.Item, fetched time.Time) string {
+    if len(items) < minItemsForDateCheck {
+        return ""
+    }
+
+    counts := make(map[int64]int)
+    dated, nearFetch := 0, 0
+    for _, item := range items {
+        t := item.PublishedParsed
+        if t == nil {
+            t = item.UpdatedParsed
+        }
+        if t == nil {
+            continue
+        }
+        dated++
+        counts[t.Unix()]++
+        if d := fetched.Sub(*t); d > -time.Minute && d < time.Minute {
+            nearFetch++
+        }
+    }
+    if dated < minItemsForDateCheck {
+        return ""
+    }
+
+    if float64(nearFetch) >= sharedDateRatio*float64(dated) {
+        return fmt.Sprintf("%d of %d items are dated at fetch time", nearFetch, dated)
+    }
+    for _, n := range counts {
+        if float64(n) >= sharedDateRatio*float64(dated) {
+            return fmt.Sprintf("%d of %d items share an identical timestamp", n, dated)
+        }
+    }
+    return ""
+}
+
+// checkFeedDates flags or clears a source whose item dates are unreliable.
+// A warning is logged only when a source is newly flagged.
+func checkFeedDates(sourceName string, items []*gofeed.Item) {
+    reason := suspiciousDates(items, time.Now())
+
+    newlyFlagged := false
+    if err := UpdateState(func(s *State) {
+        if reason == "" {
+            delete(s.UnreliableDates, sourceName)
+            return
+        }
+        if s.UnreliableDates == nil {
+            s.UnreliableDates = make(map[string]string)
+        }
+        _, known := s.UnreliableDates[sourceName]
+        newlyFlagged = !known
+        s.UnreliableDates[sourceName] = reason
+    }); err != nil {
+        Logger().Printf("Failed to record feed date check for %s: %v", sourceName, err)
+    }
+
+    if newlyFlagged {
+        HandleError(fmt.Sprintf("Feed %s has unreliable dates (%s); consider embed_timestamp \"fetched\"", sourceName, reason),
+            nil, "feed-dates", ErrorSeverityLow)
+    }
+}
+
+// unreliableDateSummary lists flagged sources for status displays
+func unreliableDateSummary() string {
+    flagged := GetState().UnreliableDates
+    if len(flagged) == 0 {
+        return ""
+    }
+    lines := make([]string, 0, len(flagged))
+    for name, reason := range flagged {
+        lines = append(lines, fmt.Sprintf("%s: %s", name, reason))
+    }
+    sort.Strings(lines)
+    return strings.Join(lines, "\n")
+}
@@ -0,0 +1,123 @@
+// cmd/sankarea/feed_validation.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "time"
+)
+
+// feedCheckResult is the outcome of validating a single source URL
+type feedCheckResult struct {
+    StatusCode int
+    Location   string // redirect target for permanent redirects
+}
+
+// checkFeedURL requests a source's feed without following redirects
+func checkFeedURL(ctx context.Context, source NewsSource) (*feedCheckResult, error) {
+    base, err := httpClientFor(source.Proxy)
+    if err != nil {
+        return nil, err
+    }
+    client := *base
+    client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+        return http.ErrUseLastResponse
+    }
+
+    req, err := http.NewRequestWithContext(ctx, "GET", source.URL, nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %v", err)
+    }
+    req.Header.Set("User-Agent", "Sankarea News Bot/1.0")
+    setSourceAuth(req, source.Headers, source.BasicAuthUser, source.BasicAuthPass)
+
+    resp, err := client.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("failed to fetch feed: %v", err)
+    }
+    defer resp.Body.Close()
+
+    result := &feedCheckResult{StatusCode: resp.StatusCode}
+    if resp.StatusCode == http.StatusMovedPermanently || resp.StatusCode == http.StatusPermanentRedirect {
+        location, err := resp.Location()
+        if err != nil {
+            return nil, fmt.Errorf("redirect without valid location: %v", err)
+        }
+        result.Location = location.String()
+    }
+    return result, nil
+}
+
+// runFeedValidation checks every source, follows permanent redirects by
+// rewriting the stored URL, and flags sources that have gone away
+func (b *Bot) runFeedValidation() {
+    sources, err := LoadSources()
+    if err != nil {
+        b.logger.Error("Feed validation: failed to load sources: %v", err)
+        return
+    }
+
+    // Check every source without holding the sources lock, then apply the
+    // results by name so edits made in the meantime are kept
+    results := make(map[string]*feedCheckResult)
+    for _, source := range sources {
+        ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+        result, err := checkFeedURL(ctx, source)
+        cancel()
+        if err != nil {
+            // Transient failures are tracked by the fetch metrics, not here
+            b.logger.Warn("Feed validation: %s: %v", source.Name, err)
+            continue
+        }
+        results[source.Name] = result
+    }
+    if len(results) == 0 {
+        return
+    }
+
+    err = UpdateSources("", func(sources []NewsSource) ([]NewsSource, error) {
+        for idx := range sources {
+            result, ok := results[sources[idx].Name]
+            if ok {
+                b.applyFeedCheck(&sources[idx], result)
+            }
+        }
+        return sources, nil
+    })
+    if err != nil {
+        b.logger.Error("Feed validation: failed to save sources: %v", err)
+    }
+}
+
+// applyFeedCheck records a validation result on a source
+func (b *Bot) applyFeedCheck(source *NewsSource, result *feedCheckResult) {
+    source.LastValidated = time.Now().UTC()
+
+    switch {
+    case result.Location != "":
+        if err := validateSourceURL(result.Location); err != nil {
+            b.logger.Warn("Feed validation: %s redirects to invalid URL %s: %v", source.Name, result.Location, err)
+            return
+        }
+        b.logger.Info("Feed validation: %s moved permanently, updating URL %s -> %s",
+            source.Name, source.URL, result.Location)
+        source.URL = result.Location
+        source.Broken = false
+        source.BrokenReason = ""
+
+    case result.StatusCode == http.StatusNotFound || result.StatusCode == http.StatusGone:
+        if !source.Broken {
+            b.logger.Warn("Feed validation: flagging %s as broken (HTTP %d)", source.Name, result.StatusCode)
+        }
+        source.Broken = true
+        source.BrokenReason = fmt.Sprintf("HTTP %d", result.StatusCode)
+
+    case result.StatusCode >= 200 && result.StatusCode < 300:
+        if source.Broken {
+            b.logger.Info("Feed validation: %s is reachable again", source.Name)
+        }
+        source.Broken = false
+        source.BrokenReason = ""
+    }
+}
@@ -0,0 +1,232 @@
+// cmd/sankarea/fetch_boost.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Limits for /sources boost
+const (
+    fetchBoostMinInterval = time.Minute
+    fetchBoostMaxDuration = 48 * time.Hour
+)
+
+// fetchBoostTick is how often boosted sources are checked for a due fetch
+const fetchBoostTick = time.Minute
+
+// FetchBoost temporarily fetches one source more often than fetch_interval
+type FetchBoost struct {
+    Interval  time.Duration `json:"interval"`
+    ExpiresAt time.Time     `json:"expires_at"`
+    SetBy     string        `json:"set_by,omitempty"`
+}
+
+// fetchBoost returns a source's boost if one is still running
+func fetchBoost(name string) (FetchBoost, bool) {
+    stateMux.RLock()
+    defer stateMux.RUnlock()
+    if state == nil {
+        return FetchBoost{}, false
+    }
+    boost, ok := state.FetchBoosts[name]
+    if !ok || !time.Now().Before(boost.ExpiresAt) {
+        return FetchBoost{}, false
+    }
+    return boost, true
+}
+
+// activeFetchBoosts returns the running boosts by source name
+func activeFetchBoosts() map[string]FetchBoost {
+    stateMux.RLock()
+    defer stateMux.RUnlock()
+    boosts := make(map[string]FetchBoost)
+    if state == nil {
+        return boosts
+    }
+    now := time.Now()
+    for name, boost := range state.FetchBoosts {
+        if now.Before(boost.ExpiresAt) {
+            boosts[name] = boost
+        }
+    }
+    return boosts
+}
+
+// SetFetchBoost saves a boost so it survives restarts until it expires
+func SetFetchBoost(name string, boost FetchBoost) error {
+    return UpdateState(func(s *State) {
+        if s.FetchBoosts == nil {
+            s.FetchBoosts = make(map[string]FetchBoost)
+        }
+        s.FetchBoosts[name] = boost
+    })
+}
+
+// ClearFetchBoost ends a source's boost and reports whether it had one
+func ClearFetchBoost(name string) (bool, error) {
+    found := false
+    err := UpdateState(func(s *State) {
+        if _, found = s.FetchBoosts[name]; found {
+            delete(s.FetchBoosts, name)
+        }
+    })
+    return found, err
+}
+
+// ExpireFetchBoosts drops boosts that have run out, including any that
+// expired while the bot was down, and returns their sources
+func ExpireFetchBoosts() []string {
+    var expired []string
+    if err := UpdateState(func(s *State) {
+        now := time.Now()
+        for name, boost := range s.FetchBoosts {
+            if !now.Before(boost.ExpiresAt) {
+                expired = append(expired, name)
+                delete(s.FetchBoosts, name)
+            }
+        }
+    }); err != nil {
+        Logger().Printf("Failed to expire fetch boosts: %v", err)
+    }
+    return expired
+}
+
+// runFetchBoosts fetches boosted sources on their own interval, between the
+// regular cycles, until the scheduler stops
+func (s *Scheduler) runFetchBoosts() {
+    ticker := time.NewTicker(fetchBoostTick)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ticker.C:
+            s.checkFetchBoosts()
+        case <-s.done:
+            return
+        }
+    }
+}
+
+// checkFetchBoosts reverts expired boosts and fetches the boosted sources
+// that are due
+func (s *Scheduler) checkFetchBoosts() {
+    for _, name := range ExpireFetchBoosts() {
+        s.bot.logger.Info("Fetch boost for %s expired; back to the regular interval", name)
+    }
+
+    for name, boost := range activeFetchBoosts() {
+        if time.Since(s.lastChecked(name)) < boost.Interval {
+            continue
+        }
+        if err := s.checkSources(name, true); err != nil {
+            s.bot.logger.Error("Boosted fetch of %s failed: %v", name, err)
+        }
+    }
+}
+
+// lastChecked returns when a source was last fetched, zero if never
+func (s *Scheduler) lastChecked(name string) time.Time {
+    s.mutex.RLock()
+    defer s.mutex.RUnlock()
+    for _, source := range s.sources {
+        if strings.EqualFold(source.Name, name) {
+            return s.lastCheck[source.URL]
+        }
+    }
+    return time.Time{}
+}
+
+// describeFetchBoost summarizes a running boost for /sources info
+func describeFetchBoost(boost FetchBoost) string {
+    text := fmt.Sprintf("Every %s until <t:%d:t> (<t:%d:R>)", boost.Interval, boost.ExpiresAt.Unix(), boost.ExpiresAt.Unix())
+    if boost.SetBy != "" {
+        text += fmt.Sprintf(" by <@%s>", boost.SetBy)
+    }
+    return text
+}
+
+// handleBoostSource fetches a source every interval for duration, then
+// returns it to the regular fetch interval. An interval of "off" ends the
+// boost early.
+func (b *Bot) handleBoostSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    name := strings.TrimSpace(getOptionString(options, "name"))
+    if name == "" {
+        respondWithError(s, i, "Please specify the source name")
+        return nil
+    }
+    sources, err := LoadSources()
+    if err != nil {
+        respondWithError(s, i, "Failed to load sources")
+        return fmt.Errorf("failed to load sources: %v", err)
+    }
+    src, ok := findSource(sources, name)
+    if !ok {
+        respondWithError(s, i, "Source not found")
+        return nil
+    }
+
+    interval := strings.TrimSpace(strings.ToLower(getOptionString(options, "interval")))
+    if interval == "off" {
+        found, err := ClearFetchBoost(src.Name)
+        if err != nil {
+            respondWithError(s, i, "Failed to end the boost")
+            return fmt.Errorf("failed to clear fetch boost for %s: %v", src.Name, err)
+        }
+        content := fmt.Sprintf("**%s** has no fetch boost", src.Name)
+        if found {
+            content = fmt.Sprintf("⏹️ Ended the fetch boost for **%s**", src.Name)
+            b.logger.Info("Fetch boost for %s ended by %s", src.Name, interactionUserID(i))
+        }
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseChannelMessageWithSource,
+            Data: &discordgo.InteractionResponseData{
+                Content: content,
+                Flags:   discordgo.MessageFlagsEphemeral,
+            },
+        })
+    }
+
+    every, err := time.ParseDuration(interval)
+    if err != nil || every < fetchBoostMinInterval {
+        respondWithError(s, i, fmt.Sprintf("Invalid interval '%s', use e.g. 2m or 5m (at least %s)", interval, fetchBoostMinInterval))
+        return nil
+    }
+    duration := getOptionString(options, "duration")
+    lasts, err := parseCleanupAge(duration)
+    if err != nil || lasts > fetchBoostMaxDuration {
+        respondWithError(s, i, fmt.Sprintf("Invalid duration '%s', use e.g. 30m or 6h (at most %s)", duration, fetchBoostMaxDuration))
+        return nil
+    }
+
+    boost := FetchBoost{
+        Interval:  every,
+        ExpiresAt: time.Now().Add(lasts),
+        SetBy:     interactionUserID(i),
+    }
+    if err := SetFetchBoost(src.Name, boost); err != nil {
+        respondWithError(s, i, "Failed to save the boost")
+        return fmt.Errorf("failed to set fetch boost for %s: %v", src.Name, err)
+    }
+    b.logger.Info("Fetch boost for %s set by %s: every %s for %s", src.Name, boost.SetBy, every, lasts)
+
+    content := fmt.Sprintf("🚀 Fetching **%s** every %s until <t:%d:t>", src.Name, every, boost.ExpiresAt.Unix())
+    if src.Paused {
+        content += "\n⚠️ The source is paused, so it won't be fetched until it is enabled"
+    }
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
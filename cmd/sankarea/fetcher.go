@@ -125,10 +125,8 @@ func (f *Fetcher) FetchSource(ctx context.Context, source NewsSource) ([]*NewsAr
             continue
         }
 
-        // Parse publication date
-        pubDate := item.PublishedParsed
-        if pubDate == nil {
-            pubDate = &time.Time{}
+        if skipUndated(item) {
+            continue
         }
 
         // Create article
@@ -139,15 +137,14 @@ func (f *Fetcher) FetchSource(ctx context.Context, source NewsSource) ([]*NewsAr
             URL:        item.Link,
             Source:     source.Name,
             Category:   source.Category,
-            PublishedAt: *pubDate,
+            PublishedAt: getPublishedTime(item),
             FetchedAt:  time.Now(),
             Citations:  extractCitations(item),
         }
 
         // Perform fact checking if enabled for this source
         if source.FactCheck {
-            fc := NewFactChecker()
-            result, err := fc.CheckArticle(ctx, article)
+            result, err := DefaultFactChecker().CheckArticle(ctx, article)
             if err != nil {
                 Logger().Printf("Warning: fact check failed for %s: %v", article.URL, err)
             } else {
@@ -197,12 +194,9 @@ func (f *Fetcher) fetchFeed(ctx context.Context, url string) (*gofeed.Feed, erro
 // Helper functions
 
 func getArticleContent(item *gofeed.Item) string {
-    // Try different fields for content
-    if item.Content != "" {
-        return item.Content
-    }
-    if item.Description != "" {
-        return item.Description
+    // Use the longest body the feed provides
+    if body := itemBody(item); body != "" {
+        return body
     }
     return item.Title
 }
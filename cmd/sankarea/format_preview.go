@@ -0,0 +1,102 @@
+// cmd/sankarea/format_preview.go
+package main
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+)
+
+// formatPreviewStyles are shown in this order, one message each
+var formatPreviewStyles = []string{FormatStyleCompact, FormatStyleDetailed, FormatStyleEmbed}
+
+// handleFormatPreviewCommand renders a stored article in every format style
+// so operators can pick a channel's format_style
+func (b *Bot) handleFormatPreviewCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    url := strings.TrimSpace(getOptionString(i.ApplicationCommandData().Options, "url"))
+    if url == "" {
+        respondWithError(s, i, "Please provide the article URL")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    article, err := b.database.GetArticleByURL(url)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to look up the article")
+        return fmt.Errorf("failed to load article %s: %v", url, err)
+    }
+    if article == nil {
+        editResponse(s, i, "No stored article with that URL. Only fetched articles can be previewed.")
+        return nil
+    }
+
+    for idx, style := range formatPreviewStyles {
+        content, embeds := formatPreview(article, style)
+        label := fmt.Sprintf("**%s**", style)
+        if style == defaultFormatStyle() {
+            label += " (default)"
+        }
+        content = truncateString(label+"\n"+content, 2000)
+
+        if idx == 0 {
+            if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+                Content: &content,
+                Embeds:  &embeds,
+            }); err != nil {
+                return fmt.Errorf("failed to send format preview: %v", err)
+            }
+            continue
+        }
+        if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+            Content: content,
+            Embeds:  embeds,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        }); err != nil {
+            return fmt.Errorf("failed to send format preview: %v", err)
+        }
+    }
+    return nil
+}
+
+// formatPreview renders an article in one style the way it would be posted
+func formatPreview(article *NewsArticle, style string) (string, []*discordgo.MessageEmbed) {
+    item := &gofeed.Item{
+        Title:           article.Title,
+        Link:            article.URL,
+        Description:     article.Content,
+        PublishedParsed: &article.PublishedAt,
+    }
+    if article.ImageURL != "" {
+        item.Image = &gofeed.Image{URL: article.ImageURL}
+    }
+
+    summary := postedText(article)
+    if summary == "" {
+        summary = articleTeaser(article.Content)
+    }
+
+    factCheck := ""
+    if article.FactCheckResult != nil {
+        factCheck = getReliabilityBadge(article, defaultLanguage())
+    }
+
+    content, embeds := FormatNewsItem(item, article.Source, article.Category, summary, factCheck, nil, style, factCheck != "", true, defaultLanguage())
+    content, embeds = applyReadingTime(content, embeds, style, article.WordCount, defaultLanguage())
+    return applyPodcast(content, embeds, article.AudioURL, article.AudioDuration)
+}
@@ -3,7 +3,6 @@ package main
 
 import (
     "fmt"
-    "strings"
     "time"
 
     "github.com/bwmarrin/discordgo"
@@ -25,8 +24,10 @@ func NewFormatter() *Formatter {
     }
 }
 
-// FormatNewsDigest creates a formatted news digest for Discord
-func (f *Formatter) FormatNewsDigest(articles []*NewsArticle) []*discordgo.MessageSend {
+// FormatNewsDigest lays out /news results for Discord grouped by category,
+// with labels in lang. The results are already capped and ranked, so every
+// article is shown in the order given; digests are built by buildDigest.
+func (f *Formatter) FormatNewsDigest(articles []*NewsArticle, lang string) []*discordgo.MessageSend {
     // Group articles by category
     categories := make(map[string][]*NewsArticle)
     for _, article := range articles {
@@ -36,32 +37,34 @@ func (f *Formatter) FormatNewsDigest(articles []*NewsArticle) []*discordgo.Messa
     var messages []*discordgo.MessageSend
 
     // Create summary embed
+    summaryData := &DigestSummaryData{Lang: lang, End: time.Now(), Total: len(articles)}
+    for category, categoryArticles := range categories {
+        summaryData.Categories = append(summaryData.Categories, DigestCategoryData{
+            Lang:  lang,
+            Name:  category,
+            Emoji: getCategoryEmoji(category),
+            Count: len(categoryArticles),
+            Shown: len(categoryArticles),
+        })
+    }
     summaryEmbed := &discordgo.MessageEmbed{
-        Title:       "📰 News Digest",
-        Description: fmt.Sprintf("News summary for %s", time.Now().Format("2006-01-02 15:04 MST")),
+        Title:       tr(lang, "digest.title"),
+        Description: digestTemplates().RenderSummary(summaryData),
         Color:       0x7289DA,
-        Fields:      make([]*discordgo.MessageEmbedField, 0),
+        Fields:      digestCategoryFields(summaryData),
         Footer: &discordgo.MessageEmbedFooter{
             Text: fmt.Sprintf("Powered by Sankarea v%s", botVersion),
         },
     }
 
-    // Add category summaries
-    for category, categoryArticles := range categories {
-        summaryEmbed.Fields = append(summaryEmbed.Fields, &discordgo.MessageEmbedField{
-            Name:   fmt.Sprintf("%s %s", getCategoryEmoji(category), category),
-            Value:  fmt.Sprintf("%d articles", len(categoryArticles)),
-            Inline: true,
-        })
-    }
-
     messages = append(messages, &discordgo.MessageSend{
         Embeds: []*discordgo.MessageEmbed{summaryEmbed},
+        Files:  applyDigestHeader(summaryEmbed, summaryData),
     })
 
     // Create category embeds
     for category, categoryArticles := range categories {
-        embeds := f.formatCategoryArticles(category, categoryArticles)
+        embeds := f.formatCategoryArticles(category, categoryArticles, lang)
         
         // Split embeds into multiple messages if needed
         currentEmbeds := make([]*discordgo.MessageEmbed, 0)
@@ -95,7 +98,7 @@ func (f *Formatter) FormatNewsDigest(articles []*NewsArticle) []*discordgo.Messa
 }
 
 // formatCategoryArticles creates embeds for articles in a category
-func (f *Formatter) formatCategoryArticles(category string, articles []*NewsArticle) []*discordgo.MessageEmbed {
+func (f *Formatter) formatCategoryArticles(category string, articles []*NewsArticle, lang string) []*discordgo.MessageEmbed {
     var embeds []*discordgo.MessageEmbed
 
     // Calculate how many articles per embed
@@ -107,12 +110,12 @@ func (f *Formatter) formatCategoryArticles(category string, articles []*NewsArti
             if end > len(articles) {
                 end = len(articles)
             }
-            embed := f.createCategoryEmbed(category, articles[i:end], i+1)
+            embed := f.createCategoryEmbed(category, articles[i:end], i+1, lang)
             embeds = append(embeds, embed)
         }
     } else {
         // Create single embed
-        embed := f.createCategoryEmbed(category, articles, 1)
+        embed := f.createCategoryEmbed(category, articles, 1, lang)
         embeds = append(embeds, embed)
     }
 
@@ -120,9 +123,9 @@ func (f *Formatter) formatCategoryArticles(category string, articles []*NewsArti
 }
 
 // createCategoryEmbed creates an embed for a set of articles
-func (f *Formatter) createCategoryEmbed(category string, articles []*NewsArticle, page int) *discordgo.MessageEmbed {
+func (f *Formatter) createCategoryEmbed(category string, articles []*NewsArticle, page int, lang string) *discordgo.MessageEmbed {
     embed := &discordgo.MessageEmbed{
-        Title: fmt.Sprintf("%s %s News", getCategoryEmoji(category), category),
+        Title: getCategoryEmoji(category) + " " + tr(lang, "digest.category_news", category),
         Color: getCategoryColor(category),
         Fields: make([]*discordgo.MessageEmbedField, 0),
     }
@@ -131,7 +134,7 @@ func (f *Formatter) createCategoryEmbed(category string, articles []*NewsArticle
         // Create article field
         field := &discordgo.MessageEmbedField{
             Name: f.truncateString(article.Title, 256),
-            Value: f.formatArticleField(article),
+            Value: f.formatArticleField(article, lang),
             Inline: false,
         }
         embed.Fields = append(embed.Fields, field)
@@ -140,32 +143,16 @@ func (f *Formatter) createCategoryEmbed(category string, articles []*NewsArticle
     // Add footer if multiple pages
     if page > 1 {
         embed.Footer = &discordgo.MessageEmbedFooter{
-            Text: fmt.Sprintf("Page %d", page),
+            Text: tr(lang, "label.page", page),
         }
     }
 
     return embed
 }
 
-// formatArticleField formats the content of an article field
-func (f *Formatter) formatArticleField(article *NewsArticle) string {
-    var parts []string
-
-    // Add source and timestamp
-    parts = append(parts, fmt.Sprintf("Source: %s | %s",
-        article.Source,
-        article.PublishedAt.Format("2006-01-02 15:04 MST")))
-
-    // Add URL
-    parts = append(parts, article.URL)
-
-    // Add reliability badge if available
-    if article.FactCheckResult != nil {
-        parts = append(parts, getReliabilityBadge(article.FactCheckResult))
-    }
-
-    // Join with newlines and truncate if needed
-    return f.truncateString(strings.Join(parts, "\n"), f.maxFieldLength)
+// formatArticleField formats the content of an article field using the digest article template
+func (f *Formatter) formatArticleField(article *NewsArticle, lang string) string {
+    return f.truncateString(digestTemplates().RenderArticle(newDigestArticleData(article, lang)), f.maxFieldLength)
 }
 
 // Helper functions
@@ -191,7 +178,7 @@ func (f *Formatter) truncateString(s string, maxLen int) string {
     return s[:maxLen-3] + "..."
 }
 
-func getReliabilityBadge(result *FactCheckResult) string {
+func getReliabilityBadge(result *FactCheckResult, lang string) string {
     var emoji string
     switch result.ReliabilityTier {
     case "High":
@@ -203,7 +190,7 @@ func getReliabilityBadge(result *FactCheckResult) string {
     default:
         emoji = "⚫"
     }
-    return fmt.Sprintf("%s Reliability: %s (%.1f/1.0)", emoji, result.ReliabilityTier, result.Score)
+    return fmt.Sprintf("%s %s: %s (%.1f/1.0)", emoji, tr(lang, "label.reliability"), result.ReliabilityTier, result.Score)
 }
 
 func getCategoryEmoji(category string) string {
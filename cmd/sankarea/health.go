@@ -85,34 +85,44 @@ func (hm *HealthMonitor) PerformChecks() {
 
     // Check memory usage
     if hm.metrics.MemoryUsageMB > highMemoryThresholdMB {
-        hm.logError("High memory usage detected", "warning")
+        hm.logError("High memory usage detected", ErrorSeverityMedium)
     }
 
     // Check disk space
     if hm.metrics.DiskUsagePercent > highDiskUsagePercent {
-        hm.logError("Low disk space warning", "warning")
+        hm.logError("Low disk space warning", ErrorSeverityMedium)
     }
 
     // Check error rate
     if hm.metrics.ErrorsPerHour > highErrorRatePerHour {
-        hm.logError("High error rate detected", "warning")
+        hm.logError("High error rate detected", ErrorSeverityMedium)
     }
 
     // Check API rate
     if hm.metrics.APICallsPerHour > highAPICallsPerHour {
-        hm.logError("High API usage detected", "warning")
+        hm.logError("High API usage detected", ErrorSeverityMedium)
     }
 
     // Perform database health check if enabled
     if cfg.EnableDatabase {
         if err := checkDatabaseHealth(); err != nil {
-            hm.logError(fmt.Sprintf("Database health check failed: %v", err), "error")
+            hm.logError(fmt.Sprintf("Database health check failed: %v", err), ErrorSeverityHigh)
+        }
+    }
+
+    // Check that feeds are still producing content
+    if sources, err := LoadSources(); err == nil {
+        _, components := CheckFeedHealth(sources, GetState().LastArticleTime)
+        for name, component := range components {
+            if component.Status != StatusOK {
+                hm.logError(fmt.Sprintf("%s %s: %s", name, component.Status, component.Description), ErrorSeverityMedium)
+            }
         }
     }
 
     // Clean up old log files
     if err := hm.cleanupOldLogs(); err != nil {
-        hm.logError(fmt.Sprintf("Failed to clean up old logs: %v", err), "warning")
+        hm.logError(fmt.Sprintf("Failed to clean up old logs: %v", err), ErrorSeverityMedium)
     }
 
     // Update streaks
@@ -148,20 +158,13 @@ func (hm *HealthMonitor) PerformChecks() {
 }
 
 // logError adds an error event to the log
-func (hm *HealthMonitor) logError(message, severity string) {
-    event := &ErrorEvent{
-        Time:      time.Now(),
-        Component: "health-monitor",
-        Message:   message,
-        Severity:  severity,
-    }
+func (hm *HealthMonitor) logError(message string, severity ErrorSeverity) {
+    event := HandleError(message, nil, "health-monitor", severity)
 
     hm.errorLog = append(hm.errorLog, event)
     if len(hm.errorLog) > defaultErrorBufferSize {
         hm.errorLog = hm.errorLog[len(hm.errorLog)-defaultErrorBufferSize:]
     }
-
-    Logger().Printf("[%s] %s", severity, message)
 }
 
 // cleanupOldLogs removes log files older than the retention period
@@ -195,6 +198,67 @@ func (hm *HealthMonitor) cleanupOldLogs() error {
     return nil
 }
 
+// CheckFeedHealth reports "degraded" when no article has arrived within the
+// staleness window and "unhealthy" when too many sources are failing. It
+// returns the overall status and per-component detail.
+func CheckFeedHealth(sources []NewsSource, lastArticle time.Time) (string, map[string]Status) {
+    now := time.Now()
+    components := make(map[string]Status)
+
+    // Freshness of stored content
+    window := time.Duration(cfg.StaleFeedMinutes) * time.Minute
+    feeds := Status{Status: StatusOK, LastCheck: now}
+    switch {
+    case lastArticle.IsZero():
+        feeds.Status = StatusDegraded
+        feeds.Description = "no articles stored yet"
+    case now.Sub(lastArticle) > window:
+        feeds.Status = StatusDegraded
+        feeds.Description = fmt.Sprintf("no new articles for %s (window %s)",
+            now.Sub(lastArticle).Round(time.Minute), window)
+    default:
+        feeds.Description = fmt.Sprintf("last article %s ago", now.Sub(lastArticle).Round(time.Minute))
+    }
+    components["feeds"] = feeds
+
+    // Share of sources currently erroring: failing since their last
+    // successful fetch, or flagged broken by feed validation
+    outages := GetState().SourceOutages
+    active, failing := 0, 0
+    for _, src := range sources {
+        if src.Paused {
+            continue
+        }
+        active++
+        if _, down := outages[src.Name]; down || src.Broken {
+            failing++
+        }
+    }
+    srcStatus := Status{Status: StatusOK, LastCheck: now}
+    if active > 0 {
+        pct := float64(failing) / float64(active) * 100
+        srcStatus.Description = fmt.Sprintf("%d/%d sources failing (%.0f%%)", failing, active, pct)
+        if pct > cfg.MaxFailingSourcePct {
+            srcStatus.Status = StatusUnhealthy
+        } else if failing > 0 {
+            srcStatus.Status = StatusDegraded
+        }
+    } else {
+        srcStatus.Description = "no active sources"
+    }
+    components["sources"] = srcStatus
+
+    overall := StatusOK
+    for _, c := range components {
+        if c.Status == StatusUnhealthy {
+            overall = StatusUnhealthy
+        } else if c.Status == StatusDegraded && overall == StatusOK {
+            overall = StatusDegraded
+        }
+    }
+    return overall, components
+}
+
 // GetHealthStatus returns the current health status
 func (hm *HealthMonitor) GetHealthStatus() map[string]interface{} {
     hm.mutex.RLock()
@@ -202,8 +266,14 @@ func (hm *HealthMonitor) GetHealthStatus() map[string]interface{} {
 
     currentState := GetState()
 
+    var components map[string]Status
+    if sources, err := LoadSources(); err == nil {
+        _, components = CheckFeedHealth(sources, currentState.LastArticleTime)
+    }
+
     return map[string]interface{}{
         "status":          getStatusString(currentState),
+        "components":      components,
         "version":         cfg.Version,
         "uptime":         FormatDuration(time.Since(currentState.StartupTime)),
         "lastCheck":       hm.lastCheck,
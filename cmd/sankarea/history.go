@@ -0,0 +1,274 @@
+// cmd/sankarea/history.go
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+)
+
+const (
+    configHistoryDir   = "data/config_history"
+    maxConfigSnapshots = 200
+
+    SnapshotKindConfig  = "config"
+    SnapshotKindSources = "sources"
+)
+
+// ConfigSnapshot is a saved copy of the config or sources file
+type ConfigSnapshot struct {
+    ID    int             `json:"id"`
+    Kind  string          `json:"kind"`
+    Time  time.Time       `json:"time"`
+    Actor string          `json:"actor,omitempty"` // Discord user ID, empty for automatic changes
+    Data  json.RawMessage `json:"data"`
+}
+
+// FieldChange is a single field-level difference between two snapshots
+type FieldChange struct {
+    Field string
+    Old   string
+    New   string
+}
+
+var historyMutex sync.Mutex
+
+// recordSnapshot stores a new snapshot of v with credentials removed,
+// skipping it if nothing changed since the last snapshot of that kind
+func recordSnapshot(kind, actor string, v interface{}) error {
+    raw, err := json.Marshal(v)
+    if err != nil {
+        return fmt.Errorf("failed to marshal snapshot: %v", err)
+    }
+    var generic interface{}
+    if err := json.Unmarshal(raw, &generic); err != nil {
+        return fmt.Errorf("failed to marshal snapshot: %v", err)
+    }
+    data, err := json.Marshal(redactSecrets("", generic))
+    if err != nil {
+        return fmt.Errorf("failed to marshal snapshot: %v", err)
+    }
+
+    historyMutex.Lock()
+    defer historyMutex.Unlock()
+
+    snapshots, err := loadSnapshots()
+    if err != nil {
+        return err
+    }
+    nextID := 1
+    if len(snapshots) > 0 {
+        nextID = snapshots[len(snapshots)-1].ID + 1
+    }
+    if prev := previousSnapshot(snapshots, kind, nextID); prev != nil && string(prev.Data) == string(data) {
+        return nil
+    }
+
+    snapshot := ConfigSnapshot{
+        ID:    nextID,
+        Kind:  kind,
+        Time:  time.Now().UTC(),
+        Actor: actor,
+        Data:  data,
+    }
+    encoded, err := json.MarshalIndent(snapshot, "", "  ")
+    if err != nil {
+        return fmt.Errorf("failed to marshal snapshot: %v", err)
+    }
+    if err := os.MkdirAll(configHistoryDir, 0755); err != nil {
+        return fmt.Errorf("failed to create history directory: %v", err)
+    }
+    if err := writeFileAtomic(snapshotPath(snapshot.ID, kind), encoded, 0644); err != nil {
+        return fmt.Errorf("failed to save snapshot: %v", err)
+    }
+
+    // Drop the oldest snapshots beyond the retention limit
+    for len(snapshots)+1 > maxConfigSnapshots {
+        os.Remove(snapshotPath(snapshots[0].ID, snapshots[0].Kind))
+        snapshots = snapshots[1:]
+    }
+    return nil
+}
+
+// snapshotPath returns the file holding a snapshot
+func snapshotPath(id int, kind string) string {
+    return filepath.Join(configHistoryDir, fmt.Sprintf("%06d-%s.json", id, kind))
+}
+
+// loadSnapshots reads all snapshots, oldest first
+func loadSnapshots() ([]*ConfigSnapshot, error) {
+    files, err := filepath.Glob(filepath.Join(configHistoryDir, "*.json"))
+    if err != nil {
+        return nil, fmt.Errorf("failed to list snapshots: %v", err)
+    }
+
+    var snapshots []*ConfigSnapshot
+    for _, file := range files {
+        data, err := os.ReadFile(file)
+        if err != nil {
+            return nil, fmt.Errorf("failed to read snapshot %s: %v", file, err)
+        }
+        var snapshot ConfigSnapshot
+        if err := json.Unmarshal(data, &snapshot); err != nil {
+            Logger().Printf("Skipping unreadable snapshot %s: %v", file, err)
+            continue
+        }
+        snapshots = append(snapshots, &snapshot)
+    }
+
+    sort.Slice(snapshots, func(i, j int) bool {
+        return snapshots[i].ID < snapshots[j].ID
+    })
+    return snapshots, nil
+}
+
+// previousSnapshot returns the latest snapshot of kind with an ID below id
+func previousSnapshot(snapshots []*ConfigSnapshot, kind string, id int) *ConfigSnapshot {
+    for i := len(snapshots) - 1; i >= 0; i-- {
+        if snapshots[i].Kind == kind && snapshots[i].ID < id {
+            return snapshots[i]
+        }
+    }
+    return nil
+}
+
+// GetConfigHistory returns up to limit snapshots, newest first
+func GetConfigHistory(limit int) ([]*ConfigSnapshot, error) {
+    historyMutex.Lock()
+    defer historyMutex.Unlock()
+
+    snapshots, err := loadSnapshots()
+    if err != nil {
+        return nil, err
+    }
+
+    var recent []*ConfigSnapshot
+    for i := len(snapshots) - 1; i >= 0 && len(recent) < limit; i-- {
+        recent = append(recent, snapshots[i])
+    }
+    return recent, nil
+}
+
+// DiffSnapshot compares snapshot id with the previous snapshot of the same kind
+func DiffSnapshot(id int) (*ConfigSnapshot, []FieldChange, error) {
+    historyMutex.Lock()
+    defer historyMutex.Unlock()
+
+    snapshots, err := loadSnapshots()
+    if err != nil {
+        return nil, nil, err
+    }
+
+    var current *ConfigSnapshot
+    for _, snapshot := range snapshots {
+        if snapshot.ID == id {
+            current = snapshot
+            break
+        }
+    }
+    if current == nil {
+        return nil, nil, fmt.Errorf("snapshot #%d not found", id)
+    }
+
+    before := make(map[string]string)
+    if prev := previousSnapshot(snapshots, current.Kind, id); prev != nil {
+        if before, err = flattenSnapshot(prev.Data); err != nil {
+            return nil, nil, err
+        }
+    }
+    after, err := flattenSnapshot(current.Data)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    var changes []FieldChange
+    for field, value := range after {
+        if old, ok := before[field]; !ok || old != value {
+            changes = append(changes, FieldChange{Field: field, Old: before[field], New: value})
+        }
+    }
+    for field, old := range before {
+        if _, ok := after[field]; !ok {
+            changes = append(changes, FieldChange{Field: field, Old: old})
+        }
+    }
+    sort.Slice(changes, func(i, j int) bool {
+        return changes[i].Field < changes[j].Field
+    })
+    return current, changes, nil
+}
+
+// flattenSnapshot turns snapshot JSON into field paths mapped to values.
+// Lists of objects with a name are keyed by name so reordering isn't a change.
+func flattenSnapshot(data []byte) (map[string]string, error) {
+    var v interface{}
+    if err := json.Unmarshal(data, &v); err != nil {
+        return nil, fmt.Errorf("failed to parse snapshot: %v", err)
+    }
+    fields := make(map[string]string)
+    flattenValue("", v, fields)
+    return fields, nil
+}
+
+func flattenValue(prefix string, v interface{}, fields map[string]string) {
+    switch value := v.(type) {
+    case map[string]interface{}:
+        for key, child := range value {
+            path := key
+            if prefix != "" {
+                path = prefix + "." + key
+            }
+            flattenValue(path, child, fields)
+        }
+    case []interface{}:
+        for idx, child := range value {
+            key := fmt.Sprintf("%d", idx)
+            if obj, ok := child.(map[string]interface{}); ok {
+                if name, ok := obj["name"].(string); ok && name != "" {
+                    key = name
+                }
+            }
+            flattenValue(fmt.Sprintf("%s[%s]", prefix, key), child, fields)
+        }
+    default:
+        encoded, _ := json.Marshal(value)
+        fields[prefix] = string(encoded)
+    }
+}
+
+// redactSecrets replaces credential-looking values so snapshots never store them
+func redactSecrets(path string, v interface{}) interface{} {
+    switch value := v.(type) {
+    case map[string]interface{}:
+        for key, child := range value {
+            value[key] = redactSecrets(path+"."+key, child)
+        }
+        return value
+    case []interface{}:
+        for idx, child := range value {
+            value[idx] = redactSecrets(path, child)
+        }
+        return value
+    default:
+        if isSecretField(path) && value != nil && value != "" {
+            return redactedValue
+        }
+        return value
+    }
+}
+
+// isSecretField reports whether a field path looks like it holds a credential
+func isSecretField(path string) bool {
+    path = strings.ToLower(path)
+    for _, marker := range []string{"token", "key", "pass", "secret", "headers", "webhook"} {
+        if strings.Contains(path, marker) {
+            return true
+        }
+    }
+    return false
+}
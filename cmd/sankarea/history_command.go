@@ -0,0 +1,93 @@
+// cmd/sankarea/history_command.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Limits for /history
+const (
+    defaultHistoryCount = 10
+    maxHistoryCount     = 50
+    historyPageSize     = 5
+)
+
+// historyPages lays a source's articles out as embeds of historyPageSize each
+func historyPages(source string, articles []*NewsArticle) []*discordgo.MessageEmbed {
+    var pages []*discordgo.MessageEmbed
+    for start := 0; start < len(articles); start += historyPageSize {
+        end := start + historyPageSize
+        if end > len(articles) {
+            end = len(articles)
+        }
+
+        lines := make([]string, 0, end-start)
+        for idx, article := range articles[start:end] {
+            lines = append(lines, fmt.Sprintf("**%d.** [%s](%s)\n<t:%d:f> · %s",
+                start+idx+1, truncateString(article.Title, 200), article.URL, article.PublishedAt.Unix(), article.Category))
+        }
+
+        pages = append(pages, &discordgo.MessageEmbed{
+            Title:       fmt.Sprintf("📜 Recent articles from %s", source),
+            Description: strings.Join(lines, "\n\n"),
+            Color:       0x7289DA,
+            Footer: &discordgo.MessageEmbedFooter{
+                Text: fmt.Sprintf("%d articles", len(articles)),
+            },
+            Timestamp: time.Now().Format(time.RFC3339),
+        })
+    }
+    return pages
+}
+
+// handleHistoryCommand lists a source's most recent stored articles
+func (b *Bot) handleHistoryCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    options := i.ApplicationCommandData().Options
+    source := strings.TrimSpace(getOptionString(options, "source"))
+    if source == "" {
+        respondWithError(s, i, "A source name is required")
+        return nil
+    }
+    if b.database == nil {
+        respondWithError(s, i, "Article history needs the article database")
+        return nil
+    }
+
+    count := defaultHistoryCount
+    if n, ok := getOptionIntValue(options, "count"); ok {
+        count = int(n)
+    }
+    if count < 1 || count > maxHistoryCount {
+        respondWithError(s, i, fmt.Sprintf("Count must be between 1 and %d", maxHistoryCount))
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    articles, err := b.database.GetArticlesBySource(source, count)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to load articles")
+        return fmt.Errorf("failed to load history for %s: %v", source, err)
+    }
+    if len(articles) == 0 {
+        editResponse(s, i, fmt.Sprintf("No stored articles from **%s**", source))
+        return nil
+    }
+
+    if err := editResponsePaginated(s, i, historyPages(source, articles)); err != nil {
+        return fmt.Errorf("failed to send history: %v", err)
+    }
+    return nil
+}
@@ -0,0 +1,171 @@
+// cmd/sankarea/html_source.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+
+    "github.com/PuerkitoBio/goquery"
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+)
+
+// Source types; sources without a type are RSS/Atom feeds
+const (
+    SourceTypeRSS     = "rss"
+    SourceTypeHTML    = "html"    // scraped from a web page with a CSS selector
+    SourceTypePodcast = "podcast" // RSS feed whose items are posted with their audio enclosure
+)
+
+// htmlTestMatches is how many matches /sources testhtml shows
+const htmlTestMatches = 5
+
+// parseHTMLFeed scrapes a page into a feed. Each element matched by selector
+// becomes an item: its link is the element's own href or its first link's,
+// and its title is that link's text, falling back to the element's text.
+// Scraped items carry no date, so undated_items decides how they're handled.
+func parseHTMLFeed(body io.Reader, pageURL, selector string) (*gofeed.Feed, error) {
+    if strings.TrimSpace(selector) == "" {
+        return nil, fmt.Errorf("HTML source has no selector")
+    }
+    base, err := url.Parse(pageURL)
+    if err != nil {
+        return nil, fmt.Errorf("invalid page URL: %v", err)
+    }
+    doc, err := goquery.NewDocumentFromReader(body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse HTML: %v", err)
+    }
+
+    feed := &gofeed.Feed{
+        Title: strings.TrimSpace(doc.Find("title").First().Text()),
+        Link:  pageURL,
+    }
+    // An invalid selector matches nothing rather than failing
+    seen := make(map[string]bool)
+    doc.Find(selector).Each(func(_ int, sel *goquery.Selection) {
+        item := htmlItem(sel, base)
+        if item == nil || seen[item.Link] {
+            return
+        }
+        seen[item.Link] = true
+        feed.Items = append(feed.Items, item)
+    })
+    return feed, nil
+}
+
+// htmlItem turns a matched element into a feed item, or nil if it has no link
+func htmlItem(sel *goquery.Selection, base *url.URL) *gofeed.Item {
+    link := sel
+    if goquery.NodeName(sel) != "a" {
+        link = sel.Find("a[href]").First()
+    }
+    href, ok := link.Attr("href")
+    if !ok || strings.TrimSpace(href) == "" {
+        return nil
+    }
+    ref, err := url.Parse(strings.TrimSpace(href))
+    if err != nil {
+        return nil
+    }
+
+    title := collapseSpace(link.Text())
+    if title == "" {
+        title = collapseSpace(sel.Text())
+    }
+    if title == "" {
+        return nil
+    }
+    return &gofeed.Item{
+        Title: title,
+        Link:  base.ResolveReference(ref).String(),
+    }
+}
+
+// collapseSpace trims a string and folds runs of whitespace into single spaces
+func collapseSpace(s string) string {
+    return strings.Join(strings.Fields(s), " ")
+}
+
+// fetchHTMLFeed downloads a page and scrapes it with selector
+func fetchHTMLFeed(ctx context.Context, client *http.Client, pageURL, selector string) (*gofeed.Feed, error) {
+    req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %v", err)
+    }
+    req.Header.Set("User-Agent", "Sankarea News Bot/1.0")
+    req.Header.Set("Accept", "text/html, application/xhtml+xml")
+
+    resp, err := client.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("failed to fetch page: %v", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+    }
+    return parseHTMLFeed(io.LimitReader(resp.Body, 10*1024*1024), pageURL, selector)
+}
+
+// handleTestHTMLSource fetches a page, applies a selector and shows the first
+// matches privately, so a selector can be checked before adding the source
+func (b *Bot) handleTestHTMLSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    pageURL := getOptionString(options, "url")
+    selector := getOptionString(options, "selector")
+    if !isValidURL(pageURL) || selector == "" {
+        respondWithError(s, i, "Provide a valid url and a CSS selector")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    feed, err := fetchHTMLFeed(ctx, GetHTTPClient(), pageURL, selector)
+    if err != nil {
+        editResponse(s, i, fmt.Sprintf("❌ %v", err))
+        return nil
+    }
+    if len(feed.Items) == 0 {
+        editResponse(s, i, fmt.Sprintf("⚠️ `%s` matched no elements with a link (check the selector syntax)", selector))
+        return nil
+    }
+
+    embed := &discordgo.MessageEmbed{
+        Title:       "🧪 Selector Test",
+        Description: fmt.Sprintf("`%s` matched %d items on %s", selector, len(feed.Items), pageURL),
+        Color:       0x7289DA,
+    }
+    for n, item := range feed.Items {
+        if n >= htmlTestMatches {
+            break
+        }
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:  truncateString(item.Title, 256),
+            Value: truncateString(item.Link, 1024),
+        })
+    }
+    editResponseWithEmbed(s, i, embed)
+    return nil
+}
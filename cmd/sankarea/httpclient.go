@@ -0,0 +1,106 @@
+// cmd/sankarea/httpclient.go
+package main
+
+import (
+    "fmt"
+    "net/http"
+    "net/url"
+    "sync"
+    "time"
+)
+
+var (
+    httpClients     = make(map[string]*http.Client) // keyed by proxy URL, "" for the default
+    httpClientMutex sync.Mutex
+)
+
+// validateProxyURL checks that a proxy URL is usable; an empty URL means no proxy
+func validateProxyURL(raw string) error {
+    if raw == "" {
+        return nil
+    }
+    u, err := url.Parse(raw)
+    if err != nil {
+        return fmt.Errorf("failed to parse proxy URL: %v", err)
+    }
+    switch u.Scheme {
+    case "http", "https", "socks5", "socks5h":
+    default:
+        return fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", u.Scheme)
+    }
+    if u.Host == "" {
+        return fmt.Errorf("proxy URL %q has no host", u.Redacted())
+    }
+    return nil
+}
+
+// setSourceAuth adds a source's custom headers and basic auth credentials to a feed request
+func setSourceAuth(req *http.Request, headers map[string]string, user, pass string) {
+    for name, value := range headers {
+        req.Header.Set(name, value)
+    }
+    if user != "" || pass != "" {
+        req.SetBasicAuth(user, pass)
+    }
+}
+
+// GetHTTPClient returns the shared client for outbound requests, routed
+// through the configured proxy if one is set
+func GetHTTPClient() *http.Client {
+    proxy := ""
+    if cfg != nil {
+        proxy = cfg.ProxyURL
+    }
+    client, err := httpClientFor(proxy)
+    if err != nil {
+        // The global proxy is validated at startup, so this only happens on bad reloads
+        Logger().Printf("Ignoring invalid proxy: %v", err)
+        client, _ = proxiedClient("")
+    }
+    return client
+}
+
+// httpClientFor returns a cached client that sends requests through proxy.
+// An empty proxy falls back to the globally configured one.
+func httpClientFor(proxy string) (*http.Client, error) {
+    if proxy == "" && cfg != nil {
+        proxy = cfg.ProxyURL
+    }
+    return proxiedClient(proxy)
+}
+
+// proxiedClient returns the cached client for exactly proxy, without the
+// global fallback; an empty proxy gives a direct client, which never fails
+func proxiedClient(proxy string) (*http.Client, error) {
+    if err := validateProxyURL(proxy); err != nil {
+        return nil, err
+    }
+
+    httpClientMutex.Lock()
+    defer httpClientMutex.Unlock()
+
+    if client, ok := httpClients[proxy]; ok {
+        return client, nil
+    }
+
+    transport := &http.Transport{
+        Proxy:              http.ProxyFromEnvironment,
+        MaxIdleConns:       100,
+        IdleConnTimeout:    90 * time.Second,
+        DisableCompression: true,
+        MaxConnsPerHost:    10,
+        ForceAttemptHTTP2:  true,
+    }
+    if proxy != "" {
+        proxyURL, _ := url.Parse(proxy)
+        transport.Proxy = http.ProxyURL(proxyURL)
+        Logger().Printf("HTTP client created using proxy %s", proxyURL.Redacted())
+    }
+
+    client := &http.Client{
+        Timeout:   DefaultTimeout,
+        Transport: transport,
+    }
+    httpClients[proxy] = client
+    return client, nil
+}
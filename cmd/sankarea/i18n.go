@@ -0,0 +1,168 @@
+// cmd/sankarea/i18n.go
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+)
+
+// DefaultLanguage is used for missing translations and unset preferences
+const DefaultLanguage = "en"
+
+// englishLabels holds the built-in text for every translation key
+var englishLabels = map[string]string{
+    "label.source":         "Source",
+    "label.topics":         "Topics",
+    "label.sentiment":      "Sentiment",
+    "label.fact_check":     "Fact Check",
+    "label.summary":        "Summary",
+    "label.published":      "Published",
+    "label.reliability":    "Reliability",
+    "label.page":           "Page %d",
+    "label.reading_time":   "Reading time",
+    "reading_time.minutes": "%d min read",
+    "reliability.high":     "High reliability",
+    "reliability.medium":   "Medium reliability",
+    "reliability.low":      "Low reliability",
+    "reliability.unknown":  "Unknown reliability",
+    "sentiment.positive":   "Positive",
+    "sentiment.negative":   "Negative",
+    "sentiment.neutral":    "Neutral",
+    "digest.title":         "📰 News Digest",
+    "digest.summary_title": "📰 News Digest Summary",
+    "digest.range":         "News from %s to %s",
+    "digest.summary_for":   "News summary for %s",
+    "digest.articles":      "%d articles",
+    "digest.category_news": "%s News",
+    "digest.more":          "And %d more articles...",
+    "digest.overflow":      "+%d more",
+    "digest.check_pending": "Fact check pending",
+}
+
+// LanguageManager translates user-facing labels using locale files
+type LanguageManager struct {
+    mutex   sync.RWMutex
+    locales map[string]map[string]string
+}
+
+var (
+    languageManager     *LanguageManager
+    languageManagerOnce sync.Once
+)
+
+// Languages returns the shared language manager, loading locale files on first use
+func Languages() *LanguageManager {
+    languageManagerOnce.Do(func() {
+        dir := "config/locales"
+        if cfg != nil && cfg.LocalesPath != "" {
+            dir = cfg.LocalesPath
+        }
+        languageManager = NewLanguageManager()
+        if err := languageManager.LoadDir(dir); err != nil {
+            Logger().Printf("Failed to load locales: %v", err)
+        }
+    })
+    return languageManager
+}
+
+// NewLanguageManager creates a language manager with only the built-in English labels
+func NewLanguageManager() *LanguageManager {
+    return &LanguageManager{
+        locales: map[string]map[string]string{DefaultLanguage: englishLabels},
+    }
+}
+
+// LoadDir loads every <lang>.json file in dir as a flat key/value locale
+func (lm *LanguageManager) LoadDir(dir string) error {
+    files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+    if err != nil {
+        return fmt.Errorf("failed to list locale files: %v", err)
+    }
+
+    for _, file := range files {
+        data, err := os.ReadFile(file)
+        if err != nil {
+            return fmt.Errorf("failed to read locale %s: %v", file, err)
+        }
+
+        var labels map[string]string
+        if err := json.Unmarshal(data, &labels); err != nil {
+            return fmt.Errorf("failed to parse locale %s: %v", file, err)
+        }
+
+        lang := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".json"))
+        lm.mutex.Lock()
+        if lang == DefaultLanguage {
+            // Overrides for the built-in English text
+            merged := make(map[string]string, len(englishLabels))
+            for k, v := range englishLabels {
+                merged[k] = v
+            }
+            for k, v := range labels {
+                merged[k] = v
+            }
+            labels = merged
+        }
+        lm.locales[lang] = labels
+        lm.mutex.Unlock()
+    }
+
+    return nil
+}
+
+// Translate returns the label for key in lang, falling back to English and
+// then to the key itself. Args are applied with fmt.Sprintf.
+func (lm *LanguageManager) Translate(lang, key string, args ...interface{}) string {
+    lm.mutex.RLock()
+    text, ok := lm.locales[normalizeLanguage(lang)][key]
+    if !ok {
+        text, ok = lm.locales[DefaultLanguage][key]
+    }
+    lm.mutex.RUnlock()
+
+    if !ok {
+        text = key
+    }
+    if len(args) > 0 {
+        return fmt.Sprintf(text, args...)
+    }
+    return text
+}
+
+// normalizeLanguage reduces tags like "es-ES" to their base language
+func normalizeLanguage(lang string) string {
+    lang = strings.ToLower(strings.TrimSpace(lang))
+    if idx := strings.IndexAny(lang, "-_"); idx > 0 {
+        lang = lang[:idx]
+    }
+    if lang == "" {
+        return DefaultLanguage
+    }
+    return lang
+}
+
+// tr translates a key using the shared language manager
+func tr(lang, key string, args ...interface{}) string {
+    return Languages().Translate(lang, key, args...)
+}
+
+// defaultLanguage returns the configured language for channel posts
+func defaultLanguage() string {
+    if cfg != nil && cfg.DefaultLanguage != "" {
+        return cfg.DefaultLanguage
+    }
+    return DefaultLanguage
+}
+
+// userLanguage returns a user's preferred language, or the default if unset
+func userLanguage(userID string) string {
+    lang, err := GetUserLanguage(userID)
+    if err != nil || lang == "" {
+        return defaultLanguage()
+    }
+    return lang
+}
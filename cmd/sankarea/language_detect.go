@@ -0,0 +1,132 @@
+// cmd/sankarea/language_detect.go
+package main
+
+import (
+    "strings"
+    "unicode"
+)
+
+// minDetectWords is the fewest stopword hits needed to trust a detection
+const minDetectWords = 2
+
+// languageStopwords lists very common words that identify Latin-script languages
+var languageStopwords = map[string][]string{
+    "en": {"the", "and", "of", "to", "in", "is", "that", "for", "with", "on", "was", "are", "from", "this", "by"},
+    "es": {"el", "la", "de", "que", "y", "en", "los", "las", "del", "por", "con", "una", "para", "es", "se"},
+    "fr": {"le", "la", "les", "de", "des", "et", "est", "une", "dans", "du", "pour", "que", "qui", "sur", "pas"},
+    "de": {"der", "die", "das", "und", "ist", "nicht", "mit", "den", "von", "ein", "eine", "auf", "für", "sich", "dem"},
+    "it": {"il", "di", "che", "e", "la", "per", "un", "non", "una", "sono", "della", "gli", "del", "con", "nel"},
+    "pt": {"o", "de", "que", "e", "do", "da", "em", "um", "para", "com", "não", "uma", "os", "no", "dos"},
+    "nl": {"de", "het", "een", "en", "van", "is", "dat", "niet", "op", "te", "voor", "met", "zijn", "ook", "wordt"},
+}
+
+// languageStopwordSets indexes languageStopwords for lookup
+var languageStopwordSets = func() map[string]map[string]bool {
+    sets := make(map[string]map[string]bool, len(languageStopwords))
+    for lang, words := range languageStopwords {
+        set := make(map[string]bool, len(words))
+        for _, w := range words {
+            set[w] = true
+        }
+        sets[lang] = set
+    }
+    return sets
+}()
+
+// detectLanguage guesses the base language of text. Non-Latin scripts are
+// identified by character ranges and Latin-script languages by stopword
+// counts. It returns "" when the text is too short or ambiguous to tell.
+func detectLanguage(text string) string {
+    if lang := detectScript(text); lang != "" {
+        return lang
+    }
+
+    words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+        return !unicode.IsLetter(r) && r != '\''
+    })
+
+    best, bestHits, runnerUp := "", 0, 0
+    for lang, set := range languageStopwordSets {
+        hits := 0
+        for _, w := range words {
+            if set[w] {
+                hits++
+            }
+        }
+        if hits > bestHits {
+            best, bestHits, runnerUp = lang, hits, bestHits
+        } else if hits > runnerUp {
+            runnerUp = hits
+        }
+    }
+
+    if bestHits < minDetectWords || bestHits == runnerUp {
+        return ""
+    }
+    return best
+}
+
+// detectScript identifies languages by writing system when most letters share one
+func detectScript(text string) string {
+    counts := make(map[string]int)
+    letters := 0
+    for _, r := range text {
+        if !unicode.IsLetter(r) {
+            continue
+        }
+        letters++
+        switch {
+        case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+            counts["ja"]++
+        case unicode.Is(unicode.Hangul, r):
+            counts["ko"]++
+        case unicode.Is(unicode.Han, r):
+            counts["zh"]++
+        case unicode.Is(unicode.Cyrillic, r):
+            counts["ru"]++
+        case unicode.Is(unicode.Arabic, r):
+            counts["ar"]++
+        case unicode.Is(unicode.Greek, r):
+            counts["el"]++
+        case unicode.Is(unicode.Hebrew, r):
+            counts["he"]++
+        }
+    }
+    if letters == 0 {
+        return ""
+    }
+
+    // Japanese text mixes kana with Han characters
+    if counts["ja"] > 0 {
+        counts["ja"] += counts["zh"]
+        counts["zh"] = 0
+    }
+    for lang, n := range counts {
+        if n*2 > letters {
+            return lang
+        }
+    }
+    return ""
+}
+
+// articleLanguage returns a source's tagged language, or detects it from the text
+func articleLanguage(sourceLanguage, title, content string) string {
+    if sourceLanguage != "" {
+        return normalizeLanguage(sourceLanguage)
+    }
+    return detectLanguage(title + " " + content)
+}
+
+// languageAllowed reports whether lang is in allowed. An empty allowed list
+// accepts everything, and undetected languages are let through rather than dropped.
+func languageAllowed(lang string, allowed []string) bool {
+    if lang == "" || len(allowed) == 0 {
+        return true
+    }
+    for _, a := range allowed {
+        if normalizeLanguage(a) == lang {
+            return true
+        }
+    }
+    return false
+}
@@ -19,6 +19,18 @@ const (
 	LayoutEmbed
 )
 
+// layoutForStyle maps a configured format style to a post layout
+func layoutForStyle(style string) PostLayout {
+	switch style {
+	case FormatStyleEmbed:
+		return LayoutEmbed
+	case FormatStyleDetailed:
+		return LayoutDetailed
+	default:
+		return LayoutStandard
+	}
+}
+
 // FormatNewsPost formats a news item according to the selected layout
 func FormatNewsPost(s *discordgo.Session, channelID string, source Source, feed *gofeed.Feed, items []*gofeed.Item, layout PostLayout) error {
 	switch layout {
@@ -52,22 +64,26 @@ func formatMinimalNewsPost(s *discordgo.Session, channelID string, source Source
 	// Format each item in a minimal way
 	var lines []string
 	for _, item := range items {
-		if item.PublishedParsed == nil {
-			item.PublishedParsed = &time.Time{}
-		}
+		published := getPublishedTime(item)
 		
 		// Format: • Title (time)
 		line := fmt.Sprintf("• [%s](%s) `%s`", 
 			cleanTitle(item.Title),
 			item.Link,
-			item.PublishedParsed.Format("15:04"))
+			published.Format("15:04"))
+		if source.Paywalled {
+			line += " " + paywallNotice(item.Link)
+		}
+		if episode, ok := episodeFromItem(item); ok && source.Type == SourceTypePodcast {
+			line += " " + episodeLink(episode.AudioURL, episode.Duration)
+		}
 			
 		lines = append(lines, line)
 	}
 	
 	// Combine and send the message
 	message := header + "\n" + strings.Join(lines, "\n")
-	_, err := s.ChannelMessageSend(channelID, message)
+	_, err := sendTextPost(s, channelID, message)
 	return err
 }
 
@@ -86,20 +102,24 @@ func formatStandardNewsPost(s *discordgo.Session, channelID string, source Sourc
 	
 	var lines []string
 	for _, item := range items {
-		if item.PublishedParsed == nil {
-			item.PublishedParsed = &time.Time{}
-		}
+		published := getPublishedTime(item)
 		
 		line := fmt.Sprintf("• [%s](%s) - %s", 
 			cleanTitle(item.Title),
 			item.Link,
-			item.PublishedParsed.Format("Jan 02"))
+			published.Format("Jan 02"))
+		if source.Paywalled {
+			line += " " + paywallNotice(item.Link)
+		}
+		if episode, ok := episodeFromItem(item); ok && source.Type == SourceTypePodcast {
+			line += " " + episodeLink(episode.AudioURL, episode.Duration)
+		}
 			
 		lines = append(lines, line)
 	}
 	
 	message := header + "\n" + strings.Join(lines, "\n")
-	_, err := s.ChannelMessageSend(channelID, message)
+	_, err := sendTextPost(s, channelID, message)
 	return err
 }
 
@@ -137,15 +157,21 @@ func formatEmbedNewsPost(s *discordgo.Session, channelID string, source Source,
 	
 	// Add fields for each item
 	for _, item := range items {
-		if item.PublishedParsed == nil {
-			item.PublishedParsed = &time.Time{}
+		published := getPublishedTime(item)
+		
+		value := fmt.Sprintf("[Read more](%s) • %s", 
+			item.Link, 
+			published.Format("Jan 02, 15:04"))
+		if source.Paywalled {
+			value += " • " + paywallNotice(item.Link)
+		}
+		if episode, ok := episodeFromItem(item); ok && source.Type == SourceTypePodcast {
+			value += " • 🎧 " + episodeValue(episode.AudioURL, episode.Duration)
 		}
 		
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: cleanTitle(item.Title),
-			Value: fmt.Sprintf("[Read more](%s) • %s", 
-				item.Link, 
-				item.PublishedParsed.Format("Jan 02, 15:04")),
+			Name:   cleanTitle(item.Title),
+			Value:  value,
 			Inline: false,
 		})
 	}
@@ -183,16 +209,10 @@ func formatDetailedNewsPost(s *discordgo.Session, channelID string, source Sourc
 		}
 	}
 	
-	_, err := s.ChannelMessageSendEmbed(channelID, headerEmbed)
-	if err != nil {
-		return err
-	}
-	
-	// Send individual embeds for each item
+	// Build an embed for each item, sent together with the header
+	embeds := []*discordgo.MessageEmbed{headerEmbed}
 	for _, item := range items {
-		if item.PublishedParsed == nil {
-			item.PublishedParsed = &time.Time{}
-		}
+		published := getPublishedTime(item)
 		
 		// Create item embed
 		itemEmbed := &discordgo.MessageEmbed{
@@ -203,7 +223,7 @@ func formatDetailedNewsPost(s *discordgo.Session, channelID string, source Sourc
 			Footer: &discordgo.MessageEmbedFooter{
 				Text: fmt.Sprintf("%s • %s", 
 					source.Name, 
-					item.PublishedParsed.Format("Jan 02, 15:04")),
+					published.Format("Jan 02, 15:04")),
 			},
 		}
 		
@@ -215,17 +235,17 @@ func formatDetailedNewsPost(s *discordgo.Session, channelID string, source Sourc
 			}
 		}
 		
-		_, err := s.ChannelMessageSendEmbed(channelID, itemEmbed)
-		if err != nil {
-			Logger().Printf("Failed to send item embed: %v", err)
-			continue
+		if source.Paywalled {
+			applyPaywall("", []*discordgo.MessageEmbed{itemEmbed}, item.Link)
+		}
+		if episode, ok := episodeFromItem(item); ok && source.Type == SourceTypePodcast {
+			applyPodcast("", []*discordgo.MessageEmbed{itemEmbed}, episode.AudioURL, episode.Duration)
 		}
 		
-		// Add a small delay to avoid rate limiting
-		time.Sleep(500 * time.Millisecond)
+		embeds = append(embeds, itemEmbed)
 	}
 	
-	return nil
+	return sendEmbedBatches(s, channelID, embeds)
 }
 
 // Helper function to clean up titles
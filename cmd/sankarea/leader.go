@@ -0,0 +1,185 @@
+// cmd/sankarea/leader.go
+package main
+
+import (
+    "fmt"
+    "os"
+    "sync"
+    "time"
+)
+
+// leaderLockName is the lock row the instances of one bot compete for
+const leaderLockName = "scheduler"
+
+// defaultLeaderTTL is how long a leader keeps the lock without renewing it
+const defaultLeaderTTL = 30 * time.Second
+
+// LeaderConfig controls leader election between instances sharing a database
+type LeaderConfig struct {
+    Enabled    bool   `json:"enabled"`
+    InstanceID string `json:"instance_id"` // default hostname-pid
+    TTLSeconds int    `json:"ttl_seconds"` // lock lifetime without a heartbeat, default 30
+}
+
+// LeaderStatus is an instance's view of the leader lock
+type LeaderStatus struct {
+    Enabled    bool      `json:"enabled"`
+    InstanceID string    `json:"instance_id"`
+    Leader     bool      `json:"leader"`
+    Holder     string    `json:"holder,omitempty"`
+    ExpiresAt  time.Time `json:"expires_at,omitempty"`
+    Since      time.Time `json:"since,omitempty"` // when this instance last became leader or standby
+}
+
+// Role names the instance's part: single instance, leader or standby
+func (s LeaderStatus) Role() string {
+    switch {
+    case !s.Enabled:
+        return "single instance"
+    case s.Leader:
+        return "leader"
+    default:
+        return "standby"
+    }
+}
+
+// LeaderElector holds or waits for the leader lock. Only the leader fetches,
+// posts and runs scheduled jobs; a standby renews its claim on every
+// heartbeat and takes over once the leader's lock expires.
+type LeaderElector struct {
+    db       *Database
+    ttl      time.Duration
+    mutex    sync.RWMutex
+    status   LeaderStatus
+    done     chan struct{}
+    stopOnce sync.Once
+}
+
+// leaderElection is the running elector, read by the dashboard
+var leaderElection *LeaderElector
+
+// defaultInstanceID names this process when instance_id is unset
+func defaultInstanceID() string {
+    host, err := os.Hostname()
+    if err != nil || host == "" {
+        host = "sankarea"
+    }
+    return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// NewLeaderElector creates an elector; with election disabled this instance
+// is always the leader
+func NewLeaderElector(db *Database, config LeaderConfig) *LeaderElector {
+    ttl := time.Duration(config.TTLSeconds) * time.Second
+    if ttl <= 0 {
+        ttl = defaultLeaderTTL
+    }
+    id := config.InstanceID
+    if id == "" {
+        id = defaultInstanceID()
+    }
+    return &LeaderElector{
+        db:  db,
+        ttl: ttl,
+        status: LeaderStatus{
+            Enabled:    config.Enabled,
+            InstanceID: id,
+            Leader:     !config.Enabled,
+            Since:      time.Now(),
+        },
+        done: make(chan struct{}),
+    }
+}
+
+// Start makes a first claim on the lock and then renews it every third of
+// its TTL, so one missed heartbeat doesn't cost the lock
+func (l *LeaderElector) Start() {
+    if !l.status.Enabled {
+        return
+    }
+    l.heartbeat()
+
+    go func() {
+        ticker := time.NewTicker(l.ttl / 3)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ticker.C:
+                l.heartbeat()
+            case <-l.done:
+                return
+            }
+        }
+    }()
+}
+
+// Stop ends the heartbeat and releases the lock so a standby can take over
+// without waiting for it to expire
+func (l *LeaderElector) Stop() {
+    if l == nil || !l.status.Enabled {
+        return
+    }
+    l.stopOnce.Do(func() {
+        close(l.done)
+        if !l.IsLeader() {
+            return
+        }
+        if err := l.db.ReleaseLeaderLock(leaderLockName, l.status.InstanceID); err != nil {
+            Logger().Printf("Failed to release leader lock: %v", err)
+        }
+    })
+}
+
+// heartbeat claims or renews the lock and records the outcome. A failed
+// claim demotes the instance: it can't tell whether another one took over.
+func (l *LeaderElector) heartbeat() {
+    holder, expires, err := l.db.AcquireLeaderLock(leaderLockName, l.status.InstanceID, l.ttl)
+    if err != nil {
+        Logger().Printf("Leader heartbeat failed: %v", err)
+    }
+    leader := err == nil && holder == l.status.InstanceID
+
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    if leader != l.status.Leader {
+        l.status.Since = time.Now()
+        if leader {
+            Logger().Printf("Instance %s is now the leader", l.status.InstanceID)
+        } else {
+            Logger().Printf("Instance %s is on standby; %s holds the leader lock", l.status.InstanceID, holder)
+        }
+    }
+    l.status.Leader = leader
+    l.status.Holder = holder
+    l.status.ExpiresAt = expires
+}
+
+// IsLeader reports whether this instance should fetch and post. A nil
+// elector means election isn't set up, so the instance leads.
+func (l *LeaderElector) IsLeader() bool {
+    if l == nil {
+        return true
+    }
+    l.mutex.RLock()
+    defer l.mutex.RUnlock()
+    return l.status.Leader
+}
+
+// Status returns a snapshot of the leadership state
+func (l *LeaderElector) Status() LeaderStatus {
+    if l == nil {
+        return LeaderStatus{Leader: true}
+    }
+    l.mutex.RLock()
+    defer l.mutex.RUnlock()
+    return l.status
+}
+
+// leaderOnly wraps a scheduled job so it only runs on the leader
+func (b *Bot) leaderOnly(job func()) func() {
+    return func() {
+        if b.leader.IsLeader() {
+            job()
+        }
+    }
+}
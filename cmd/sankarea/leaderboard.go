@@ -0,0 +1,158 @@
+// cmd/sankarea/leaderboard.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// SourceLeaderboardConfig controls the weekly source leaderboard post
+type SourceLeaderboardConfig struct {
+    Enabled   bool   `json:"enabled"`
+    ChannelID string `json:"channel_id"`
+    Schedule  string `json:"schedule"` // cron spec, default Monday 09:00
+    Limit     int    `json:"limit"`    // sources listed, default 10
+}
+
+// LeaderboardEntry is one source's performance over the leaderboard window
+type LeaderboardEntry struct {
+    SourceStat         // name and articles contributed
+    Checked    int     // articles with a fact check result
+    TotalScore float64 // sum of fact check scores
+    High       int
+    Medium     int
+    Low        int
+}
+
+// AverageScore is the mean fact check score, or -1 without checks
+func (e *LeaderboardEntry) AverageScore() float64 {
+    if e.Checked == 0 {
+        return -1
+    }
+    return e.TotalScore / float64(e.Checked)
+}
+
+// buildSourceLeaderboard ranks sources by articles contributed, then by
+// average fact check score
+func buildSourceLeaderboard(articles []*NewsArticle) []*LeaderboardEntry {
+    bySource := make(map[string]*LeaderboardEntry)
+    for _, article := range articles {
+        entry, ok := bySource[article.Source]
+        if !ok {
+            entry = &LeaderboardEntry{SourceStat: SourceStat{Name: article.Source}}
+            bySource[article.Source] = entry
+        }
+        entry.Count++
+
+        result := article.FactCheckResult
+        if result == nil {
+            continue
+        }
+        entry.Checked++
+        entry.TotalScore += result.Score
+        switch result.ReliabilityTier {
+        case TierHigh:
+            entry.High++
+        case TierMedium:
+            entry.Medium++
+        case TierLow:
+            entry.Low++
+        }
+    }
+
+    entries := make([]*LeaderboardEntry, 0, len(bySource))
+    for _, entry := range bySource {
+        entries = append(entries, entry)
+    }
+    sort.Slice(entries, func(i, j int) bool {
+        if entries[i].Count != entries[j].Count {
+            return entries[i].Count > entries[j].Count
+        }
+        if entries[i].AverageScore() != entries[j].AverageScore() {
+            return entries[i].AverageScore() > entries[j].AverageScore()
+        }
+        return entries[i].Name < entries[j].Name
+    })
+    return entries
+}
+
+// formatSourceLeaderboard renders the leaderboard embed; quiet counts the
+// active sources that contributed nothing
+func formatSourceLeaderboard(entries []*LeaderboardEntry, start, end time.Time, limit, quiet int) *discordgo.MessageEmbed {
+    var lines []string
+    for idx, entry := range entries {
+        if idx >= limit {
+            break
+        }
+        score := "unchecked"
+        if avg := entry.AverageScore(); avg >= 0 {
+            score = fmt.Sprintf("trust %.2f", avg)
+        }
+        lines = append(lines, fmt.Sprintf("%d. **%s** · %d articles · %s · ✅ %d ⚠️ %d ❌ %d",
+            idx+1, entry.Name, entry.Count, score, entry.High, entry.Medium, entry.Low))
+    }
+    if len(lines) == 0 {
+        lines = append(lines, "No articles were posted this period.")
+    }
+
+    description := fmt.Sprintf("%s - %s\n\n%s", start.Format("2006-01-02"), end.Format("2006-01-02"), strings.Join(lines, "\n"))
+    embed := &discordgo.MessageEmbed{
+        Title:       "🏆 Source Leaderboard",
+        Description: truncateString(description, MaxEmbedLength),
+        Color:       0xF1C40F,
+        Timestamp:   end.Format(time.RFC3339),
+        Footer: &discordgo.MessageEmbedFooter{
+            Text: "Ranked by articles, then average fact check score. ✅ high ⚠️ medium ❌ low reliability",
+        },
+    }
+    if quiet > 0 {
+        embed.Fields = []*discordgo.MessageEmbedField{{
+            Name:  "Quiet sources",
+            Value: fmt.Sprintf("%d active sources posted nothing", quiet),
+        }}
+    }
+    return embed
+}
+
+// runSourceLeaderboard posts the last week's source leaderboard
+func (b *Bot) runSourceLeaderboard() {
+    conf := b.config.SourceLeaderboard
+    end := time.Now().UTC()
+    start := end.AddDate(0, 0, -7)
+
+    articles, err := b.database.GetArticlesByTimeRange(start, end)
+    if err != nil {
+        b.logger.Error("Source leaderboard failed: %v", err)
+        return
+    }
+    entries := buildSourceLeaderboard(articles)
+
+    quiet := 0
+    if sources, err := LoadSources(); err == nil {
+        posted := make(map[string]bool, len(entries))
+        for _, entry := range entries {
+            posted[entry.Name] = true
+        }
+        for _, source := range filterActiveSources(sources) {
+            if !posted[source.Name] {
+                quiet++
+            }
+        }
+    }
+
+    if err := waitForSlowMode(b.discord, conf.ChannelID); err != nil {
+        b.logger.Error("Failed to post source leaderboard: %v", err)
+        return
+    }
+    embed := formatSourceLeaderboard(entries, start, end, conf.Limit, quiet)
+    if _, err := b.discord.ChannelMessageSendEmbed(conf.ChannelID, embed); err != nil {
+        b.logger.Error("Failed to post source leaderboard: %v", err)
+        return
+    }
+
+    b.logger.Info("Posted source leaderboard for %d sources", len(entries))
+}
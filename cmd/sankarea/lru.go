@@ -0,0 +1,193 @@
+// cmd/sankarea/lru.go
+package main
+
+import (
+    "container/list"
+    "sync"
+    "time"
+)
+
+// Defaults for dedup_cache_size and dedup_cache_ttl_hours
+const (
+    defaultDedupCacheSize     = 5000
+    defaultDedupCacheTTLHours = 48
+)
+
+// lruEntry is one key in an LRUCache
+type lruEntry struct {
+    key     string
+    value   interface{}
+    addedAt time.Time
+}
+
+// LRUCache is a bounded, expiring map for the in-memory "seen" structures, so
+// they stay flat over long uptimes. The least recently used entry is evicted
+// when it is full and entries older than the TTL are treated as absent.
+type LRUCache struct {
+    mu       sync.Mutex
+    name     string
+    maxItems int
+    ttl      time.Duration // 0 never expires
+    order    *list.List    // front is most recently used
+    items    map[string]*list.Element
+    hits     uint64
+    misses   uint64
+}
+
+// DedupCacheStats reports an LRUCache's size and hit rate
+type DedupCacheStats struct {
+    Size     int     `json:"size"`
+    MaxItems int     `json:"max_items"`
+    Hits     uint64  `json:"hits"`
+    Misses   uint64  `json:"misses"`
+    HitRate  float64 `json:"hit_rate"` // 0-1, 0 before the first lookup
+}
+
+// lruCaches registers every cache by name for metrics
+var lruCaches = struct {
+    sync.Mutex
+    byName map[string]*LRUCache
+}{byName: make(map[string]*LRUCache)}
+
+// NewLRUCache creates a cache and registers it under name for metrics; a
+// later cache with the same name replaces the earlier one in the registry
+func NewLRUCache(name string, maxItems int, ttl time.Duration) *LRUCache {
+    if maxItems <= 0 {
+        maxItems = defaultDedupCacheSize
+    }
+    c := &LRUCache{
+        name:     name,
+        maxItems: maxItems,
+        ttl:      ttl,
+        order:    list.New(),
+        items:    make(map[string]*list.Element),
+    }
+
+    lruCaches.Lock()
+    lruCaches.byName[name] = c
+    lruCaches.Unlock()
+    return c
+}
+
+// Get returns the value stored under key and marks it recently used
+func (c *LRUCache) Get(key string) (interface{}, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    elem, ok := c.items[key]
+    if ok && c.expired(elem.Value.(*lruEntry)) {
+        c.remove(elem)
+        ok = false
+    }
+    if !ok {
+        c.misses++
+        return nil, false
+    }
+    c.hits++
+    c.order.MoveToFront(elem)
+    return elem.Value.(*lruEntry).value, true
+}
+
+// Contains reports whether key is present, counting as a lookup
+func (c *LRUCache) Contains(key string) bool {
+    _, ok := c.Get(key)
+    return ok
+}
+
+// Add stores value under key, restarting its TTL and evicting the least
+// recently used entries if the cache is full
+func (c *LRUCache) Add(key string, value interface{}) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if elem, ok := c.items[key]; ok {
+        entry := elem.Value.(*lruEntry)
+        entry.value = value
+        entry.addedAt = time.Now()
+        c.order.MoveToFront(elem)
+        return
+    }
+
+    c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, addedAt: time.Now()})
+    for c.order.Len() > c.maxItems {
+        c.remove(c.order.Back())
+    }
+}
+
+// Remove deletes key if present
+func (c *LRUCache) Remove(key string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if elem, ok := c.items[key]; ok {
+        c.remove(elem)
+    }
+}
+
+// Len returns the number of entries, including any not yet found expired
+func (c *LRUCache) Len() int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.order.Len()
+}
+
+// Stats returns the cache's size and hit rate
+func (c *LRUCache) Stats() DedupCacheStats {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    stats := DedupCacheStats{
+        Size:     c.order.Len(),
+        MaxItems: c.maxItems,
+        Hits:     c.hits,
+        Misses:   c.misses,
+    }
+    if total := c.hits + c.misses; total > 0 {
+        stats.HitRate = float64(c.hits) / float64(total)
+    }
+    return stats
+}
+
+// expired reports whether an entry has outlived the TTL; callers hold c.mu
+func (c *LRUCache) expired(entry *lruEntry) bool {
+    return c.ttl > 0 && time.Since(entry.addedAt) >= c.ttl
+}
+
+// remove drops an element; callers hold c.mu
+func (c *LRUCache) remove(elem *list.Element) {
+    c.order.Remove(elem)
+    delete(c.items, elem.Value.(*lruEntry).key)
+}
+
+// dedupCacheStats returns the stats of every registered cache by name
+func dedupCacheStats() map[string]DedupCacheStats {
+    lruCaches.Lock()
+    names := make([]string, 0, len(lruCaches.byName))
+    caches := make([]*LRUCache, 0, len(lruCaches.byName))
+    for name, c := range lruCaches.byName {
+        names = append(names, name)
+        caches = append(caches, c)
+    }
+    lruCaches.Unlock()
+
+    stats := make(map[string]DedupCacheStats, len(caches))
+    for i, c := range caches {
+        stats[names[i]] = c.Stats()
+    }
+    return stats
+}
+
+// dedupCacheSize returns the configured entry limit for dedup caches
+func dedupCacheSize() int {
+    if cfg != nil && cfg.DedupCacheSize > 0 {
+        return cfg.DedupCacheSize
+    }
+    return defaultDedupCacheSize
+}
+
+// dedupCacheTTL returns how long dedup caches remember an entry
+func dedupCacheTTL() time.Duration {
+    if cfg != nil && cfg.DedupCacheTTLHours > 0 {
+        return time.Duration(cfg.DedupCacheTTLHours) * time.Hour
+    }
+    return defaultDedupCacheTTLHours * time.Hour
+}
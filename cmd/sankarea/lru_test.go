@@ -0,0 +1,114 @@
+// cmd/sankarea/lru_test.go
+package main
+
+import (
+    "fmt"
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+    c := NewLRUCache("test_eviction", 3, 0)
+    c.Add("a", 1)
+    c.Add("b", 2)
+    c.Add("c", 3)
+
+    // Reading "a" makes "b" the least recently used
+    if v, ok := c.Get("a"); !ok || v != 1 {
+        t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+    }
+    c.Add("d", 4)
+    if c.Contains("b") {
+        t.Error("b wasn't evicted")
+    }
+    for _, key := range []string{"a", "c", "d"} {
+        if !c.Contains(key) {
+            t.Errorf("%s was evicted", key)
+        }
+    }
+
+    // Updating a key replaces its value without growing the cache
+    c.Add("c", 30)
+    if v, _ := c.Get("c"); v != 30 {
+        t.Errorf("Get(c) = %v after update, want 30", v)
+    }
+    if c.Len() != 3 {
+        t.Errorf("Len = %d, want 3", c.Len())
+    }
+
+    c.Remove("a")
+    if c.Contains("a") || c.Len() != 2 {
+        t.Errorf("Remove left a: contains %v, len %d", c.Contains("a"), c.Len())
+    }
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+    c := NewLRUCache("test_ttl", 10, 200*time.Millisecond)
+    c.Add("old", true)
+    time.Sleep(250 * time.Millisecond)
+    c.Add("new", true)
+
+    if c.Contains("old") {
+        t.Error("expired entry still found")
+    }
+    if !c.Contains("new") {
+        t.Error("fresh entry not found")
+    }
+    if c.Len() != 1 {
+        t.Errorf("Len = %d, want the expired entry dropped on lookup", c.Len())
+    }
+
+    // Adding again restarts the TTL
+    time.Sleep(120 * time.Millisecond)
+    c.Add("new", true)
+    time.Sleep(120 * time.Millisecond)
+    if !c.Contains("new") {
+        t.Error("re-added entry expired on its old TTL")
+    }
+}
+
+func TestLRUCacheStats(t *testing.T) {
+    c := NewLRUCache("test_stats", 0, 0)
+    if stats := c.Stats(); stats.MaxItems != defaultDedupCacheSize || stats.HitRate != 0 {
+        t.Errorf("new cache stats = %+v", stats)
+    }
+
+    c.Add("a", 1)
+    c.Contains("a")
+    c.Contains("a")
+    c.Contains("a")
+    c.Contains("missing")
+    stats := c.Stats()
+    if stats.Size != 1 || stats.Hits != 3 || stats.Misses != 1 || stats.HitRate != 0.75 {
+        t.Errorf("stats = %+v, want size 1, 3 hits, 1 miss, hit rate 0.75", stats)
+    }
+    if _, ok := dedupCacheStats()["test_stats"]; !ok {
+        t.Error("cache isn't registered for metrics")
+    }
+}
+
+// Run with -race
+func TestLRUCacheConcurrent(t *testing.T) {
+    const max = 50
+    c := NewLRUCache("test_concurrent", max, time.Hour)
+    var wg sync.WaitGroup
+    for n := 0; n < 8; n++ {
+        wg.Add(1)
+        go func(n int) {
+            defer wg.Done()
+            for k := 0; k < 200; k++ {
+                key := fmt.Sprintf("%d-%d", n, k)
+                c.Add(key, k)
+                c.Get(key)
+                if k%3 == 0 {
+                    c.Remove(key)
+                }
+            }
+        }(n)
+    }
+    wg.Wait()
+    if c.Len() > max {
+        t.Errorf("Len = %d, over the %d limit", c.Len(), max)
+    }
+}
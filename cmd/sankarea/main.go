@@ -32,6 +32,74 @@ type BotConfig struct {
     LogToConsole     bool     `json:"log_to_console"`
     Categories       []string `json:"categories"`
     CategoryChannels map[string]string
+    RepostOnEdit     bool `json:"repost_on_edit"` // repost stored items whose content changed
+
+    // SelfTestOnStartup runs the /selftest checks at startup and logs failures
+    SelfTestOnStartup bool `json:"self_test_on_startup"`
+
+    // CommandCooldowns maps a command name to its per-user cooldown in seconds
+    CommandCooldowns map[string]int `json:"command_cooldowns"`
+
+    // CommandPermissions maps a guild ID ("*" for all guilds) to command names
+    // and the role IDs allowed to use them, including their admin actions
+    CommandPermissions map[string]map[string][]string `json:"command_permissions"`
+
+    // Database retention
+    ArticleRetentionDays int    `json:"article_retention_days"`
+    ErrorRetentionDays   int    `json:"error_retention_days"`
+    MessageRetentionDays int    `json:"message_retention_days"` // how long posted message IDs are kept for edits and /cleanup
+    CleanupSchedule      string `json:"cleanup_schedule"`
+    VacuumSchedule       string `json:"vacuum_schedule"`
+
+    // Feed validation
+    FeedValidationSchedule string `json:"feed_validation_schedule"`
+
+    // CSV export
+    CSVExport CSVExportConfig `json:"csv_export"`
+
+    // Duplicate detection
+    ImageDedup ImageDedupConfig `json:"image_dedup"`
+
+    // Breaking news fast path
+    BreakingNews BreakingNewsConfig `json:"breaking_news"`
+
+    // Retries of failed fact checks
+    FactCheckRetry FactCheckRetryConfig `json:"fact_check_retry"`
+
+    // Fact-check workers, independent of feed fetch concurrency
+    FactCheckWorkers int `json:"fact_check_workers"`
+
+    // FactCheckRatePerMinute caps fact checks started per minute, shared by
+    // the workers, retries and /refactcheck; default 30
+    FactCheckRatePerMinute int `json:"fact_check_rate_per_minute"`
+
+    // Weekly source leaderboard post
+    SourceLeaderboard SourceLeaderboardConfig `json:"source_leaderboard"`
+
+    // Digest posted to a channel on a schedule
+    ScheduledDigest ScheduledDigestConfig `json:"scheduled_digest"`
+
+    // MaxArticlesPerCycle caps posts per feed check across all sources;
+    // the rest wait for the next cycle. 0 means no cap.
+    MaxArticlesPerCycle int `json:"max_articles_per_cycle"`
+
+    // Leader election between instances sharing the database
+    Leader LeaderConfig `json:"leader_election"`
+}
+
+// ImageDedupConfig controls duplicate detection by shared lead image
+type ImageDedupConfig struct {
+    Enabled            bool    `json:"enabled"`
+    WindowHours        int     `json:"window_hours"`
+    MinTitleSimilarity float64 `json:"min_title_similarity"` // 0-1 word overlap required alongside the image match
+}
+
+// CSVExportConfig controls the periodic CSV export of stored articles
+type CSVExportConfig struct {
+    Enabled  bool     `json:"enabled"`
+    Dir      string   `json:"dir"`
+    Columns  []string `json:"columns"`
+    Schedule string   `json:"schedule"`
 }
 
 func main() {
@@ -74,6 +142,14 @@ func main() {
 }
 
 // loadConfig loads the bot configuration from file
+// botConfigPath returns the config file path, overridable with BOT_CONFIG_PATH
+func botConfigPath() string {
+    if envConfig := os.Getenv("BOT_CONFIG_PATH"); envConfig != "" {
+        return envConfig
+    }
+    return "config.json"
+}
+
 func loadConfig() (*BotConfig, error) {
     // Try to load from environment first
     token := os.Getenv("DISCORD_BOT_TOKEN")
@@ -82,12 +158,7 @@ func loadConfig() (*BotConfig, error) {
     }
 
     // Load config file
-    configPath := "config.json"
-    if envConfig := os.Getenv("BOT_CONFIG_PATH"); envConfig != "" {
-        configPath = envConfig
-    }
-
-    file, err := os.ReadFile(configPath)
+    file, err := os.ReadFile(botConfigPath())
     if err != nil {
         return nil, fmt.Errorf("failed to read config file: %v", err)
     }
@@ -119,6 +190,60 @@ func loadConfig() (*BotConfig, error) {
     if config.CachePath == "" {
         config.CachePath = "data/cache"
     }
+    if config.ArticleRetentionDays == 0 {
+        config.ArticleRetentionDays = 30
+    }
+    if config.ErrorRetentionDays == 0 {
+        config.ErrorRetentionDays = 14
+    }
+    if config.MessageRetentionDays == 0 {
+        config.MessageRetentionDays = 90
+    }
+    if config.CleanupSchedule == "" {
+        config.CleanupSchedule = "0 3 * * *" // nightly at 03:00
+    }
+    if config.VacuumSchedule == "" {
+        config.VacuumSchedule = "30 3 * * 0" // weekly, after Sunday cleanup
+    }
+    if config.FeedValidationSchedule == "" {
+        config.FeedValidationSchedule = "15 */6 * * *"
+    }
+    if config.ImageDedup.WindowHours <= 0 {
+        config.ImageDedup.WindowHours = 48
+    }
+    if config.ImageDedup.MinTitleSimilarity <= 0 {
+        config.ImageDedup.MinTitleSimilarity = 0.2
+    }
+    if config.BreakingNews.Enabled && len(config.BreakingNews.Rules) == 0 {
+        config.BreakingNews.Rules = defaultBreakingRules
+    }
+    if config.CommandCooldowns == nil {
+        config.CommandCooldowns = defaultCommandCooldowns
+    }
+    if config.CSVExport.Dir == "" {
+        config.CSVExport.Dir = "data/exports"
+    }
+    if len(config.CSVExport.Columns) == 0 {
+        config.CSVExport.Columns = defaultCSVColumns
+    }
+    if err := validateCSVColumns(config.CSVExport.Columns); err != nil {
+        return nil, fmt.Errorf("invalid csv_export.columns: %v", err)
+    }
+    if config.CSVExport.Schedule == "" {
+        config.CSVExport.Schedule = "0 2 * * *" // nightly at 02:00
+    }
+    if config.SourceLeaderboard.Schedule == "" {
+        config.SourceLeaderboard.Schedule = "0 9 * * 1" // Mondays at 09:00
+    }
+    if config.SourceLeaderboard.Limit <= 0 {
+        config.SourceLeaderboard.Limit = 10
+    }
+    if config.ScheduledDigest.Schedule == "" {
+        config.ScheduledDigest.Schedule = "0 8 * * *" // daily at 08:00
+    }
+    if config.ScheduledDigest.Hours <= 0 {
+        config.ScheduledDigest.Hours = 24
+    }
 
     return &config, nil
 }
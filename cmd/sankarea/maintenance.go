@@ -0,0 +1,100 @@
+// cmd/sankarea/maintenance.go
+package main
+
+import (
+    "fmt"
+    "time"
+)
+
+// scheduleMaintenance registers the database cleanup, vacuum, feed validation, fact-check retry, report, digest and housekeeping cron jobs.
+// Jobs that touch the shared database or post run on the leader only.
+func (b *Bot) scheduleMaintenance() error {
+    if _, err := cronManager.AddFunc(b.config.CleanupSchedule, b.leaderOnly(b.runCleanup)); err != nil {
+        return fmt.Errorf("invalid cleanup schedule %q: %v", b.config.CleanupSchedule, err)
+    }
+
+    if _, err := cronManager.AddFunc(b.config.VacuumSchedule, b.leaderOnly(b.runVacuum)); err != nil {
+        return fmt.Errorf("invalid vacuum schedule %q: %v", b.config.VacuumSchedule, err)
+    }
+
+    if _, err := cronManager.AddFunc(b.config.FeedValidationSchedule, b.leaderOnly(b.runFeedValidation)); err != nil {
+        return fmt.Errorf("invalid feed validation schedule %q: %v", b.config.FeedValidationSchedule, err)
+    }
+
+    if _, err := cronManager.AddFunc("@every 5m", b.leaderOnly(b.runFactCheckRetries)); err != nil {
+        return fmt.Errorf("failed to schedule fact check retries: %v", err)
+    }
+
+    if _, err := cronManager.AddFunc("@every 10m", b.cooldowns.Cleanup); err != nil {
+        return fmt.Errorf("failed to schedule cooldown cleanup: %v", err)
+    }
+
+    if _, err := cronManager.AddFunc("@hourly", b.leaderOnly(b.recordAnalytics)); err != nil {
+        return fmt.Errorf("failed to schedule analytics sampling: %v", err)
+    }
+
+    if b.config.CSVExport.Enabled {
+        if _, err := cronManager.AddFunc(b.config.CSVExport.Schedule, b.leaderOnly(b.runCSVExport)); err != nil {
+            return fmt.Errorf("invalid CSV export schedule %q: %v", b.config.CSVExport.Schedule, err)
+        }
+    }
+
+    if b.config.SourceLeaderboard.Enabled {
+        if b.config.SourceLeaderboard.ChannelID == "" {
+            return fmt.Errorf("source_leaderboard.channel_id is required when the leaderboard is enabled")
+        }
+        if _, err := cronManager.AddFunc(b.config.SourceLeaderboard.Schedule, b.leaderOnly(b.runSourceLeaderboard)); err != nil {
+            return fmt.Errorf("invalid source leaderboard schedule %q: %v", b.config.SourceLeaderboard.Schedule, err)
+        }
+    }
+
+    if b.config.ScheduledDigest.Enabled {
+        if b.config.ScheduledDigest.ChannelID == "" {
+            return fmt.Errorf("scheduled_digest.channel_id is required when the scheduled digest is enabled")
+        }
+        if _, err := cronManager.AddFunc(b.config.ScheduledDigest.Schedule, b.leaderOnly(b.runScheduledDigest)); err != nil {
+            return fmt.Errorf("invalid scheduled digest schedule %q: %v", b.config.ScheduledDigest.Schedule, err)
+        }
+    }
+
+    return nil
+}
+
+// runCleanup deletes articles, errors and posted message records older than
+// their retention period
+func (b *Bot) runCleanup() {
+    articleTTL := time.Duration(b.config.ArticleRetentionDays) * 24 * time.Hour
+    errorTTL := time.Duration(b.config.ErrorRetentionDays) * 24 * time.Hour
+    messageTTL := time.Duration(b.config.MessageRetentionDays) * 24 * time.Hour
+
+    articles, err := b.database.CleanOldArticles(articleTTL)
+    if err != nil {
+        b.logger.Error("Article cleanup failed: %v", err)
+    } else {
+        b.logger.Info("Removed %d articles older than %d days", articles, b.config.ArticleRetentionDays)
+    }
+
+    errors, err := b.database.CleanOldErrors(errorTTL)
+    if err != nil {
+        b.logger.Error("Error log cleanup failed: %v", err)
+    } else {
+        b.logger.Info("Removed %d error records older than %d days", errors, b.config.ErrorRetentionDays)
+    }
+
+    messages, err := b.database.CleanOldArticleMessages(messageTTL)
+    if err != nil {
+        b.logger.Error("Posted message cleanup failed: %v", err)
+    } else {
+        b.logger.Info("Forgot %d posted messages older than %d days", messages, b.config.MessageRetentionDays)
+    }
+}
+
+// runVacuum reclaims disk space freed by cleanup
+func (b *Bot) runVacuum() {
+    start := time.Now()
+    if err := b.database.Vacuum(); err != nil {
+        b.logger.Error("Database vacuum failed: %v", err)
+        return
+    }
+    b.logger.Info("Database vacuum completed in %v", time.Since(start).Round(time.Millisecond))
+}
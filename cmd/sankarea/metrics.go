@@ -22,6 +22,10 @@ type Metrics struct {
     CategoryStats map[string]int   `json:"category_stats"`
     SourceStats   map[string]int   `json:"source_stats"`
     Reliability   ReliabilityStats `json:"reliability"`
+
+    // Memory and queue health
+    DedupCaches      map[string]DedupCacheStats `json:"dedup_caches"`       // size and hit rate per in-memory dedup cache
+    FactCheckBacklog int                        `json:"fact_check_backlog"` // articles waiting for a fact-check retry
 }
 
 // ReliabilityStats tracks fact-checking statistics
@@ -98,6 +102,11 @@ func CollectMetrics(ctx context.Context) error {
         UpTime:        time.Since(state.StartupTime),
         CategoryStats: make(map[string]int),
         SourceStats:   make(map[string]int),
+        DedupCaches:   dedupCacheStats(),
+    }
+
+    if backlog, err := factCheckQueue.Backlog(); err == nil {
+        metrics.FactCheckBacklog = len(backlog)
     }
 
     // Collect category and source stats
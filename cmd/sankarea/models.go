@@ -2,17 +2,84 @@
 package main
 
 import (
+    "bytes"
+    _ "embed"
     "fmt"
+    "net/url"
+    "os"
+    "path/filepath"
+    "sync"
     "time"
+
+    "gopkg.in/yaml.v2"
 )
 
 // NewsSource represents a news feed source configuration
 type NewsSource struct {
-    Name      string `json:"name"`
-    URL       string `json:"url"`
-    Category  string `json:"category"`
-    FactCheck bool   `json:"fact_check"`
-    Paused    bool   `json:"paused"`
+    Name      string    `json:"name" yaml:"name"`
+    URL       string    `json:"url" yaml:"url"`
+    Category  string    `json:"category" yaml:"category"`
+    FactCheck bool      `json:"fact_check" yaml:"fact_check"`
+    Paused    bool      `json:"paused" yaml:"paused,omitempty"`
+    Priority  int       `json:"priority,omitempty" yaml:"priority,omitempty"`   // 1 (highest) to 3; 0 means normal
+    Proxy     string    `json:"proxy,omitempty" yaml:"proxy,omitempty"`         // overrides the global proxy_url for this feed
+    Sensitive bool      `json:"sensitive,omitempty" yaml:"sensitive,omitempty"` // always post with a content warning
+    MaxPosts  int       `json:"max_posts,omitempty" yaml:"max_posts,omitempty"` // per-run post cap; 0 uses max_posts_per_source
+    Language  string    `json:"language,omitempty" yaml:"language,omitempty"`   // feed language; detected per article when empty
+    Added     time.Time `json:"added,omitempty" yaml:"added,omitempty"`
+    AddedBy   string    `json:"added_by,omitempty" yaml:"added_by,omitempty"`
+
+    // ForceCategory makes Category authoritative: feed item categories and
+    // the AI categorizer are never consulted for this source
+    ForceCategory bool `json:"force_category,omitempty" yaml:"force_category,omitempty"`
+
+    // Paywalled marks posts with a paywall notice and skips full-text
+    // extraction and summarization, which would only see the paywall
+    Paywalled bool `json:"paywalled,omitempty" yaml:"paywalled,omitempty"`
+
+    // RepostCooldownHours overrides repost_cooldown_hours for this source
+    RepostCooldownHours int `json:"repost_cooldown_hours,omitempty" yaml:"repost_cooldown_hours,omitempty"`
+
+    // Editorial profile: lean (left, center-left, center, center-right, right) and 0-1 trust
+    Bias  string  `json:"bias,omitempty" yaml:"bias,omitempty"`
+    Trust float64 `json:"trust,omitempty" yaml:"trust,omitempty"`
+
+    // Feed authentication
+    Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+    BasicAuthUser string            `json:"basic_auth_user,omitempty" yaml:"basic_auth_user,omitempty"`
+    BasicAuthPass string            `json:"basic_auth_pass,omitempty" yaml:"basic_auth_pass,omitempty"`
+
+    // HTML scraping; type "html" scrapes URL as a web page using Selector
+    Type     string `json:"type,omitempty" yaml:"type,omitempty"`
+    Selector string `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+    // Validation status, maintained by the feed validation job
+    LastValidated time.Time `json:"last_validated,omitempty" yaml:"last_validated,omitempty"`
+    Broken        bool      `json:"broken,omitempty" yaml:"broken,omitempty"` // returned 404/410, needs operator review
+    BrokenReason  string    `json:"broken_reason,omitempty" yaml:"broken_reason,omitempty"`
+}
+
+// redactedValue replaces secrets in API responses
+const redactedValue = "[redacted]"
+
+// Redacted returns a copy of the source with credentials masked for display
+func (s NewsSource) Redacted() NewsSource {
+    if len(s.Headers) > 0 {
+        headers := make(map[string]string, len(s.Headers))
+        for name := range s.Headers {
+            headers[name] = redactedValue
+        }
+        s.Headers = headers
+    }
+    if s.BasicAuthPass != "" {
+        s.BasicAuthPass = redactedValue
+    }
+    if s.Proxy != "" {
+        if u, err := url.Parse(s.Proxy); err == nil {
+            s.Proxy = u.Redacted()
+        }
+    }
+    return s
 }
 
 // NewsArticle represents a processed news article
@@ -29,21 +96,6 @@ type NewsArticle struct {
     FactCheckResult *FactCheckResult `json:"fact_check_result,omitempty"`
 }
 
-// ErrorEvent represents an error occurrence for tracking
-type ErrorEvent struct {
-    Time      time.Time `json:"time"`
-    Component string    `json:"component"`
-    Message   string    `json:"message"`
-    Severity  string    `json:"severity"`
-}
-
-// ErrorBuffer maintains a circular buffer of recent errors
-type ErrorBuffer struct {
-    Events    []*ErrorEvent
-    MaxSize   int
-    Position  int
-}
-
 // BotConfig represents the bot's configuration
 type BotConfig struct {
     Token              string   `json:"token"`
@@ -67,75 +119,145 @@ const (
     CategoryWorld     = "World"
 )
 
-// NewErrorBuffer creates a new error buffer with specified size
-func NewErrorBuffer(size int) *ErrorBuffer {
-    return &ErrorBuffer{
-        Events:   make([]*ErrorEvent, size),
-        MaxSize:  size,
-        Position: 0,
+// sourcesMutex serializes reads and writes of the sources file
+var sourcesMutex sync.Mutex
+
+// sourcesFile is the on-disk layout of the sources file
+type sourcesFile struct {
+    Sources []NewsSource `yaml:"sources"`
+}
+
+// sourcesPath returns the configured sources file location
+func sourcesPath() string {
+    if cfg != nil && cfg.SourcesPath != "" {
+        return cfg.SourcesPath
     }
+    return "config/sources.yml"
 }
 
-// Add adds a new error event to the buffer
-func (eb *ErrorBuffer) Add(event *ErrorEvent) {
-    eb.Events[eb.Position] = event
-    eb.Position = (eb.Position + 1) % eb.MaxSize
+// LoadSources loads news sources from the sources file. On first run, when
+// the file is missing or empty, it is seeded with the default sources.
+func LoadSources() ([]NewsSource, error) {
+    sourcesMutex.Lock()
+    defer sourcesMutex.Unlock()
+    return loadSourcesLocked()
 }
 
-// GetErrors returns all stored errors in chronological order
-func (eb *ErrorBuffer) GetErrors() []*ErrorEvent {
-    result := make([]*ErrorEvent, 0, eb.MaxSize)
-    
-    // Add events from position to end
-    for i := eb.Position; i < eb.MaxSize; i++ {
-        if eb.Events[i] != nil {
-            result = append(result, eb.Events[i])
-        }
+// SaveSources atomically replaces the sources file
+func SaveSources(sources []NewsSource) error {
+    sourcesMutex.Lock()
+    defer sourcesMutex.Unlock()
+    return saveSourcesLocked(sources, "")
+}
+
+// UpdateSources loads the sources, applies fn and saves the result while
+// holding the lock, so concurrent edits from commands and the dashboard
+// can't overwrite each other. Nothing is saved if fn returns an error.
+// The actor (a Discord user ID, or empty) is recorded in the config history.
+func UpdateSources(actor string, fn func([]NewsSource) ([]NewsSource, error)) error {
+    sourcesMutex.Lock()
+    defer sourcesMutex.Unlock()
+
+    sources, err := loadSourcesLocked()
+    if err != nil {
+        return err
     }
-    
-    // Add events from start to position
-    for i := 0; i < eb.Position; i++ {
-        if eb.Events[i] != nil {
-            result = append(result, eb.Events[i])
-        }
+    sources, err = fn(sources)
+    if err != nil {
+        return err
     }
-    
-    return result
+    return saveSourcesLocked(sources, actor)
 }
 
-// GetRecentErrors returns the n most recent errors
-func (eb *ErrorBuffer) GetRecentErrors(n int) []*ErrorEvent {
-    allErrors := eb.GetErrors()
-    if len(allErrors) <= n {
-        return allErrors
+func loadSourcesLocked() ([]NewsSource, error) {
+    data, err := os.ReadFile(sourcesPath())
+    if err != nil && !os.IsNotExist(err) {
+        return nil, fmt.Errorf("failed to read sources file: %v", err)
+    }
+    if len(bytes.TrimSpace(data)) == 0 {
+        return seedSourcesLocked()
+    }
+
+    var file sourcesFile
+    if err := yaml.Unmarshal(data, &file); err != nil {
+        return nil, fmt.Errorf("failed to parse sources file: %v", err)
     }
-    return allErrors[len(allErrors)-n:]
+    return file.Sources, nil
 }
 
-// ClearErrors removes all stored errors
-func (eb *ErrorBuffer) ClearErrors() {
-    eb.Events = make([]*ErrorEvent, eb.MaxSize)
-    eb.Position = 0
+func saveSourcesLocked(sources []NewsSource, actor string) error {
+    data, err := yaml.Marshal(sourcesFile{Sources: sources})
+    if err != nil {
+        return fmt.Errorf("failed to marshal sources: %v", err)
+    }
+    if err := writeFileAtomic(sourcesPath(), data, 0644); err != nil {
+        return fmt.Errorf("failed to write sources file: %v", err)
+    }
+    if err := recordSnapshot(SnapshotKindSources, actor, sources); err != nil {
+        Logger().Printf("Failed to record sources history: %v", err)
+    }
+    auditTrail.Record(&AuditEvent{
+        Actor:   actor,
+        Type:    AuditTypeSources,
+        Action:  "sources saved",
+        Target:  sourcesPath(),
+        Details: fmt.Sprintf("%d sources", len(sources)),
+    })
+    return nil
 }
 
-// LoadSources loads news sources from configuration
-func LoadSources() ([]NewsSource, error) {
-    // TODO: Implement loading from configuration file or database
-    // For now, return default sources
-    return []NewsSource{
-        {
-            Name:      "Tech News",
-            URL:       "https://example.com/tech.rss",
-            Category:  CategoryTechnology,
-            FactCheck: true,
-        },
-        {
-            Name:      "World News",
-            URL:       "https://example.com/world.rss",
-            Category:  CategoryWorld,
-            FactCheck: true,
-        },
-    }, nil
+// embeddedDefaultSources seeds sources.yml on first run
+//go:embed default_sources.yml
+var embeddedDefaultSources []byte
+
+// defaultSources returns the embedded default source set
+func defaultSources() []NewsSource {
+    var file sourcesFile
+    if err := yaml.Unmarshal(embeddedDefaultSources, &file); err != nil {
+        // The file ships with the binary, so this is a build problem
+        Logger().Printf("Failed to parse embedded default sources: %v", err)
+        return nil
+    }
+    return file.Sources
+}
+
+// seedSourcesLocked handles a missing or empty sources file: it writes the
+// default sources unless seeding is disabled, in which case there are none
+func seedSourcesLocked() ([]NewsSource, error) {
+    if cfg != nil && cfg.DisableSourceSeeding {
+        return []NewsSource{}, nil
+    }
+
+    sources := defaultSources()
+    if err := os.MkdirAll(filepath.Dir(sourcesPath()), 0755); err != nil {
+        return nil, fmt.Errorf("failed to create sources directory: %v", err)
+    }
+    if err := saveSourcesLocked(sources, ""); err != nil {
+        return nil, fmt.Errorf("failed to seed default sources: %v", err)
+    }
+    Logger().Printf("Seeded %s with %d default sources", sourcesPath(), len(sources))
+    return sources, nil
+}
+
+// sourceTier returns a source's priority tier, treating unset values as normal
+func sourceTier(priority int) int {
+    if priority < SourcePriorityHigh || priority > SourcePriorityLow {
+        return SourcePriorityNormal
+    }
+    return priority
+}
+
+// sourcePriorities maps source names to their priority tier
+func sourcePriorities() map[string]int {
+    priorities := make(map[string]int)
+    sources, err := LoadSources()
+    if err != nil {
+        return priorities
+    }
+    for _, source := range sources {
+        priorities[source.Name] = sourceTier(source.Priority)
+    }
+    return priorities
 }
 
 // ValidateConfig checks if the configuration is valid
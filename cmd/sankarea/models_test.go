@@ -0,0 +1,169 @@
+// cmd/sankarea/models_test.go
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sync"
+    "testing"
+
+    "gopkg.in/yaml.v2"
+)
+
+// useTempSources points the sources file and the relative data directory at
+// a fresh temporary directory for the duration of the test
+func useTempSources(t *testing.T) {
+    t.Helper()
+    dir := t.TempDir()
+    wd, err := os.Getwd()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := os.Chdir(dir); err != nil {
+        t.Fatal(err)
+    }
+    saved := cfg
+    cfg = &Config{SourcesPath: filepath.Join(dir, "sources.yml"), DisableSourceSeeding: true}
+    t.Cleanup(func() {
+        cfg = saved
+        os.Chdir(wd)
+    })
+}
+
+// sourceNamesOnDisk parses the sources file as YAML and returns the names in it
+func sourceNamesOnDisk(t *testing.T) map[string]bool {
+    t.Helper()
+    data, err := os.ReadFile(cfg.SourcesPath)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var file sourcesFile
+    if err := yaml.Unmarshal(data, &file); err != nil {
+        t.Fatalf("sources file isn't valid YAML after concurrent updates: %v", err)
+    }
+    names := make(map[string]bool, len(file.Sources))
+    for _, source := range file.Sources {
+        if names[source.Name] {
+            t.Errorf("%s is in the sources file twice", source.Name)
+        }
+        names[source.Name] = true
+    }
+    return names
+}
+
+// noTempFiles fails if an atomic write left its temp file behind
+func noTempFiles(t *testing.T, dir string) {
+    t.Helper()
+    leftovers, err := filepath.Glob(filepath.Join(dir, "*.tmp*"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(leftovers) > 0 {
+        t.Errorf("temp files left behind: %v", leftovers)
+    }
+}
+
+// Run with -race: concurrent edits must each see the previous edit's result
+func TestUpdateSourcesConcurrent(t *testing.T) {
+    useTempSources(t)
+    const writers = 20
+    var existing []NewsSource
+    for n := 0; n < writers; n++ {
+        existing = append(existing, NewsSource{
+            Name: fmt.Sprintf("old-%d", n),
+            URL:  fmt.Sprintf("https://example.com/old/%d/feed.xml", n),
+        })
+    }
+    if err := SaveSources(existing); err != nil {
+        t.Fatal(err)
+    }
+
+    // Adds and removes interleave, each removing a source an add didn't create
+    var wg sync.WaitGroup
+    errs := make(chan error, 2*writers)
+    for n := 0; n < writers; n++ {
+        wg.Add(2)
+        go func(n int) {
+            defer wg.Done()
+            errs <- UpdateSources(fmt.Sprintf("adder%d", n), func(sources []NewsSource) ([]NewsSource, error) {
+                return append(sources, NewsSource{
+                    Name: fmt.Sprintf("source-%d", n),
+                    URL:  fmt.Sprintf("https://example.com/%d/feed.xml", n),
+                }), nil
+            })
+        }(n)
+        go func(n int) {
+            defer wg.Done()
+            errs <- UpdateSources(fmt.Sprintf("remover%d", n), func(sources []NewsSource) ([]NewsSource, error) {
+                name := fmt.Sprintf("old-%d", n)
+                for idx, source := range sources {
+                    if source.Name == name {
+                        return append(sources[:idx:idx], sources[idx+1:]...), nil
+                    }
+                }
+                return nil, errSourceNotFound
+            })
+        }(n)
+    }
+    wg.Wait()
+    close(errs)
+    for err := range errs {
+        if err != nil {
+            t.Fatalf("UpdateSources: %v", err)
+        }
+    }
+
+    names := sourceNamesOnDisk(t)
+    if len(names) != writers {
+        t.Errorf("got %d sources after the concurrent updates, want %d", len(names), writers)
+    }
+    for n := 0; n < writers; n++ {
+        if name := fmt.Sprintf("source-%d", n); !names[name] {
+            t.Errorf("update adding %s was lost", name)
+        }
+        if name := fmt.Sprintf("old-%d", n); names[name] {
+            t.Errorf("update removing %s was lost", name)
+        }
+    }
+    noTempFiles(t, filepath.Dir(cfg.SourcesPath))
+}
+
+// Run with -race: concurrent saves must each leave a complete config file
+func TestSaveConfigByConcurrent(t *testing.T) {
+    useTempSources(t)
+    path := filepath.Join(filepath.Dir(cfg.SourcesPath), "config.json")
+    cfg.WebhookSecret = "s3cret"
+
+    const writers = 10
+    var wg sync.WaitGroup
+    errs := make(chan error, writers)
+    for n := 0; n < writers; n++ {
+        wg.Add(1)
+        go func(n int) {
+            defer wg.Done()
+            errs <- SaveConfigBy(path, fmt.Sprintf("user%d", n))
+        }(n)
+    }
+    wg.Wait()
+    close(errs)
+    for err := range errs {
+        if err != nil {
+            t.Fatalf("SaveConfigBy: %v", err)
+        }
+    }
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var saved Config
+    if err := json.Unmarshal(data, &saved); err != nil {
+        t.Fatalf("config file isn't valid JSON after concurrent saves: %v", err)
+    }
+    if saved.SourcesPath != cfg.SourcesPath || saved.WebhookSecret != cfg.WebhookSecret {
+        t.Errorf("saved config = %+v, want the current one", saved)
+    }
+    noTempFiles(t, filepath.Dir(path))
+}
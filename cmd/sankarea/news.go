@@ -4,41 +4,18 @@ package main
 import (
     "context"
     "fmt"
-    "net/http"
     "sort"
     "strings"
-    "sync"
     "time"
 
     "github.com/bwmarrin/discordgo"
-    "github.com/mmcdole/gofeed"
 )
 
-// NewsProcessor handles news feed processing and Discord integration
-type NewsProcessor struct {
-    client    *http.Client
-    parser    *gofeed.Parser
-    mutex     sync.RWMutex
-    cache     map[string]time.Time
-    semaphore chan struct{}
-}
-
-// NewNewsProcessor creates a new NewsProcessor instance
-func NewNewsProcessor() *NewsProcessor {
-    return &NewsProcessor{
-        client: &http.Client{
-            Timeout: DefaultTimeout,
-        },
-        parser:    gofeed.NewParser(),
-        cache:     make(map[string]time.Time),
-        semaphore: make(chan struct{}, MaxConcurrentFeeds),
-    }
-}
-
-// ProcessNews fetches and processes news from all sources
+// ProcessNews runs a standalone fetch cycle: it fetches all active sources,
+// posts new articles to each guild's channels and updates the state
 func (np *NewsProcessor) ProcessNews(ctx context.Context, s *discordgo.Session) error {
     startTime := time.Now()
-    
+
     // Load active sources
     sources, err := LoadSources()
     if err != nil {
@@ -51,110 +28,34 @@ func (np *NewsProcessor) ProcessNews(ctx context.Context, s *discordgo.Session)
         return NewNewsError(ErrNewsFetch, "no active sources configured", nil)
     }
 
-    // Create error channel for collecting errors
-    errCh := make(chan error, len(activeSources))
-    articleCh := make(chan *NewsArticle, len(activeSources)*10)
-    var wg sync.WaitGroup
-
-    // Process each source
-    for _, source := range activeSources {
-        wg.Add(1)
-        go func(src NewsSource) {
-            defer wg.Done()
-            defer RecoverFromPanic(fmt.Sprintf("news-fetch-%s", src.Name))
-
-            if err := np.processFeed(ctx, src, articleCh); err != nil {
-                errCh <- err
-                updateSourceError(src.Name, err)
-            }
-        }(source)
-    }
-
-    // Wait for all fetches to complete
-    go func() {
-        wg.Wait()
-        close(articleCh)
-        close(errCh)
-    }()
-
-    // Collect and process articles
-    var articles []*NewsArticle
-    for article := range articleCh {
-        articles = append(articles, article)
-    }
+    // Fetch every source; a failing feed doesn't stop the others
+    articles, fetchErr := np.ProcessFeeds(ctx, activeSources, false)
 
     // Sort and filter articles
     articles = np.processArticles(articles)
 
-    // Post articles to Discord
-    if err := np.postArticles(s, articles); err != nil {
-        return NewNewsError(ErrNewsParser, "failed to post articles", err)
-    }
-
-    // Collect errors
-    var errors []error
-    for err := range errCh {
-        errors = append(errors, err)
+    // Post articles to Discord unless only the digest should post
+    if !digestOnly() {
+        if err := np.postArticles(s, articles); err != nil {
+            return NewNewsError(ErrNewsParser, "failed to post articles", err)
+        }
     }
 
     // Update state after processing
     if err := UpdateState(func(s *State) {
         s.LastFetchTime = time.Now()
-        s.ArticleCount += len(articles)
         s.LastInterval = int(time.Since(startTime).Minutes())
-        if len(errors) > 0 {
-            s.ErrorCount += len(errors)
-            s.LastError = errors[0].Error()
+        if fetchErr != nil {
+            s.ErrorCount++
+            s.LastError = fetchErr.Error()
             s.LastErrorTime = time.Now()
         }
     }); err != nil {
-        Logger().Printf("Failed to update state after news fetch: %v", err)
-    }
-
-    if len(errors) > 0 {
-        return fmt.Errorf("encountered %d errors while fetching news", len(errors))
-    }
-
-    return nil
-}
-
-// processFeed handles fetching and processing a single feed
-func (np *NewsProcessor) processFeed(ctx context.Context, source NewsSource, articleCh chan<- *NewsArticle) error {
-    // Acquire semaphore
-    select {
-    case np.semaphore <- struct{}{}:
-        defer func() { <-np.semaphore }()
-    case <-ctx.Done():
-        return ctx.Err()
-    }
-
-    // Check cache to avoid duplicate processing
-    np.mutex.RLock()
-    lastFetch, exists := np.cache[source.URL]
-    np.mutex.RUnlock()
-
-    if exists && time.Since(lastFetch) < time.Duration(cfg.MinFetchInterval)*time.Minute {
-        return nil
+        np.logger.Error("Failed to update state after news fetch: %v", err)
     }
 
-    // Set user agent
-    np.parser.UserAgent = cfg.UserAgentString
-
-    // Fetch and parse feed
-    feed, err := np.parser.ParseURL(source.URL)
-    if err != nil {
-        return NewNewsError(ErrNewsFetch, fmt.Sprintf("failed to parse feed for %s", source.Name), err)
-    }
-
-    // Update cache
-    np.mutex.Lock()
-    np.cache[source.URL] = time.Now()
-    np.mutex.Unlock()
-
-    // Process feed items
-    for _, item := range feed.Items {
-        article := convertFeedItemToArticle(item, source)
-        articleCh <- article
+    if fetchErr != nil {
+        return NewNewsError(ErrNewsFetch, "failed to fetch some sources", fetchErr)
     }
 
     return nil
@@ -175,11 +76,13 @@ func (np *NewsProcessor) processArticles(articles []*NewsArticle) []*NewsArticle
     for _, article := range articles {
         // Skip old articles
         if article.PublishedAt.Before(cutoff) {
+            traceDecision(article.URL, StageDedup, "too old", "published more than 24 hours ago")
             continue
         }
 
         // Skip duplicates (based on title similarity)
         if seen[normalizeTitle(article.Title)] {
+            traceDecision(article.URL, StageDedup, "skipped", "same title as another article in this batch")
             continue
         }
         seen[normalizeTitle(article.Title)] = true
@@ -209,11 +112,17 @@ func (np *NewsProcessor) postArticles(s *discordgo.Session, articles []*NewsArti
                 continue
             }
 
+            embeds := make([]*discordgo.MessageEmbed, 0, len(catArticles))
             for _, article := range catArticles {
-                embed := createNewsEmbed(article)
-                if _, err := s.ChannelMessageSendEmbed(channelID, embed); err != nil {
-                    Logger().Printf("Error posting article to channel %s: %v", channelID, err)
-                }
+                embeds = append(embeds, createNewsEmbed(article))
+            }
+            outcome := "posted"
+            if err := sendEmbedBatches(s, channelID, embeds); err != nil {
+                Logger().Printf("Error posting articles to channel %s: %v", channelID, err)
+                outcome = "post failed"
+            }
+            for _, article := range catArticles {
+                traceDecision(article.URL, StageRouting, outcome, fmt.Sprintf("<#%s> in guild %s", channelID, guild.ID))
             }
         }
     }
@@ -233,42 +142,21 @@ func filterActiveSources(sources []NewsSource) []NewsSource {
     return active
 }
 
-func convertFeedItemToArticle(item *gofeed.Item, source NewsSource) *NewsArticle {
-    publishedAt := time.Now()
-    if item.PublishedParsed != nil {
-        publishedAt = *item.PublishedParsed
-    }
-
-    article := &NewsArticle{
-        ID:          generateArticleID(item),
-        Title:       item.Title,
-        URL:         item.Link,
-        Source:      source.Name,
-        PublishedAt: publishedAt,
-        FetchedAt:   time.Now(),
-        Summary:     item.Description,
-        Category:    source.Category,
-        Tags:        item.Categories,
-    }
-
-    if item.Image != nil {
-        article.ImageURL = item.Image.URL
-    }
-
-    return article
-}
-
 func createNewsEmbed(article *NewsArticle) *discordgo.MessageEmbed {
+    timestamp, fetchedNote := embedTimes(article.PublishedAt, article.FetchedAt)
     embed := &discordgo.MessageEmbed{
         Title:       article.Title,
         URL:         article.URL,
-        Description: truncateString(article.Summary, MaxEmbedLength),
-        Timestamp:   article.PublishedAt.Format(time.RFC3339),
+        Description: truncateString(postedText(article), MaxEmbedLength),
+        Timestamp:   timestamp,
         Color:       getCategoryColor(article.Category),
         Footer: &discordgo.MessageEmbedFooter{
             Text: fmt.Sprintf("Source: %s", article.Source),
         },
     }
+    if fetchedNote != "" {
+        embed.Footer.Text += " • " + fetchedNote
+    }
 
     if article.ImageURL != "" {
         embed.Image = &discordgo.MessageEmbedImage{
@@ -287,13 +175,6 @@ func createNewsEmbed(article *NewsArticle) *discordgo.MessageEmbed {
     return embed
 }
 
-func generateArticleID(item *gofeed.Item) string {
-    if item.GUID != "" {
-        return item.GUID
-    }
-    return fmt.Sprintf("%s-%s", normalizeTitle(item.Title), time.Now().Format("20060102"))
-}
-
 func normalizeTitle(title string) string {
     return strings.ToLower(strings.Join(strings.Fields(title), " "))
 }
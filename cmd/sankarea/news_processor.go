@@ -2,9 +2,11 @@
 package main
 
 import (
+    "bytes"
     "context"
     "crypto/sha256"
     "encoding/hex"
+    "errors"
     "fmt"
     "html"
     "io"
@@ -29,43 +31,77 @@ type NewsArticle struct {
     FetchedAt      time.Time       `json:"fetched_at"`
     Citations      []string        `json:"citations,omitempty"`
     FactCheckResult *FactCheckResult `json:"fact_check_result,omitempty"`
+    ContentHash    string           `json:"content_hash,omitempty"`
+    Language       string           `json:"language,omitempty"` // tagged or detected base language, "" if unknown
+    Edited         bool             `json:"edited,omitempty"`   // content changed since it was first posted
+    WordCount      int              `json:"word_count,omitempty"`     // words in the extracted page text, 0 if not extracted
+    AudioURL       string           `json:"audio_url,omitempty"`      // podcast episode enclosure
+    AudioDuration  string           `json:"audio_duration,omitempty"` // podcast episode length, "H:MM:SS" or "M:SS"
 }
 
-// NewsProcessor handles the fetching and processing of RSS feeds
+// NewsProcessor handles the fetching and processing of RSS feeds. It runs
+// either inside a Bot, which supplies the database, fact checker and config,
+// or standalone with a nil bot, in which case articles aren't stored.
 type NewsProcessor struct {
     parser      *gofeed.Parser
     client      *http.Client
     bot         *Bot
+    logger      *Logger
     maxArticles int
     userAgent   string
     timeout     time.Duration
-    mu          sync.RWMutex
+    minInterval time.Duration // skip feeds fetched more recently than this
+    semaphore   chan struct{}
+    lastFetch   *LRUCache // feed URL -> time.Time of the last fetch
 }
 
-// NewNewsProcessor creates a new NewsProcessor instance
+// ErrFetchedRecently is returned for a feed skipped because it was fetched
+// less than the processor's minimum interval ago
+var ErrFetchedRecently = errors.New("feed was fetched too recently")
+
+// NewNewsProcessor creates a new NewsProcessor instance; bot may be nil
 func NewNewsProcessor(bot *Bot) *NewsProcessor {
-    return &NewsProcessor{
-        parser: gofeed.NewParser(),
-        client: &http.Client{
-            Timeout: 30 * time.Second,
-            Transport: &http.Transport{
-                MaxIdleConns:        100,
-                IdleConnTimeout:     90 * time.Second,
-                DisableCompression:  true,
-                MaxConnsPerHost:     10,
-                DisableKeepAlives:   false,
-                ForceAttemptHTTP2:   true,
-            },
-        },
+    np := &NewsProcessor{
+        parser:      gofeed.NewParser(),
+        client:      GetHTTPClient(),
         bot:         bot,
         maxArticles: 5,
         userAgent:   "Sankarea News Bot/1.0",
         timeout:     30 * time.Second,
+        minInterval: time.Minute,
+        semaphore:   make(chan struct{}, MaxConcurrentFeeds),
+        lastFetch:   NewLRUCache("feed_last_fetch", dedupCacheSize(), dedupCacheTTL()),
+    }
+    if bot != nil {
+        np.logger = bot.logger
+    } else {
+        np.logger = Logger()
     }
+    if cfg != nil && cfg.UserAgentString != "" {
+        np.userAgent = cfg.UserAgentString
+    }
+    return np
 }
 
-// ProcessFeeds fetches and processes all enabled feeds
-func (np *NewsProcessor) ProcessFeeds(ctx context.Context, sources []NewsSource) ([]*NewsArticle, error) {
+// database returns the article store, or nil when running standalone
+func (np *NewsProcessor) database() *Database {
+    if np.bot == nil {
+        return nil
+    }
+    return np.bot.database
+}
+
+// ProcessFeeds fetches and processes all enabled feeds. Feeds fetched less
+// than the minimum interval ago are skipped unless force is set, as it is
+// for manual refreshes and fetch boosts.
+func (np *NewsProcessor) ProcessFeeds(ctx context.Context, sources []NewsSource, force bool) ([]*NewsArticle, error) {
+    return np.ProcessFeedsNotify(ctx, sources, force, nil)
+}
+
+// ProcessFeedsNotify is ProcessFeeds that also hands each feed's articles to
+// onFeed as soon as that feed is processed. onFeed runs on the feed's fetch
+// goroutine, so it may be called concurrently.
+func (np *NewsProcessor) ProcessFeedsNotify(ctx context.Context, sources []NewsSource, force bool, onFeed func([]*NewsArticle)) ([]*NewsArticle, error) {
     var (
         articles = make([]*NewsArticle, 0)
         errors   = make([]error, 0)
@@ -73,30 +109,51 @@ func (np *NewsProcessor) ProcessFeeds(ctx context.Context, sources []NewsSource)
         wg       sync.WaitGroup
     )
 
-    // Create semaphore for concurrent processing
-    sem := make(chan struct{}, 5) // limit to 5 concurrent requests
+    if BeginFetchCycle() {
+        np.logger.Warning("Previous fetch cycle was interrupted; fetching stale sources first")
+    }
+    defer EndFetchCycle()
+
+    // Sources that have gone longest without a successful fetch go first, so
+    // a cycle cut short by a restart catches up on them
+    names := make([]string, len(sources))
+    for i, source := range sources {
+        names[i] = source.Name
+    }
 
-    // Process each source
-    for _, source := range sources {
+    for _, idx := range staleFirst(names) {
+        source := sources[idx]
         if source.Paused {
-            np.bot.logger.Info("Skipping paused source: %s", source.Name)
+            np.logger.Info("Skipping paused source: %s", source.Name)
             continue
         }
 
         wg.Add(1)
         go func(src NewsSource) {
             defer wg.Done()
-            sem <- struct{}{} // acquire semaphore
-            defer func() { <-sem }() // release semaphore
+            defer RecoverFromPanic(fmt.Sprintf("news-fetch-%s", src.Name))
+
+            // Limit concurrent requests
+            select {
+            case np.semaphore <- struct{}{}:
+                defer func() { <-np.semaphore }()
+            case <-ctx.Done():
+                return
+            }
 
             // Process the feed with timeout
             feedCtx, cancel := context.WithTimeout(ctx, np.timeout)
             defer cancel()
 
-            feedArticles, err := np.processFeed(feedCtx, src)
+            feedArticles, err := np.processFeed(feedCtx, src, force)
+            if err == nil && onFeed != nil && len(feedArticles) > 0 {
+                onFeed(feedArticles)
+            }
             mu.Lock()
-            if err != nil {
-                np.bot.logger.Error("Failed to process %s: %v", src.Name, err)
+            if err == ErrFetchedRecently {
+                np.logger.Info("Skipping %s: fetched less than %s ago", src.Name, np.minInterval)
+            } else if err != nil {
+                np.logger.Error("Failed to process %s: %v", src.Name, err)
                 errors = append(errors, fmt.Errorf("error processing %s: %v", src.Name, err))
             } else {
                 articles = append(articles, feedArticles...)
@@ -121,22 +178,38 @@ func (np *NewsProcessor) ProcessFeeds(ctx context.Context, sources []NewsSource)
 }
 
 // processFeed fetches and processes a single feed
-func (np *NewsProcessor) processFeed(ctx context.Context, source NewsSource) ([]*NewsArticle, error) {
+func (np *NewsProcessor) processFeed(ctx context.Context, source NewsSource, force bool) ([]*NewsArticle, error) {
+    // Skip feeds that were just fetched, e.g. by an overlapping cycle
+    if last, fetched := np.lastFetch.Get(source.URL); !force && fetched && time.Since(last.(time.Time)) < np.minInterval {
+        return nil, ErrFetchedRecently
+    }
+    fetchStart := time.Now()
+
     // Create request with context and timeout
     req, err := http.NewRequestWithContext(ctx, "GET", source.URL, nil)
     if err != nil {
-        np.logFeedError(source, err)
+        np.logFeedError(source, fetchStart, err)
         return nil, fmt.Errorf("failed to create request: %v", err)
     }
 
     // Set headers
     req.Header.Set("User-Agent", np.userAgent)
-    req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml")
+    if source.Type == SourceTypeHTML {
+        req.Header.Set("Accept", "text/html, application/xhtml+xml")
+    } else {
+        req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml")
+    }
+    setSourceAuth(req, source.Headers, source.BasicAuthUser, source.BasicAuthPass)
 
-    // Perform request
-    resp, err := np.client.Do(req)
+    // Perform request, through the source's proxy if it has one
+    client, err := httpClientFor(source.Proxy)
+    if err != nil {
+        np.logFeedError(source, fetchStart, err)
+        return nil, fmt.Errorf("failed to create client: %v", err)
+    }
+    resp, err := client.Do(req)
     if err != nil {
-        np.logFeedError(source, err)
+        np.logFeedError(source, fetchStart, err)
         return nil, fmt.Errorf("failed to fetch feed: %v", err)
     }
     defer resp.Body.Close()
@@ -144,24 +217,34 @@ func (np *NewsProcessor) processFeed(ctx context.Context, source NewsSource) ([]
     // Check response status
     if resp.StatusCode != http.StatusOK {
         err := fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-        np.logFeedError(source, err)
+        np.logFeedError(source, fetchStart, err)
         return nil, err
     }
 
     // Read response with timeout
     bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024)) // 10MB limit
     if err != nil {
-        np.logFeedError(source, err)
+        np.logFeedError(source, fetchStart, err)
         return nil, fmt.Errorf("failed to read response: %v", err)
     }
 
-    // Parse feed
-    feed, err := np.parser.ParseString(string(bodyBytes))
+    // Parse feed, or scrape the page for HTML sources
+    var feed *gofeed.Feed
+    if source.Type == SourceTypeHTML {
+        feed, err = parseHTMLFeed(bytes.NewReader(bodyBytes), source.URL, source.Selector)
+    } else {
+        feed, err = np.parser.ParseString(string(bodyBytes))
+    }
     if err != nil {
-        np.logFeedError(source, err)
+        np.logFeedError(source, fetchStart, err)
         return nil, fmt.Errorf("failed to parse feed: %v", err)
     }
 
+    np.lastFetch.Add(source.URL, time.Now())
+    updateSourceMetrics(source.Name, time.Since(fetchStart), true)
+
+    checkFeedDates(source.Name, feed.Items)
+
     // Process articles
     var articles []*NewsArticle
     seenURLs := make(map[string]bool)
@@ -172,57 +255,115 @@ func (np *NewsProcessor) processFeed(ctx context.Context, source NewsSource) ([]
             break
         }
 
-        // Generate article ID
-        articleID := np.generateArticleID(item)
-
-        // Skip if article exists in database
-        exists, err := np.articleExists(articleID)
-        if err != nil {
-            np.bot.logger.Error("Failed to check article existence: %v", err)
-            continue
-        }
-        if exists {
-            continue
+        // Podcast episodes are posted with their audio instead of as text
+        var episode podcastEpisode
+        if source.Type == SourceTypePodcast {
+            episode = preparePodcastItem(feed, item)
         }
 
         // Skip duplicate URLs in current batch
-        if seenURLs[item.Link] {
+        if seenURLs[item.Link] || skipUndated(item) {
             continue
         }
         seenURLs[item.Link] = true
 
         // Create article
         article := &NewsArticle{
-            ID:          articleID,
+            ID:          np.generateArticleID(item),
             Title:       html.UnescapeString(item.Title),
             Content:     np.processContent(item),
             URL:         item.Link,
             Source:      source.Name,
             Category:    source.Category,
-            PublishedAt: np.getPublishDate(item),
+            PublishedAt: getPublishedTime(item),
             FetchedAt:   time.Now().UTC(),
         }
+        article.ContentHash = contentHash(article.Title, article.Content)
+        article.AudioURL, article.AudioDuration = episode.AudioURL, episode.Duration
+
+        article.Language = articleLanguage(source.Language, article.Title, article.Content)
+        if cfg != nil && cfg.EnableMultiLanguage && !languageAllowed(article.Language, cfg.SupportedLanguages) {
+            np.logger.Debug("Skipping %s article in unsupported language %s", source.Name, article.Language)
+            startDecisionTrace(article, source)
+            traceDecision(article.URL, StageLanguage, "skipped", fmt.Sprintf("%s is not in supported_languages", article.Language))
+            continue
+        }
+
+        // Items we have already stored are only reposted if they were really edited
+        existing, err := np.findExistingArticle(article)
+        if err != nil {
+            np.logger.Error("Failed to check article existence: %v", err)
+            continue
+        }
+        if existing != nil && !np.shouldRepost(existing, article) {
+            // Keep the trace of the run that first handled it
+            if !hasDecisionTrace(article.URL) {
+                startDecisionTrace(article, source)
+                traceDecision(article.URL, StageDedup, "already stored", "unchanged since it was stored; not posted again")
+            }
+            continue
+        }
+        startDecisionTrace(article, source)
+        if existing != nil {
+            article.ID = existing.ID
+            traceDecision(article.URL, StageDedup, "edited", "content changed since it was stored")
+        } else {
+            traceDecision(article.URL, StageDedup, "new", "")
+        }
+
+        // Normalize the category before anything routes on it; edits keep
+        // the category already chosen
+        if category := normalizeCategory(existingCategory(existing)); category != "" {
+            article.Category = category
+        } else {
+            article.Category = np.categorizeArticle(ctx, item, source, article)
+        }
 
         // Extract image
         if imageURL := np.extractImage(item); imageURL != "" {
             article.ImageURL = imageURL
         }
 
-        // Perform fact checking if enabled
-        if source.FactCheck {
-            if result, err := np.bot.factChecker.Check(article); err != nil {
-                np.bot.logger.Error("Fact check failed for %s: %v", article.Title, err)
-            } else {
-                article.FactCheckResult = result
-            }
+        // Replace the feed's body with the full page text; the content hash
+        // stays on the feed body so refetches don't look like edits. Paywalled
+        // pages only hold the paywall and podcast links are episode pages or
+        // the audio itself, so both keep the feed body.
+        if storeFullContent() && article.URL != "" && !source.Paywalled && source.Type != SourceTypePodcast {
+            np.extractFullContent(ctx, article)
         }
 
         // Extract citations
         article.Citations = np.extractCitations(item)
 
         // Save article to database
-        if err := np.bot.database.SaveArticle(article); err != nil {
-            np.bot.logger.Error("Failed to save article: %v", err)
+        if db := np.database(); db != nil {
+            if err := db.SaveArticle(article); err != nil {
+                np.logger.Error("Failed to save article: %v", err)
+                continue
+            }
+        }
+
+        // Fact check in the background; the result is saved and shown by
+        // editing the post once it arrives
+        if source.FactCheck && np.bot != nil && np.bot.factChecker != nil && np.bot.factCheckPool != nil {
+            np.bot.factCheckPool.Submit(article)
+            traceDecision(article.URL, StageFactCheck, "queued", "")
+        }
+
+        // The global trust floor stores articles without posting them
+        if reason := trustFloorReason(source, article); reason != "" {
+            np.logger.Info("Not posting %s: %s", article.Title, reason)
+            traceDecision(article.URL, StageTrustFloor, "held", reason)
+            if np.bot != nil {
+                noteHeldArticle(np.bot.discord, article, reason)
+            }
+            continue
+        }
+
+        // Edited articles are updated in place where they were posted; they
+        // are only posted again if every original message is gone
+        if article.Edited && np.bot.updatePostedArticle(article) {
+            traceDecision(article.URL, StageRouting, "updated in place", "existing posts were edited")
             continue
         }
 
@@ -231,17 +372,74 @@ func (np *NewsProcessor) processFeed(ctx context.Context, source NewsSource) ([]
 
     // Update feed stats
     np.updateFeedStats(source, len(articles), nil)
+    if outage := RecordSourceSuccess(source.Name, len(articles)); outage != nil && np.bot != nil {
+        noteSourceRecovered(np.bot.discord, source.Name, outage)
+    }
 
     return articles, nil
 }
 
-// articleExists checks if an article already exists in the database
-func (np *NewsProcessor) articleExists(id string) (bool, error) {
-    article, err := np.bot.database.GetArticle(id)
+// findExistingArticle looks up a stored article by ID, falling back to URL
+func (np *NewsProcessor) findExistingArticle(article *NewsArticle) (*NewsArticle, error) {
+    db := np.database()
+    if db == nil {
+        return nil, nil
+    }
+    existing, err := db.GetArticle(article.ID)
+    if err != nil || existing != nil || article.URL == "" {
+        return existing, err
+    }
+    return db.GetArticleByURL(article.URL)
+}
+
+// shouldRepost decides what to do with an item that is already stored. Items
+// with a newer date but unchanged content are updated silently. Edited content
+// is processed again and marked Edited when its post can be edited in place or
+// RepostOnEdit is enabled; otherwise it is also stored silently.
+func (np *NewsProcessor) shouldRepost(existing, article *NewsArticle) bool {
+    if article.ContentHash != existing.ContentHash {
+        if np.bot.config.RepostOnEdit || np.bot.hasPostedMessages(existing.ID) {
+            article.Edited = true
+            return true
+        }
+    } else if !article.PublishedAt.After(existing.PublishedAt) {
+        return false
+    }
+
+    article.ID = existing.ID
+    article.FactCheckResult = existing.FactCheckResult
+    if storeFullContent() {
+        article.Content = existing.Content // keep the extracted full text
+        article.WordCount = existing.WordCount
+    }
+    if err := np.database().SaveArticle(article); err != nil {
+        np.logger.Error("Failed to update article %s: %v", article.ID, err)
+    }
+    return false
+}
+
+// extractFullContent fetches the article page and stores its full text,
+// keeping the feed body if extraction fails
+func (np *NewsProcessor) extractFullContent(ctx context.Context, article *NewsArticle) {
+    extracted, err := NewArticleExtractor().ExtractContext(ctx, article.URL)
     if err != nil {
-        return false, err
+        np.logger.Debug("Keeping feed content for %s: %v", article.URL, err)
+        return
+    }
+    if len(extracted.Content) > len(article.Content) {
+        article.Content = extracted.Content
+        article.WordCount = extracted.WordCount
+    }
+    if article.ImageURL == "" {
+        article.ImageURL = extracted.ImageURL
     }
-    return article != nil, nil
+}
+
+// contentHash fingerprints an article's title and body, ignoring case and whitespace changes
+func contentHash(title, content string) string {
+    normalized := strings.ToLower(strings.Join(strings.Fields(title+" "+content), " "))
+    hash := sha256.Sum256([]byte(normalized))
+    return hex.EncodeToString(hash[:])
 }
 
 // generateArticleID creates a unique ID for an article
@@ -269,14 +467,8 @@ func (np *NewsProcessor) generateArticleID(item *gofeed.Item) string {
 
 // processContent processes the article content
 func (np *NewsProcessor) processContent(item *gofeed.Item) string {
-    var content string
-    
-    // Prefer full content if available
-    if item.Content != "" {
-        content = item.Content
-    } else if item.Description != "" {
-        content = item.Description
-    } else {
+    content := itemBody(item)
+    if content == "" {
         return item.Title // Fallback to title if no content available
     }
 
@@ -293,6 +485,35 @@ func (np *NewsProcessor) processContent(item *gofeed.Item) string {
     return content
 }
 
+// itemBody returns the longest body available for a feed item. WordPress and
+// similar feeds often put the full article in content:encoded while the
+// description only holds a teaser, and gofeed doesn't always map it to Content.
+func itemBody(item *gofeed.Item) string {
+    candidates := []string{item.Content, item.Description}
+
+    if ext, ok := item.Extensions["content"]; ok {
+        for _, e := range ext["encoded"] {
+            candidates = append(candidates, e.Value)
+        }
+    }
+    if ext, ok := item.Extensions["dc"]; ok {
+        for _, e := range ext["description"] {
+            candidates = append(candidates, e.Value)
+        }
+    }
+    if item.DublinCoreExt != nil {
+        candidates = append(candidates, item.DublinCoreExt.Description...)
+    }
+
+    var body string
+    for _, c := range candidates {
+        if len(strings.TrimSpace(c)) > len(body) {
+            body = strings.TrimSpace(c)
+        }
+    }
+    return body
+}
+
 // extractImage finds the best image for an article
 func (np *NewsProcessor) extractImage(item *gofeed.Item) string {
     // Check feed item image
@@ -343,8 +564,15 @@ func (np *NewsProcessor) extractCitations(item *gofeed.Item) []string {
     return citations
 }
 
-// getPublishDate gets the publish date of an article
-func (np *NewsProcessor) getPublishDate(item *gofeed.Item) time.Time {
+// Ways to handle feed items that have neither a published nor an updated date
+const (
+    UndatedItemsNow  = "now"  // date them at fetch time
+    UndatedItemsSkip = "skip" // drop them
+)
+
+// getPublishedTime returns when an item was published, falling back to its
+// updated time and then to now for feeds that don't date their items
+func getPublishedTime(item *gofeed.Item) time.Time {
     if item.PublishedParsed != nil {
         return item.PublishedParsed.UTC()
     }
@@ -354,18 +582,20 @@ func (np *NewsProcessor) getPublishDate(item *gofeed.Item) time.Time {
     return time.Now().UTC()
 }
 
-// logFeedError logs a feed processing error
-func (np *NewsProcessor) logFeedError(source NewsSource, err error) {
-    event := &ErrorEvent{
-        Component: "NewsProcessor",
-        Message:   fmt.Sprintf("Error processing feed %s: %v", source.Name, err),
-        Severity:  "ERROR",
-        Time:     time.Now().UTC(),
-    }
-    
-    if err := np.bot.database.LogError(event); err != nil {
-        np.bot.logger.Error("Failed to log feed error: %v", err)
+// skipUndated reports whether an item without any date should be dropped
+func skipUndated(item *gofeed.Item) bool {
+    if item.PublishedParsed != nil || item.UpdatedParsed != nil {
+        return false
     }
+    return cfg != nil && cfg.UndatedItems == UndatedItemsSkip
+}
+
+// logFeedError logs a feed processing error and counts it against the
+// source's uptime
+func (np *NewsProcessor) logFeedError(source NewsSource, fetchStart time.Time, err error) {
+    HandleError(feedErrorPrefix+source.Name, err, feedErrorComponent, ErrorSeverityMedium)
+    RecordSourceFailure(source.Name, err)
+    updateSourceMetrics(source.Name, time.Since(fetchStart), false)
 }
 
 // updateFeedStats updates the feed statistics
@@ -378,11 +608,15 @@ func (np *NewsProcessor) updateFeedStats(source NewsSource, articleCount int, er
     } else {
         source.ErrorCount = 0
         source.LastError = ""
-        np.bot.logger.Info("Successfully processed %d articles from %s", articleCount, source.Name)
+        np.logger.Info("Successfully processed %d articles from %s", articleCount, source.Name)
     }
     
-    if err := np.bot.database.SaveSource(&source); err != nil {
-        np.bot.logger.Error("Failed to update feed stats: %v", err)
+    db := np.database()
+    if db == nil {
+        return
+    }
+    if err := db.SaveSource(&source); err != nil {
+        np.logger.Error("Failed to update feed stats: %v", err)
     }
 }
 
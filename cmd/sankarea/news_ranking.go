@@ -0,0 +1,118 @@
+// cmd/sankarea/news_ranking.go
+package main
+
+import (
+    "math"
+    "sort"
+    "strings"
+    "time"
+)
+
+// Limits for /news with a query
+const (
+    newsQueryWindow     = 7 * 24 * time.Hour
+    newsRecencyHalfLife = 24 * time.Hour
+    newsResultCount     = 10
+)
+
+// defaultNewsRelevanceWeight is used when news_relevance_weight is unset
+const defaultNewsRelevanceWeight = 0.5
+
+// Relevance signals, summing to 1: how well the query matches, the source's
+// priority tier and the article's trust score
+const (
+    newsMatchWeight    = 0.6
+    newsPriorityWeight = 0.2
+    newsTrustWeight    = 0.2
+)
+
+// newsRelevanceWeight returns the share of relevance, against recency, in
+// the /news ranking
+func newsRelevanceWeight() float64 {
+    if cfg != nil && cfg.NewsRelevanceWeight > 0 {
+        return cfg.NewsRelevanceWeight
+    }
+    return defaultNewsRelevanceWeight
+}
+
+// queryMatch scores how well an article matches query terms, 0-1: a term in
+// the title counts twice as much as a term only in the body
+func queryMatch(article *NewsArticle, terms []string) float64 {
+    if len(terms) == 0 {
+        return 0
+    }
+    title := strings.ToLower(article.Title)
+    body := strings.ToLower(article.Content)
+    hits := 0.0
+    for _, term := range terms {
+        switch {
+        case strings.Contains(title, term):
+            hits += 2
+        case strings.Contains(body, term):
+            hits++
+        }
+    }
+    return hits / float64(2*len(terms))
+}
+
+// priorityScore maps a priority tier to 0-1, highest tier first
+func priorityScore(tier int) float64 {
+    return float64(SourcePriorityLow-sourceTier(tier)) / float64(SourcePriorityLow-SourcePriorityHigh)
+}
+
+// recencyScore halves for every newsRecencyHalfLife since publication
+func recencyScore(published, now time.Time) float64 {
+    age := now.Sub(published)
+    if age < 0 {
+        age = 0
+    }
+    return math.Pow(0.5, float64(age)/float64(newsRecencyHalfLife))
+}
+
+// filterArticlesByCategory keeps the articles in one category
+func filterArticlesByCategory(articles []*NewsArticle, category string) []*NewsArticle {
+    var kept []*NewsArticle
+    for _, article := range articles {
+        if strings.EqualFold(article.Category, category) {
+            kept = append(kept, article)
+        }
+    }
+    return kept
+}
+
+// rankNewsByQuery keeps the articles that match the query and orders them
+// by a blend of recency and relevance, weighted by news_relevance_weight
+func rankNewsByQuery(articles []*NewsArticle, query string, priorities map[string]int, now time.Time) []*NewsArticle {
+    terms := strings.Fields(strings.ToLower(query))
+    weight := newsRelevanceWeight()
+
+    type scored struct {
+        article *NewsArticle
+        score   float64
+    }
+    var ranked []scored
+    for _, article := range articles {
+        match := queryMatch(article, terms)
+        if match == 0 {
+            continue
+        }
+        relevance := newsMatchWeight*match +
+            newsPriorityWeight*priorityScore(priorities[article.Source]) +
+            newsTrustWeight*articleTrust(article)
+        score := (1-weight)*recencyScore(article.PublishedAt, now) + weight*relevance
+        ranked = append(ranked, scored{article, score})
+    }
+
+    sort.SliceStable(ranked, func(i, j int) bool {
+        if ranked[i].score != ranked[j].score {
+            return ranked[i].score > ranked[j].score
+        }
+        return ranked[i].article.PublishedAt.After(ranked[j].article.PublishedAt)
+    })
+
+    result := make([]*NewsArticle, 0, len(ranked))
+    for _, r := range ranked {
+        result = append(result, r.article)
+    }
+    return result
+}
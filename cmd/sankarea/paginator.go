@@ -0,0 +1,149 @@
+// cmd/sankarea/paginator.go
+package main
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Paginated messages stop responding to their buttons after this long
+const paginatorTTL = 15 * time.Minute
+
+// pagePrefix starts the custom ID of every page button: page:<id>:<index>
+const pagePrefix = "page:"
+
+// paginatedMessage is a list of embeds flipped through with buttons
+type paginatedMessage struct {
+    pages   []*discordgo.MessageEmbed
+    ownerID string // only the user who ran the command can turn pages
+    created time.Time
+}
+
+// paginators holds the live paginated messages by ID
+var paginators = struct {
+    sync.Mutex
+    byID map[string]*paginatedMessage
+    next uint64
+}{byID: make(map[string]*paginatedMessage)}
+
+// registerPaginator stores pages and returns their ID, dropping expired ones
+func registerPaginator(pages []*discordgo.MessageEmbed, ownerID string) string {
+    paginators.Lock()
+    defer paginators.Unlock()
+
+    for id, p := range paginators.byID {
+        if time.Since(p.created) > paginatorTTL {
+            delete(paginators.byID, id)
+        }
+    }
+    paginators.next++
+    id := strconv.FormatUint(paginators.next, 36)
+    paginators.byID[id] = &paginatedMessage{pages: pages, ownerID: ownerID, created: time.Now()}
+    return id
+}
+
+// lookupPaginator returns a live paginated message, or nil if it expired
+func lookupPaginator(id string) *paginatedMessage {
+    paginators.Lock()
+    defer paginators.Unlock()
+
+    p, ok := paginators.byID[id]
+    if !ok || time.Since(p.created) > paginatorTTL {
+        return nil
+    }
+    return p
+}
+
+// pageButtons renders the previous/next buttons and page counter
+func pageButtons(id string, page, total int) []discordgo.MessageComponent {
+    return []discordgo.MessageComponent{
+        discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+            discordgo.Button{
+                Label:    "◀ Prev",
+                Style:    discordgo.SecondaryButton,
+                CustomID: fmt.Sprintf("%s%s:%d", pagePrefix, id, page-1),
+                Disabled: page == 0,
+            },
+            discordgo.Button{
+                Label:    fmt.Sprintf("%d / %d", page+1, total),
+                Style:    discordgo.SecondaryButton,
+                CustomID: fmt.Sprintf("%s%s:counter", pagePrefix, id),
+                Disabled: true,
+            },
+            discordgo.Button{
+                Label:    "Next ▶",
+                Style:    discordgo.SecondaryButton,
+                CustomID: fmt.Sprintf("%s%s:%d", pagePrefix, id, page+1),
+                Disabled: page >= total-1,
+            },
+        }},
+    }
+}
+
+// editResponsePaginated shows the first page as the deferred response, with
+// buttons when there is more than one page
+func editResponsePaginated(s *discordgo.Session, i *discordgo.InteractionCreate, pages []*discordgo.MessageEmbed) error {
+    if len(pages) == 0 {
+        return fmt.Errorf("no pages to show")
+    }
+    embeds := []*discordgo.MessageEmbed{pages[0]}
+    edit := &discordgo.WebhookEdit{Embeds: &embeds}
+    if len(pages) > 1 {
+        components := pageButtons(registerPaginator(pages, interactionUserID(i)), 0, len(pages))
+        edit.Components = &components
+    }
+    _, err := s.InteractionResponseEdit(i.Interaction, edit)
+    return err
+}
+
+// handlePageButton turns the page of a paginated message
+func (b *Bot) handlePageButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    parts := strings.Split(strings.TrimPrefix(i.MessageComponentData().CustomID, pagePrefix), ":")
+    if len(parts) != 2 {
+        return fmt.Errorf("malformed page button %q", i.MessageComponentData().CustomID)
+    }
+
+    p := lookupPaginator(parts[0])
+    if p == nil {
+        // Expired: leave the current page but drop the dead buttons
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseUpdateMessage,
+            Data: &discordgo.InteractionResponseData{
+                Embeds:     i.Message.Embeds,
+                Components: []discordgo.MessageComponent{},
+            },
+        })
+    }
+    if p.ownerID != interactionUserID(i) {
+        respondWithError(s, i, "Only the person who ran the command can turn its pages")
+        return nil
+    }
+
+    page, err := strconv.Atoi(parts[1])
+    if err != nil || page < 0 || page >= len(p.pages) {
+        return fmt.Errorf("invalid page %q", parts[1])
+    }
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseUpdateMessage,
+        Data: &discordgo.InteractionResponseData{
+            Embeds:     []*discordgo.MessageEmbed{p.pages[page]},
+            Components: pageButtons(parts[0], page, len(p.pages)),
+        },
+    })
+}
+
+// interactionUserID returns who triggered an interaction, in a guild or a DM
+func interactionUserID(i *discordgo.InteractionCreate) string {
+    if i.Member != nil && i.Member.User != nil {
+        return i.Member.User.ID
+    }
+    if i.User != nil {
+        return i.User.ID
+    }
+    return ""
+}
@@ -0,0 +1,73 @@
+// cmd/sankarea/paywall.go
+package main
+
+import (
+    "fmt"
+    "net/url"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Archive services for the paywall fallback link (paywall_archive)
+const (
+    PaywallArchiveNone  = "none"
+    PaywallArchiveOrg   = "archive.org"
+    PaywallArchiveToday = "archive.today"
+)
+
+// paywallIndicatorLabel marks posts from paywalled sources
+const paywallIndicatorLabel = "🔒 paywalled"
+
+// paywallArchiveURL returns the configured archive's copy of a link, or ""
+// when the fallback is off
+func paywallArchiveURL(link string) string {
+    if cfg == nil || link == "" {
+        return ""
+    }
+    switch cfg.PaywallArchive {
+    case PaywallArchiveOrg:
+        return "https://web.archive.org/web/" + link
+    case PaywallArchiveToday:
+        return "https://archive.ph/newest/" + url.PathEscape(link)
+    default:
+        return ""
+    }
+}
+
+// paywallNotice is the inline indicator for a paywalled link, followed by
+// the archive link when one is configured
+func paywallNotice(link string) string {
+    if archive := paywallArchiveURL(link); archive != "" {
+        return fmt.Sprintf("%s · [archived copy](<%s>)", paywallIndicatorLabel, archive)
+    }
+    return paywallIndicatorLabel
+}
+
+// applyPaywall marks formatted news from a paywalled source: text posts get
+// the notice on their own line and embeds get it as a field
+func applyPaywall(content string, embeds []*discordgo.MessageEmbed, link string) (string, []*discordgo.MessageEmbed) {
+    value := "A subscription may be needed to read this article"
+    if archive := paywallArchiveURL(link); archive != "" {
+        value = fmt.Sprintf("[Archived copy](%s)", archive)
+    }
+    for _, embed := range embeds {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:  "🔒 Paywalled",
+            Value: value,
+        })
+    }
+    if content != "" {
+        content += "\n" + paywallNotice(link)
+    }
+    return content, embeds
+}
+
+// sourcePaywalled reports whether the named source is flagged paywalled
+func (s *Scheduler) sourcePaywalled(name string) bool {
+    for _, src := range s.GetSources() {
+        if src.Name == name {
+            return src.Paywalled
+        }
+    }
+    return false
+}
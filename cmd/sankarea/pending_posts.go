@@ -0,0 +1,153 @@
+// cmd/sankarea/pending_posts.go
+package main
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+// inFlightArticles tracks articles fetched in the current run that haven't
+// been posted yet, so a shutdown can save them for the next start
+type inFlightArticles struct {
+    mu       sync.Mutex
+    articles map[string]*NewsArticle
+    order    []string
+}
+
+// add starts tracking articles
+func (f *inFlightArticles) add(articles []*NewsArticle) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.articles == nil {
+        f.articles = make(map[string]*NewsArticle)
+    }
+    for _, article := range articles {
+        if _, ok := f.articles[article.ID]; !ok {
+            f.order = append(f.order, article.ID)
+        }
+        f.articles[article.ID] = article
+    }
+}
+
+// done stops tracking an article once it was posted or given up on
+func (f *inFlightArticles) done(article *NewsArticle) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    delete(f.articles, article.ID)
+    if len(f.articles) == 0 {
+        f.order = nil
+    }
+}
+
+// snapshot returns the tracked articles in the order they were added
+func (f *inFlightArticles) snapshot() []*NewsArticle {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    articles := make([]*NewsArticle, 0, len(f.articles))
+    for _, id := range f.order {
+        if article, ok := f.articles[id]; ok {
+            articles = append(articles, article)
+        }
+    }
+    return articles
+}
+
+// stopping reports whether Stop was called
+func (s *Scheduler) stopping() bool {
+    select {
+    case <-s.done:
+        return true
+    default:
+        return false
+    }
+}
+
+// postingContext returns a context that ends when the scheduler stops, so a
+// post waiting for a slow mode slot doesn't hold up shutdown
+func (s *Scheduler) postingContext() (context.Context, context.CancelFunc) {
+    ctx, cancel := context.WithCancel(context.Background())
+    go func() {
+        select {
+        case <-s.done:
+            cancel()
+        case <-ctx.Done():
+        }
+    }()
+    return ctx, cancel
+}
+
+// deferArticle queues an article this cycle couldn't post for the next one
+func (s *Scheduler) deferArticle(article *NewsArticle, reason string) {
+    if err := s.bot.database.SavePendingPost(article.ID, article.FetchedAt); err != nil {
+        s.bot.logger.Error("Failed to defer %s: %v", article.URL, err)
+        return
+    }
+    traceDecision(article.URL, StageRouting, "deferred", reason)
+}
+
+// DrainInFlight waits, until ctx ends, for the running feed check to stop
+// posting, then queues every article it didn't get to in the database. It
+// returns how many articles were queued.
+func (s *Scheduler) DrainInFlight(ctx context.Context) (int, error) {
+    released := make(chan struct{})
+    go func() {
+        s.mutex.Lock()
+        close(released)
+        s.mutex.Unlock()
+    }()
+    select {
+    case <-released:
+    case <-ctx.Done():
+        s.bot.logger.Warn("Feed check still running at shutdown; saving its unposted articles anyway")
+    }
+
+    articles := s.inFlight.snapshot()
+    if len(articles) == 0 {
+        return 0, nil
+    }
+
+    drained := 0
+    now := time.Now()
+    for _, article := range articles {
+        if ctx.Err() != nil {
+            return drained, ctx.Err()
+        }
+        // Articles are normally stored at fetch; store any that weren't
+        existing, err := s.bot.database.GetArticle(article.ID)
+        if err != nil {
+            return drained, err
+        }
+        if existing == nil {
+            if err := s.bot.database.SaveArticle(article); err != nil {
+                return drained, err
+            }
+        }
+        if err := s.bot.database.SavePendingPost(article.ID, now); err != nil {
+            return drained, err
+        }
+        traceDecision(article.URL, StageRouting, "deferred", "shutdown before posting; queued for the next start")
+        drained++
+    }
+    return drained, nil
+}
+
+// takePendingArticles empties the pending post queue, which holds articles
+// left by a shutdown or deferred by the cycle cap, and returns them for this
+// cycle. Anything the cycle doesn't post is queued again.
+func (s *Scheduler) takePendingArticles() []*NewsArticle {
+    articles, err := s.bot.database.GetPendingPosts()
+    if err != nil {
+        s.bot.logger.Error("Failed to load pending posts: %v", err)
+        return nil
+    }
+    if len(articles) == 0 {
+        return nil
+    }
+    if err := s.bot.database.ClearPendingPosts(); err != nil {
+        s.bot.logger.Error("Failed to clear pending posts: %v", err)
+        return nil
+    }
+    s.bot.logger.Info("Picked up %d queued articles from earlier cycles", len(articles))
+    return articles
+}
@@ -80,8 +80,9 @@ func CheckCommandPermissions(s *discordgo.Session, i *discordgo.InteractionCreat
 	return true
 }
 
-// AuditLog logs admin actions to the audit log channel
+// AuditLog records admin actions in the audit trail and posts them to the audit log channel
 func AuditLog(s *discordgo.Session, action, userID, details string) {
+	auditTrail.Record(&AuditEvent{Actor: userID, Type: AuditTypeAdmin, Action: action, Details: details})
 	if cfg.AuditLogChannelID == "" {
 		return
 	}
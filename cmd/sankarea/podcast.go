@@ -0,0 +1,114 @@
+// cmd/sankarea/podcast.go
+package main
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+)
+
+// podcastEpisode is the playable part of a podcast feed item
+type podcastEpisode struct {
+    AudioURL string
+    Duration string // "M:SS" or "H:MM:SS", "" if the feed doesn't say
+}
+
+// episodeFromItem returns an item's first audio or video enclosure and its
+// iTunes duration
+func episodeFromItem(item *gofeed.Item) (podcastEpisode, bool) {
+    for _, enc := range item.Enclosures {
+        if enc.URL == "" || !(strings.HasPrefix(enc.Type, "audio/") || strings.HasPrefix(enc.Type, "video/")) {
+            continue
+        }
+        episode := podcastEpisode{AudioURL: enc.URL}
+        if item.ITunesExt != nil {
+            episode.Duration = formatEpisodeDuration(item.ITunesExt.Duration)
+        }
+        return episode, true
+    }
+    return podcastEpisode{}, false
+}
+
+// formatEpisodeDuration normalizes an iTunes duration, given as seconds,
+// MM:SS or HH:MM:SS, to M:SS or H:MM:SS
+func formatEpisodeDuration(raw string) string {
+    raw = strings.TrimSpace(raw)
+    if raw == "" {
+        return ""
+    }
+    total := 0
+    for _, part := range strings.Split(raw, ":") {
+        n, err := strconv.Atoi(part)
+        if err != nil || n < 0 {
+            return ""
+        }
+        total = total*60 + n
+    }
+    if total == 0 {
+        return ""
+    }
+    if total >= 3600 {
+        return fmt.Sprintf("%d:%02d:%02d", total/3600, total/60%60, total%60)
+    }
+    return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+// preparePodcastItem readies a podcast item for processing: an episode with
+// no page link uses its audio URL, and an episode with no categories takes
+// the show's iTunes categories so it can still be categorized
+func preparePodcastItem(feed *gofeed.Feed, item *gofeed.Item) podcastEpisode {
+    episode, _ := episodeFromItem(item)
+    if item.Link == "" {
+        item.Link = episode.AudioURL
+    }
+    if len(item.Categories) == 0 && feed.ITunesExt != nil {
+        for _, category := range feed.ITunesExt.Categories {
+            item.Categories = append(item.Categories, category.Text)
+            if category.Subcategory != nil {
+                item.Categories = append(item.Categories, category.Subcategory.Text)
+            }
+        }
+    }
+    return episode
+}
+
+// episodeLink is the inline play link for an episode in a text post; the
+// URL is wrapped so Discord doesn't preview the audio file
+func episodeLink(audioURL, duration string) string {
+    link := fmt.Sprintf("🎧 [Listen](<%s>)", audioURL)
+    if duration != "" {
+        link += " · " + duration
+    }
+    return link
+}
+
+// episodeValue is the play link for an episode in an embed
+func episodeValue(audioURL, duration string) string {
+    value := fmt.Sprintf("[Play episode](%s)", audioURL)
+    if duration != "" {
+        value += " · " + duration
+    }
+    return value
+}
+
+// applyPodcast adds the episode's play link and duration to formatted
+// news: text posts get a line and embeds get a field
+func applyPodcast(content string, embeds []*discordgo.MessageEmbed, audioURL, duration string) (string, []*discordgo.MessageEmbed) {
+    if audioURL == "" {
+        return content, embeds
+    }
+    for _, embed := range embeds {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   "🎧 Episode",
+            Value:  episodeValue(audioURL, duration),
+            Inline: true,
+        })
+    }
+    if content != "" {
+        content += "\n" + episodeLink(audioURL, duration)
+    }
+    return content, embeds
+}
@@ -0,0 +1,244 @@
+// cmd/sankarea/posted_messages.go
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// ArticleMessage records where an article was posted, so the post can be
+// edited or cleaned up later. EmbedIndex is the position of the article's
+// first embed in the message and EmbedCount how many it has; text posts
+// have none.
+type ArticleMessage struct {
+    ArticleID  string
+    ChannelID  string
+    MessageID  string
+    EmbedIndex int
+    EmbedCount int
+    PostedAt   time.Time
+    Source     string
+}
+
+// errMessageGone means a recorded post was deleted from Discord
+var errMessageGone = errors.New("message no longer exists")
+
+// postArticleEmbeds sends articles' embeds to a channel in as few messages as
+// the limits allow and records which message each article landed in. An
+// article's embeds always share one message, so it can be edited in place.
+// embedsFor[i] holds the embeds of articles[i], and errs[i] is nil when
+// articles[i] was posted. ctx cancels the wait for a slow mode slot.
+func (b *Bot) postArticleEmbeds(ctx context.Context, channelID string, articles []*NewsArticle, embedsFor [][]*discordgo.MessageEmbed) []error {
+    errs := make([]error, len(articles))
+    for n, batch := range batchArticleEmbeds(embedsFor, maxEmbedsPerMessage()) {
+        if n > 0 {
+            // Space out messages to avoid rate limiting
+            time.Sleep(500 * time.Millisecond)
+        }
+
+        // Flatten, remembering which article each embed belongs to
+        var embeds []*discordgo.MessageEmbed
+        var owners []int
+        for _, idx := range batch {
+            for _, embed := range embedsFor[idx] {
+                embeds = append(embeds, embed)
+                owners = append(owners, idx)
+            }
+        }
+
+        err := slowModePacer.Wait(ctx, b.discord, channelID, slowModeMaxWait)
+        var msg *discordgo.Message
+        if err == nil {
+            msg, err = b.discord.ChannelMessageSendEmbeds(channelID, embeds)
+        }
+        if err != nil {
+            b.logger.Error("Failed to send %d embeds to channel %s: %v", len(embeds), channelID, err)
+            for _, idx := range batch {
+                errs[idx] = err
+            }
+            continue
+        }
+        b.recordArticleMessages(msg, articles, owners)
+    }
+    return errs
+}
+
+// batchArticleEmbeds groups articles into messages of at most max embeds
+// whose combined size stays within Discord's limit, never splitting one
+// article's embeds. It returns the article indexes of each message; an
+// article over the limits on its own gets a message to itself.
+func batchArticleEmbeds(embedsFor [][]*discordgo.MessageEmbed, max int) [][]int {
+    var batches [][]int
+    var current []int
+    count, size := 0, 0
+    for idx, embeds := range embedsFor {
+        articleSize := 0
+        for _, embed := range embeds {
+            articleSize += embedSize(embed)
+        }
+        if len(current) > 0 && (count+len(embeds) > max || size+articleSize > MaxMessageEmbedSize) {
+            batches = append(batches, current)
+            current, count, size = nil, 0, 0
+        }
+        current = append(current, idx)
+        count += len(embeds)
+        size += articleSize
+    }
+    if len(current) > 0 {
+        batches = append(batches, current)
+    }
+    return batches
+}
+
+// recordArticleMessages stores the message position of every article in a sent batch
+func (b *Bot) recordArticleMessages(msg *discordgo.Message, articles []*NewsArticle, owners []int) {
+    if b.database == nil {
+        return
+    }
+    for start := 0; start < len(owners); {
+        end := start
+        for end < len(owners) && owners[end] == owners[start] {
+            end++
+        }
+        article := articles[owners[start]]
+        if err := b.database.SaveArticleMessage(&ArticleMessage{
+            ArticleID:  article.ID,
+            ChannelID:  msg.ChannelID,
+            MessageID:  msg.ID,
+            EmbedIndex: start,
+            EmbedCount: end - start,
+            PostedAt:   time.Now().UTC(),
+            Source:     article.Source,
+        }); err != nil {
+            b.logger.Error("Failed to record message for %s: %v", article.URL, err)
+        }
+        start = end
+    }
+}
+
+// recordArticlePost stores the message an article was posted in on its own
+func (b *Bot) recordArticlePost(msg *discordgo.Message, article *NewsArticle, embedCount int) {
+    if b.database == nil || msg == nil {
+        return
+    }
+    if err := b.database.SaveArticleMessage(&ArticleMessage{
+        ArticleID:  article.ID,
+        ChannelID:  msg.ChannelID,
+        MessageID:  msg.ID,
+        EmbedCount: embedCount,
+        PostedAt:   time.Now().UTC(),
+        Source:     article.Source,
+    }); err != nil {
+        b.logger.Error("Failed to record message for %s: %v", article.URL, err)
+    }
+}
+
+// editArticleMessage replaces an article's embeds in a posted message
+func (b *Bot) editArticleMessage(record *ArticleMessage, embeds []*discordgo.MessageEmbed) error {
+    msg, err := b.discord.ChannelMessage(record.ChannelID, record.MessageID)
+    if err != nil {
+        return messageLoadError(err)
+    }
+
+    end := record.EmbedIndex + record.EmbedCount
+    if end > len(msg.Embeds) {
+        return fmt.Errorf("message has %d embeds, expected at least %d", len(msg.Embeds), end)
+    }
+    updated := make([]*discordgo.MessageEmbed, 0, len(msg.Embeds)-record.EmbedCount+len(embeds))
+    updated = append(updated, msg.Embeds[:record.EmbedIndex]...)
+    updated = append(updated, embeds...)
+    updated = append(updated, msg.Embeds[end:]...)
+
+    if _, err := b.discord.ChannelMessageEditEmbeds(record.ChannelID, record.MessageID, updated); err != nil {
+        return fmt.Errorf("failed to edit message: %v", err)
+    }
+    if len(embeds) != record.EmbedCount {
+        record.EmbedCount = len(embeds)
+        if err := b.database.SaveArticleMessage(record); err != nil {
+            b.logger.Error("Failed to update message record for %s: %v", record.ArticleID, err)
+        }
+    }
+    return nil
+}
+
+// messageLoadError maps a failed message lookup to errMessageGone when the
+// message was deleted
+func messageLoadError(err error) error {
+    var restErr *discordgo.RESTError
+    if errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound {
+        return errMessageGone
+    }
+    return fmt.Errorf("failed to load message: %v", err)
+}
+
+// hasPostedMessages reports whether any post of the article was recorded
+func (b *Bot) hasPostedMessages(articleID string) bool {
+    if b.database == nil {
+        return false
+    }
+    records, err := b.database.GetArticleMessages(articleID)
+    if err != nil {
+        b.logger.Error("Failed to load posts for %s: %v", articleID, err)
+        return false
+    }
+    return len(records) > 0
+}
+
+// updatePostedArticle edits every recorded post of an article to its current
+// content. It reports whether at least one post was updated; records of
+// deleted messages are dropped so the article can be posted afresh.
+func (b *Bot) updatePostedArticle(article *NewsArticle) bool {
+    if b.database == nil {
+        return false
+    }
+    records, err := b.database.GetArticleMessages(article.ID)
+    if err != nil {
+        b.logger.Error("Failed to load posts for %s: %v", article.URL, err)
+        return false
+    }
+
+    _, _, embeds, err := b.scheduler.formatArticle(article)
+    if err != nil || len(embeds) == 0 {
+        return false
+    }
+
+    updated := false
+    for _, record := range records {
+        err := b.editArticleMessage(record, embeds)
+        switch {
+        case err == nil:
+            updated = true
+        case err == errMessageGone:
+            if err := b.database.DeleteArticleMessage(record); err != nil {
+                b.logger.Error("Failed to drop message record for %s: %v", article.URL, err)
+            }
+        default:
+            b.logger.Error("Failed to update post of %s in %s: %v", article.URL, record.ChannelID, err)
+        }
+    }
+    if updated {
+        b.logger.Info("Updated posts of edited article: %s", article.Title)
+    }
+    return updated
+}
+
+// markUpdated flags formatted output as an updated version of an earlier post
+func markUpdated(content string, embeds []*discordgo.MessageEmbed) (string, []*discordgo.MessageEmbed) {
+    const label = "✏️ Updated"
+    if content != "" {
+        content = label + "\n" + content
+    }
+    for _, embed := range embeds {
+        if embed.Footer == nil {
+            embed.Footer = &discordgo.MessageEmbedFooter{Text: label}
+        } else {
+            embed.Footer.Text += " • " + label
+        }
+    }
+    return content, embeds
+}
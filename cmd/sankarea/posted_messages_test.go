@@ -0,0 +1,48 @@
+// cmd/sankarea/posted_messages_test.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    "testing"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// sizedEmbeds returns n embeds of about size characters each
+func sizedEmbeds(n, size int) []*discordgo.MessageEmbed {
+    embeds := make([]*discordgo.MessageEmbed, n)
+    for idx := range embeds {
+        embeds[idx] = &discordgo.MessageEmbed{Description: strings.Repeat("x", size)}
+    }
+    return embeds
+}
+
+func TestBatchArticleEmbeds(t *testing.T) {
+    tests := []struct {
+        name   string
+        counts []int // embeds per article
+        size   int   // characters per embed
+        max    int
+        want   string
+    }{
+        {"fits in one message", []int{1, 2, 3}, 10, 10, "[[0 1 2]]"},
+        {"article moves whole to the next message", []int{4, 4, 3}, 10, 10, "[[0 1] [2]]"},
+        {"exactly full", []int{5, 5, 1}, 10, 10, "[[0 1] [2]]"},
+        {"size limit", []int{1, 1, 1}, 2500, 10, "[[0 1] [2]]"},
+        {"oversized article alone", []int{1, 12, 1}, 10, 10, "[[0] [1] [2]]"},
+        {"no articles", nil, 10, 10, "[]"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var embedsFor [][]*discordgo.MessageEmbed
+            for _, n := range tt.counts {
+                embedsFor = append(embedsFor, sizedEmbeds(n, tt.size))
+            }
+            batches := batchArticleEmbeds(embedsFor, tt.max)
+            if got := fmt.Sprint(batches); got != tt.want {
+                t.Errorf("batchArticleEmbeds = %s, want %s", got, tt.want)
+            }
+        })
+    }
+}
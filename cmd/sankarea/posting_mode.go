@@ -0,0 +1,84 @@
+// cmd/sankarea/posting_mode.go
+package main
+
+import (
+    "fmt"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Bot-wide posting modes
+const (
+    PostingModeStream = "stream" // post articles as they are fetched
+    PostingModeDigest = "digest" // only the scheduled digest posts; articles are still stored
+)
+
+// postingMode returns the active mode: a /mode override saved in the state,
+// otherwise the digest_only config flag
+func postingMode() string {
+    stateMux.RLock()
+    override := ""
+    if state != nil {
+        override = state.PostingMode
+    }
+    stateMux.RUnlock()
+
+    if override != "" {
+        return override
+    }
+    if cfg != nil && cfg.DigestOnly {
+        return PostingModeDigest
+    }
+    return PostingModeStream
+}
+
+// digestOnly reports whether individual article posts are suppressed
+func digestOnly() bool {
+    return postingMode() == PostingModeDigest
+}
+
+// SetPostingMode saves a posting mode override that survives restarts
+func SetPostingMode(mode string) error {
+    if mode != PostingModeStream && mode != PostingModeDigest {
+        return fmt.Errorf("unknown posting mode %q", mode)
+    }
+    return UpdateState(func(s *State) {
+        s.PostingMode = mode
+    })
+}
+
+// handleModeCommand switches between streaming posts and digest-only mode
+func (b *Bot) handleModeCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    if len(options) == 0 {
+        respondWithError(s, i, "Choose digest or stream")
+        return nil
+    }
+    mode := options[0].Name
+
+    if err := SetPostingMode(mode); err != nil {
+        respondWithError(s, i, "Failed to save posting mode")
+        return fmt.Errorf("failed to set posting mode: %v", err)
+    }
+    b.logger.Info("Posting mode set to %s by %s", mode, interactionUserID(i))
+
+    content := "📰 Stream mode: articles are posted as they arrive."
+    if mode == PostingModeDigest {
+        content = "🗞️ Digest-only mode: articles are collected and only the scheduled digest is posted."
+        if !b.config.ScheduledDigest.Enabled {
+            content += "\n⚠️ scheduled_digest isn't enabled, so nothing will be posted until it is."
+        }
+    }
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: content,
+            Flags:   discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
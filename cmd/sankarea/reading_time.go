@@ -0,0 +1,46 @@
+// cmd/sankarea/reading_time.go
+package main
+
+import (
+    "math"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// defaultReadingWPM is the reading speed used when reading_wpm is unset
+const defaultReadingWPM = 230
+
+// readingMinutes estimates how long an article takes to read, rounded up
+// to a whole minute; 0 means the word count is unknown
+func readingMinutes(wordCount int) int {
+    if wordCount <= 0 {
+        return 0
+    }
+    wpm := defaultReadingWPM
+    if cfg != nil && cfg.ReadingWPM > 0 {
+        wpm = cfg.ReadingWPM
+    }
+    return int(math.Ceil(float64(wordCount) / float64(wpm)))
+}
+
+// applyReadingTime adds the estimated reading time to detailed and embed
+// posts. Compact posts, and articles without extracted text, are unchanged.
+func applyReadingTime(content string, embeds []*discordgo.MessageEmbed, style string, wordCount int, lang string) (string, []*discordgo.MessageEmbed) {
+    minutes := readingMinutes(wordCount)
+    if minutes == 0 {
+        return content, embeds
+    }
+    label := "⏱ " + tr(lang, "reading_time.minutes", minutes)
+
+    for _, embed := range embeds {
+        embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+            Name:   tr(lang, "label.reading_time"),
+            Value:  label,
+            Inline: true,
+        })
+    }
+    if style == FormatStyleDetailed && content != "" {
+        content += label + "\n"
+    }
+    return content, embeds
+}
@@ -0,0 +1,196 @@
+// cmd/sankarea/recommend.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Limits for /recommend
+const (
+    defaultRecommendCount = 5
+    maxRecommendCount     = 10
+    recommendWindow       = 3 * 24 * time.Hour
+)
+
+// Recommendation weights: a category the user reads or subscribes to is
+// worth up to recommendCategoryWeight, each keyword hit in a title
+// recommendTitleWeight and each hit only in the body recommendBodyWeight
+const (
+    recommendCategoryWeight = 3.0
+    recommendTitleWeight    = 2.0
+    recommendBodyWeight     = 1.0
+)
+
+// Recommendation is a scored article with the reasons behind its score
+type Recommendation struct {
+    Article  *NewsArticle
+    Score    float64
+    Category float64  // category affinity, 0-1
+    Keywords []string // tracked keywords the article mentions
+}
+
+// categoryAffinity returns the share of the user's read articles in each
+// category; subscribed categories count as fully liked
+func categoryAffinity(read []*NewsArticle, subscriptions []string) map[string]float64 {
+    affinity := make(map[string]float64)
+    for _, article := range read {
+        affinity[strings.ToLower(article.Category)] += 1 / float64(len(read))
+    }
+    for _, category := range subscriptions {
+        affinity[strings.ToLower(category)] = 1
+    }
+    return affinity
+}
+
+// scoreRecommendation weighs an article by category affinity and keyword hits
+func scoreRecommendation(article *NewsArticle, affinity map[string]float64, keywords []string) Recommendation {
+    rec := Recommendation{Article: article, Category: affinity[strings.ToLower(article.Category)]}
+    rec.Score = rec.Category * recommendCategoryWeight
+
+    title := strings.ToLower(article.Title)
+    body := strings.ToLower(article.Content)
+    for _, keyword := range keywords {
+        k := strings.ToLower(strings.TrimSpace(keyword))
+        if k == "" {
+            continue
+        }
+        switch {
+        case strings.Contains(title, k):
+            rec.Score += recommendTitleWeight
+        case strings.Contains(body, k):
+            rec.Score += recommendBodyWeight
+        default:
+            continue
+        }
+        rec.Keywords = append(rec.Keywords, keyword)
+    }
+    return rec
+}
+
+// recommendArticles ranks unread candidates for a user, best first, and
+// drops those that match nothing the user has shown interest in
+func recommendArticles(candidates, read []*NewsArticle, readIDs map[string]time.Time, subscriptions, keywords []string) []Recommendation {
+    affinity := categoryAffinity(read, subscriptions)
+
+    var recs []Recommendation
+    for _, article := range candidates {
+        if _, seen := readIDs[article.ID]; seen {
+            continue
+        }
+        if rec := scoreRecommendation(article, affinity, keywords); rec.Score > 0 {
+            recs = append(recs, rec)
+        }
+    }
+
+    sort.SliceStable(recs, func(i, j int) bool {
+        if recs[i].Score != recs[j].Score {
+            return recs[i].Score > recs[j].Score
+        }
+        return recs[i].Article.PublishedAt.After(recs[j].Article.PublishedAt)
+    })
+    return recs
+}
+
+// recommendReason explains a recommendation's score in one line
+func recommendReason(rec Recommendation) string {
+    var parts []string
+    if rec.Category > 0 {
+        parts = append(parts, fmt.Sprintf("%s %.0f%%", rec.Article.Category, rec.Category*100))
+    }
+    if len(rec.Keywords) > 0 {
+        parts = append(parts, "keywords: "+strings.Join(rec.Keywords, ", "))
+    }
+    return strings.Join(parts, " · ")
+}
+
+// handleRecommendCommand suggests recent unread articles matching the
+// user's read categories, subscriptions and tracked keywords
+func (b *Bot) handleRecommendCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    count := defaultRecommendCount
+    if n, ok := getOptionIntValue(i.ApplicationCommandData().Options, "count"); ok {
+        count = int(n)
+    }
+    if count < 1 || count > maxRecommendCount {
+        respondWithError(s, i, fmt.Sprintf("Count must be between 1 and %d", maxRecommendCount))
+        return nil
+    }
+    if b.database == nil {
+        respondWithError(s, i, "Recommendations need the article database")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    userID := interactionUserID(i)
+    readIDs, err := GetReadMarkers(userID)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to load your read history")
+        return fmt.Errorf("failed to load read markers for %s: %v", userID, err)
+    }
+    subscriptions, err := GetUserSubscriptions(userID)
+    if err != nil {
+        b.logger.Warn("Failed to load subscriptions for %s: %v", userID, err)
+    }
+    keywords, err := GetTrackedKeywords(userID)
+    if err != nil {
+        b.logger.Warn("Failed to load tracked keywords for %s: %v", userID, err)
+    }
+
+    var read []*NewsArticle
+    for id := range readIDs {
+        if article, err := b.database.GetArticle(id); err == nil && article != nil {
+            read = append(read, article)
+        }
+    }
+    if len(read) == 0 && len(subscriptions) == 0 && len(keywords) == 0 {
+        editResponse(s, i, "No interests to go on yet. Subscribe to categories or track keywords first.")
+        return nil
+    }
+
+    end := time.Now().UTC()
+    candidates, err := b.database.GetArticlesByTimeRange(end.Add(-recommendWindow), end)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to load recent articles")
+        return fmt.Errorf("failed to load recommendation candidates: %v", err)
+    }
+    candidates = ApplyUserFilter(userID, candidates)
+
+    recs := recommendArticles(candidates, read, readIDs, subscriptions, keywords)
+    if len(recs) == 0 {
+        editResponse(s, i, "Nothing new matches your interests right now.")
+        return nil
+    }
+    if len(recs) > count {
+        recs = recs[:count]
+    }
+
+    lines := make([]string, 0, len(recs))
+    for idx, rec := range recs {
+        lines = append(lines, fmt.Sprintf("**%d.** [%s](%s)\n%s · %s",
+            idx+1, truncateString(rec.Article.Title, 200), rec.Article.URL, rec.Article.Source, recommendReason(rec)))
+    }
+
+    editResponseWithEmbed(s, i, &discordgo.MessageEmbed{
+        Title:       "✨ Recommended for you",
+        Description: strings.Join(lines, "\n\n"),
+        Color:       0x7289DA,
+        Footer: &discordgo.MessageEmbedFooter{
+            Text: "Ranked by your read categories, subscriptions and tracked keywords",
+        },
+        Timestamp: time.Now().Format(time.RFC3339),
+    })
+    return nil
+}
@@ -0,0 +1,281 @@
+// cmd/sankarea/refactcheck.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Progress reporting for /refactcheck
+const (
+    refactCheckReportEvery = 30 * time.Second
+    // Interaction tokens last 15 minutes; later progress is only in /refactcheck
+    refactCheckEditWindow = 14 * time.Minute
+)
+
+// RefactCheckProgress is the state of a fact-check reprocessing run
+type RefactCheckProgress struct {
+    Target     string // a source name or "all"
+    Since      time.Time
+    StartedBy  string
+    StartedAt  time.Time
+    Total      int
+    Done       int
+    Changed    int // reliability tier differs from the stored result
+    Failed     int
+    Finished   bool
+    FinishedAt time.Time
+}
+
+// summary describes the progress in one or two lines
+func (p RefactCheckProgress) summary() string {
+    scope := "all sources"
+    if p.Target != "all" {
+        scope = "**" + p.Target + "**"
+    }
+    if !p.Since.IsZero() {
+        scope += fmt.Sprintf(" since <t:%d:f>", p.Since.Unix())
+    }
+    text := fmt.Sprintf("🔁 Re-checked %d of %d articles from %s · %d changed tier · %d failed",
+        p.Done, p.Total, scope, p.Changed, p.Failed)
+    if p.Finished {
+        text = "✅ " + strings.TrimPrefix(text, "🔁 ") + fmt.Sprintf("\nFinished in %s", p.FinishedAt.Sub(p.StartedAt).Round(time.Second))
+    }
+    return text
+}
+
+// RefactCheckJob runs at most one reprocessing pass at a time
+type RefactCheckJob struct {
+    mu       sync.Mutex
+    progress RefactCheckProgress
+    running  bool
+    cancel   context.CancelFunc
+}
+
+// refactCheckJob is the process-wide reprocessing job
+var refactCheckJob = &RefactCheckJob{}
+
+// begin claims the job for a new run, or returns false if one is running
+func (j *RefactCheckJob) begin(progress RefactCheckProgress, cancel context.CancelFunc) bool {
+    j.mu.Lock()
+    defer j.mu.Unlock()
+    if j.running {
+        return false
+    }
+    j.running = true
+    j.progress = progress
+    j.cancel = cancel
+    return true
+}
+
+// update changes the progress under the lock and returns a copy
+func (j *RefactCheckJob) update(fn func(*RefactCheckProgress)) RefactCheckProgress {
+    j.mu.Lock()
+    defer j.mu.Unlock()
+    fn(&j.progress)
+    if j.progress.Finished {
+        j.running = false
+        j.cancel = nil
+    }
+    return j.progress
+}
+
+// Status returns the current or last run and whether it is still running
+func (j *RefactCheckJob) Status() (RefactCheckProgress, bool) {
+    j.mu.Lock()
+    defer j.mu.Unlock()
+    return j.progress, j.running
+}
+
+// Cancel stops a running pass; checked articles keep their new result
+func (j *RefactCheckJob) Cancel() {
+    j.mu.Lock()
+    defer j.mu.Unlock()
+    if j.cancel != nil {
+        j.cancel()
+    }
+}
+
+// runRefactCheck re-checks the articles one at a time under the fact-check
+// rate limit and stores the new results. Posts are left alone.
+func (b *Bot) runRefactCheck(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, ids []string) {
+    defer RecoverFromPanic("refactcheck")
+
+    lastReport := time.Now()
+    for _, id := range ids {
+        if ctx.Err() != nil {
+            break
+        }
+        changed, err := b.refactCheckArticle(ctx, id)
+        if err != nil && ctx.Err() != nil {
+            break
+        }
+        if err != nil {
+            b.logger.Error("Re-check of article %s failed: %v", id, err)
+        }
+        progress := refactCheckJob.update(func(p *RefactCheckProgress) {
+            p.Done++
+            if err != nil {
+                p.Failed++
+            } else if changed {
+                p.Changed++
+            }
+        })
+
+        if time.Since(lastReport) >= refactCheckReportEvery && time.Since(progress.StartedAt) < refactCheckEditWindow {
+            editResponse(s, i, progress.summary())
+            lastReport = time.Now()
+        }
+    }
+
+    progress := refactCheckJob.update(func(p *RefactCheckProgress) {
+        p.Finished = true
+        p.FinishedAt = time.Now()
+    })
+    b.logger.Info("Fact-check reprocessing of %s finished: %d of %d checked, %d changed tier, %d failed",
+        progress.Target, progress.Done, progress.Total, progress.Changed, progress.Failed)
+    if time.Since(progress.StartedAt) < refactCheckEditWindow {
+        editResponse(s, i, progress.summary())
+    }
+}
+
+// refactCheckArticle re-checks one stored article and saves the result,
+// reporting whether its reliability tier changed
+func (b *Bot) refactCheckArticle(ctx context.Context, id string) (bool, error) {
+    article, err := b.database.GetArticle(id)
+    if err != nil {
+        return false, err
+    }
+    if article == nil {
+        // Cleaned up since the run started
+        return false, nil
+    }
+
+    if err := b.factCheckLimit.Wait(ctx); err != nil {
+        return false, err
+    }
+    checkCtx, cancel := context.WithTimeout(ctx, factCheckTimeout)
+    result, err := b.factChecker.Recheck(checkCtx, article)
+    cancel()
+    if err != nil {
+        return false, err
+    }
+
+    changed := article.FactCheckResult == nil || article.FactCheckResult.ReliabilityTier != result.ReliabilityTier
+    if err := b.database.UpdateFactCheckResult(article.ID, result); err != nil {
+        return false, err
+    }
+    traceDecision(article.URL, StageFactCheck, "re-checked", fmt.Sprintf("%s (%.2f)", result.ReliabilityTier, result.Score))
+    return changed, nil
+}
+
+// handleRefactCheckCommand re-runs fact checks over stored articles of one
+// source or all of them in the background. While a run is going it reports
+// the run's progress instead of starting another.
+func (b *Bot) handleRefactCheckCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    if progress, running := refactCheckJob.Status(); running {
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseChannelMessageWithSource,
+            Data: &discordgo.InteractionResponseData{
+                Content: progress.summary() + "\nA fact-check reprocessing run is already going",
+                Flags:   discordgo.MessageFlagsEphemeral,
+            },
+        })
+    }
+
+    options := i.ApplicationCommandData().Options
+    target := strings.TrimSpace(getOptionString(options, "source"))
+    if target == "" {
+        progress, _ := refactCheckJob.Status()
+        if progress.StartedAt.IsZero() {
+            respondWithError(s, i, "Please specify a source, or all")
+            return nil
+        }
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseChannelMessageWithSource,
+            Data: &discordgo.InteractionResponseData{
+                Content: progress.summary(),
+                Flags:   discordgo.MessageFlagsEphemeral,
+            },
+        })
+    }
+    var since time.Time
+    if value := getOptionString(options, "since"); value != "" {
+        age, err := parseCleanupAge(value)
+        if err != nil {
+            respondWithError(s, i, err.Error())
+            return nil
+        }
+        since = time.Now().UTC().Add(-age)
+    }
+    if b.database == nil {
+        respondWithError(s, i, "Reprocessing needs the article database")
+        return nil
+    }
+
+    source := ""
+    if !strings.EqualFold(target, "all") {
+        sources, err := LoadSources()
+        if err != nil {
+            respondWithError(s, i, "Failed to load sources")
+            return fmt.Errorf("failed to load sources: %v", err)
+        }
+        src, ok := findSource(sources, target)
+        if !ok {
+            respondWithError(s, i, "Source not found")
+            return nil
+        }
+        source, target = src.Name, src.Name
+    } else {
+        target = "all"
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    ids, err := b.database.GetArticleIDs(source, since)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to load stored articles")
+        return fmt.Errorf("failed to load articles to re-check: %v", err)
+    }
+    if len(ids) == 0 {
+        editResponse(s, i, "No stored articles match")
+        return nil
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    progress := RefactCheckProgress{
+        Target:    target,
+        Since:     since,
+        StartedBy: interactionUserID(i),
+        StartedAt: time.Now(),
+        Total:     len(ids),
+    }
+    if !refactCheckJob.begin(progress, cancel) {
+        cancel()
+        editResponse(s, i, "A fact-check reprocessing run is already going")
+        return nil
+    }
+    b.logger.Info("Fact-check reprocessing of %s (%d articles) started by %s", target, len(ids), progress.StartedBy)
+
+    go b.runRefactCheck(ctx, s, i, ids)
+    editResponse(s, i, progress.summary()+"\nRunning in the background; use /refactcheck again to see progress")
+    return nil
+}
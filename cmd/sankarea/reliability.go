@@ -0,0 +1,280 @@
+// cmd/sankarea/reliability.go
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/url"
+    "strings"
+)
+
+// Reliability backends selectable in fact_check_backends
+const (
+    BackendHeuristic   = "heuristic"   // built-in source, content and citation heuristics
+    BackendGoogle      = "google"      // Google Fact Check Tools claim search
+    BackendClaimBuster = "claimbuster" // ClaimBuster check-worthiness scoring
+)
+
+// Fact-checking API endpoints
+const (
+    googleFactCheckEndpoint = "https://factchecktools.googleapis.com/v1alpha1/claims:search"
+    claimBusterEndpoint     = "https://idir.uta.edu/claimbuster/api/v2/score/text/"
+)
+
+// errNoReliabilityData means a backend had nothing to say about an article;
+// it is left out of the combined score rather than treated as a failure
+var errNoReliabilityData = fmt.Errorf("no reliability data")
+
+// ReliabilityReport is one backend's assessment of an article
+type ReliabilityReport struct {
+    Score   float64 // 0 (unreliable) to 1 (reliable)
+    Reasons []string
+    Claims  []Claim
+}
+
+// ReliabilityChecker is a fact-checking backend
+type ReliabilityChecker interface {
+    Name() string
+    Check(ctx context.Context, article *NewsArticle) (*ReliabilityReport, error)
+}
+
+// FactCheckBackendConfig enables a backend and sets its weight in the combined score
+type FactCheckBackendConfig struct {
+    Name   string  `json:"name"`
+    Weight float64 `json:"weight"`
+}
+
+// weightedChecker pairs a backend with its weight
+type weightedChecker struct {
+    checker ReliabilityChecker
+    weight  float64
+}
+
+// defaultFactCheckBackends uses the heuristics plus every API with a key set
+func defaultFactCheckBackends() []FactCheckBackendConfig {
+    backends := []FactCheckBackendConfig{{Name: BackendHeuristic, Weight: 1}}
+    if cfg != nil && cfg.GoogleFactCheckAPIKey != "" {
+        backends = append(backends, FactCheckBackendConfig{Name: BackendGoogle, Weight: 1})
+    }
+    if cfg != nil && cfg.ClaimBustersAPIKey != "" {
+        backends = append(backends, FactCheckBackendConfig{Name: BackendClaimBuster, Weight: 0.5})
+    }
+    return backends
+}
+
+// newReliabilityChecker builds a backend by name
+func newReliabilityChecker(name string, fc *FactChecker) (ReliabilityChecker, error) {
+    switch name {
+    case BackendHeuristic:
+        return &heuristicChecker{fc: fc}, nil
+    case BackendGoogle:
+        if cfg == nil || cfg.GoogleFactCheckAPIKey == "" {
+            return nil, fmt.Errorf("google backend needs google_fact_check_api_key")
+        }
+        return &googleFactChecker{client: fc.client, apiKey: cfg.GoogleFactCheckAPIKey}, nil
+    case BackendClaimBuster:
+        if cfg == nil || cfg.ClaimBustersAPIKey == "" {
+            return nil, fmt.Errorf("claimbuster backend needs claimbusters_api_key")
+        }
+        return &claimBusterChecker{client: fc.client, apiKey: cfg.ClaimBustersAPIKey}, nil
+    default:
+        return nil, fmt.Errorf("unknown fact check backend %q", name)
+    }
+}
+
+// reliabilityBackends builds the configured backends, skipping any that
+// can't be created so one missing key doesn't disable fact checking
+func reliabilityBackends(fc *FactChecker) []weightedChecker {
+    configs := defaultFactCheckBackends()
+    if cfg != nil && len(cfg.FactCheckBackends) > 0 {
+        configs = cfg.FactCheckBackends
+    }
+
+    var backends []weightedChecker
+    for _, c := range configs {
+        if c.Weight <= 0 {
+            continue
+        }
+        checker, err := newReliabilityChecker(c.Name, fc)
+        if err != nil {
+            Logger().Printf("Fact check backend %s disabled: %v", c.Name, err)
+            continue
+        }
+        backends = append(backends, weightedChecker{checker: checker, weight: c.Weight})
+    }
+    if len(backends) == 0 {
+        backends = append(backends, weightedChecker{checker: &heuristicChecker{fc: fc}, weight: 1})
+    }
+    return backends
+}
+
+// heuristicChecker wraps the built-in source, content and citation heuristics
+type heuristicChecker struct {
+    fc *FactChecker
+}
+
+// Name identifies the backend
+func (h *heuristicChecker) Name() string { return BackendHeuristic }
+
+// Check scores the article with the built-in heuristics
+func (h *heuristicChecker) Check(ctx context.Context, article *NewsArticle) (*ReliabilityReport, error) {
+    score, reasons, err := h.fc.analyzeReliability(ctx, article)
+    if err != nil {
+        return nil, err
+    }
+
+    claims, err := h.fc.extractClaims(ctx, article)
+    if err != nil {
+        Logger().Printf("Warning: claim extraction failed for %s: %v", article.URL, err)
+        claims = nil
+    }
+    return &ReliabilityReport{Score: score, Reasons: reasons, Claims: claims}, nil
+}
+
+// googleFactChecker looks the headline up in published fact checks
+type googleFactChecker struct {
+    client *http.Client
+    apiKey string
+}
+
+// Name identifies the backend
+func (g *googleFactChecker) Name() string { return BackendGoogle }
+
+// Check averages the ratings of fact checks matching the headline
+func (g *googleFactChecker) Check(ctx context.Context, article *NewsArticle) (*ReliabilityReport, error) {
+    query := url.Values{
+        "query":    {truncateString(article.Title, 200)},
+        "pageSize": {"5"},
+        "key":      {g.apiKey},
+    }
+    req, err := http.NewRequestWithContext(ctx, "GET", googleFactCheckEndpoint+"?"+query.Encode(), nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %v", err)
+    }
+
+    var body struct {
+        Claims []struct {
+            Text        string `json:"text"`
+            ClaimReview []struct {
+                Publisher struct {
+                    Name string `json:"name"`
+                } `json:"publisher"`
+                URL           string `json:"url"`
+                TextualRating string `json:"textualRating"`
+            } `json:"claimReview"`
+        } `json:"claims"`
+    }
+    if err := getJSON(g.client, req, &body); err != nil {
+        return nil, err
+    }
+
+    report := &ReliabilityReport{}
+    total, rated := 0.0, 0
+    for _, claim := range body.Claims {
+        for _, review := range claim.ClaimReview {
+            score, ok := ratingScore(review.TextualRating)
+            if !ok {
+                continue
+            }
+            total += score
+            rated++
+            report.Claims = append(report.Claims, Claim{
+                Text:     claim.Text,
+                Rating:   review.TextualRating,
+                Evidence: review.URL,
+            })
+            report.Reasons = append(report.Reasons, fmt.Sprintf("%s rated a matching claim %q", review.Publisher.Name, review.TextualRating))
+        }
+    }
+    if rated == 0 {
+        return nil, errNoReliabilityData
+    }
+    report.Score = total / float64(rated)
+    return report, nil
+}
+
+// ratingScore maps a fact checker's textual rating onto 0-1
+func ratingScore(rating string) (float64, bool) {
+    r := strings.ToLower(rating)
+    switch {
+    case strings.Contains(r, "mostly true"), strings.Contains(r, "mostly correct"):
+        return 0.75, true
+    case strings.Contains(r, "mostly false"), strings.Contains(r, "misleading"):
+        return 0.25, true
+    case strings.Contains(r, "half"), strings.Contains(r, "mixed"), strings.Contains(r, "partly"):
+        return 0.5, true
+    case strings.Contains(r, "false"), strings.Contains(r, "fake"), strings.Contains(r, "pants on fire"), strings.Contains(r, "incorrect"):
+        return 0, true
+    case strings.Contains(r, "true"), strings.Contains(r, "correct"), strings.Contains(r, "accurate"):
+        return 1, true
+    default:
+        return 0, false
+    }
+}
+
+// claimBusterChecker scores how check-worthy the article's sentences are.
+// It can't say whether claims are true, so text full of unverified
+// check-worthy claims only lowers the score moderately.
+type claimBusterChecker struct {
+    client *http.Client
+    apiKey string
+}
+
+// Name identifies the backend
+func (c *claimBusterChecker) Name() string { return BackendClaimBuster }
+
+// Check scores the headline and lead text
+func (c *claimBusterChecker) Check(ctx context.Context, article *NewsArticle) (*ReliabilityReport, error) {
+    text := truncateString(strings.TrimSpace(article.Title+". "+article.Content), 1000)
+    req, err := http.NewRequestWithContext(ctx, "GET", claimBusterEndpoint+url.PathEscape(text), nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %v", err)
+    }
+    req.Header.Set("x-api-key", c.apiKey)
+
+    var body struct {
+        Results []struct {
+            Text  string  `json:"text"`
+            Score float64 `json:"score"`
+        } `json:"results"`
+    }
+    if err := getJSON(c.client, req, &body); err != nil {
+        return nil, err
+    }
+    if len(body.Results) == 0 {
+        return nil, errNoReliabilityData
+    }
+
+    report := &ReliabilityReport{}
+    total := 0.0
+    for _, r := range body.Results {
+        total += r.Score
+        if r.Score >= 0.5 {
+            report.Claims = append(report.Claims, Claim{Text: r.Text, Rating: "Check-worthy"})
+        }
+    }
+    report.Score = 1 - total/float64(len(body.Results))/2
+    if len(report.Claims) > 0 {
+        report.Reasons = append(report.Reasons, fmt.Sprintf("%d check-worthy claims found", len(report.Claims)))
+    }
+    return report, nil
+}
+
+// getJSON performs a request and decodes a JSON response body into v
+func getJSON(client *http.Client, req *http.Request, v interface{}) error {
+    resp, err := client.Do(req)
+    if err != nil {
+        return fmt.Errorf("request failed: %v", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("HTTP %d", resp.StatusCode)
+    }
+    if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+        return fmt.Errorf("failed to decode response: %v", err)
+    }
+    return nil
+}
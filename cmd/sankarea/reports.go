@@ -188,6 +188,20 @@ func generateWeeklyReport() (*discordgo.MessageEmbed, error) {
 		})
 	}
 
+	// Add slowest sources
+	if len(stats.SlowestSources) > 0 {
+		slowestValue := ""
+		for i, source := range stats.SlowestSources {
+			slowestValue += fmt.Sprintf("%d. **%s** (%.0fms avg, %.1f%% uptime)\n",
+				i+1, source.Name, source.AvgResponseTime, source.UptimePercent)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Slowest Sources",
+			Value:  slowestValue,
+			Inline: false,
+		})
+	}
+
 	// Add trending topics
 	if len(stats.TrendingTopics) > 0 {
 		trendingValue := ""
@@ -484,6 +498,7 @@ type ReportStats struct {
 	RightBiasCount     int
 	
 	TopSources     []SourceStat
+	SlowestSources []SlowSourceStat
 	TopCategories  []CategoryStat
 	TrendingTopics []TopicStat
 	RecentErrors   []ErrorStat
@@ -494,6 +509,12 @@ type SourceStat struct {
 	Count int
 }
 
+type SlowSourceStat struct {
+	Name            string
+	AvgResponseTime float64 // in milliseconds
+	UptimePercent   float64
+}
+
 type CategoryStat struct {
 	Name  string
 	Count int
@@ -559,6 +580,11 @@ func getReportStats(startDate, endDate time.Time) (*ReportStats, error) {
 		},
 	}
 
+	// Slowest sources come from the live per-source fetch metrics
+	if sources, err := LoadSources(); err == nil {
+		stats.SlowestSources = slowestSources(sources, 5)
+	}
+
 	return stats, nil
 }
 
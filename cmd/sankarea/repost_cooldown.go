@@ -0,0 +1,90 @@
+// cmd/sankarea/repost_cooldown.go
+package main
+
+import (
+    "fmt"
+    "sync"
+    "time"
+)
+
+// StageRepostCooldown is the decision stage for links posted too recently
+const StageRepostCooldown = "repost cooldown"
+
+// repostTimes remembers when each link was last posted. Entries don't
+// expire with the dedup TTL, so the cooldown holds after dedup forgets them.
+var (
+    repostTimes     *LRUCache
+    repostTimesOnce sync.Once
+)
+
+// repostTimeCache returns the last post time of recently posted links
+func repostTimeCache() *LRUCache {
+    repostTimesOnce.Do(func() {
+        repostTimes = NewLRUCache("repost_cooldown", dedupCacheSize(), 0)
+    })
+    return repostTimes
+}
+
+// repostCooldown returns a source's minimum repost interval: its own
+// repost_cooldown_hours, otherwise the global value; 0 means no cooldown
+func repostCooldown(sourceHours int) time.Duration {
+    hours := sourceHours
+    if hours == 0 && cfg != nil {
+        hours = cfg.RepostCooldownHours
+    }
+    if hours <= 0 {
+        return 0
+    }
+    return time.Duration(hours) * time.Hour
+}
+
+// repostSuppressed reports whether a link was posted within the cooldown,
+// logging the suppression when it was
+func repostSuppressed(sourceName, link string, cooldown time.Duration) bool {
+    if cooldown <= 0 || link == "" {
+        return false
+    }
+    value, ok := repostTimeCache().Get(link)
+    if !ok {
+        return false
+    }
+    last, ok := value.(time.Time)
+    if !ok {
+        return false
+    }
+    since := time.Since(last)
+    if since >= cooldown {
+        return false
+    }
+    Logger().Printf("Suppressed repost of %s from %s: posted %s ago, cooldown is %s",
+        link, sourceName, since.Round(time.Minute), cooldown)
+    return true
+}
+
+// markPosted starts a link's repost cooldown
+func markPosted(link string) {
+    if link != "" {
+        repostTimeCache().Add(link, time.Now())
+    }
+}
+
+// dropCoolingDown removes articles whose link was posted within their
+// source's cooldown. Edited articles are updated in place, not reposted,
+// so they pass.
+func (s *Scheduler) dropCoolingDown(articles []*NewsArticle) []*NewsArticle {
+    cooldowns := make(map[string]int, len(s.sources))
+    for _, src := range s.sources {
+        cooldowns[src.Name] = src.RepostCooldownHours
+    }
+
+    kept := make([]*NewsArticle, 0, len(articles))
+    for _, article := range articles {
+        cooldown := repostCooldown(cooldowns[article.Source])
+        if !article.Edited && repostSuppressed(article.Source, article.URL, cooldown) {
+            traceDecision(article.URL, StageRepostCooldown, "suppressed", fmt.Sprintf("posted within the last %s", cooldown))
+            continue
+        }
+        kept = append(kept, article)
+    }
+    return kept
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -28,18 +30,43 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 	articlesProcessed := 0
 	
 	// Track which articles we've already sent (by URL)
-	sentArticles := make(map[string]bool)
+	sentArticles := sentArticleCache()
+
+	// In digest-only mode feeds are still fetched and tracked, just not posted
+	suppressPosts := digestOnly()
 	
+	if BeginFetchCycle() {
+		Logger().Println("Previous fetch cycle was interrupted; fetching stale sources first")
+	}
+
+	// Sources that have gone longest without a successful fetch go first, so
+	// a cycle cut short by a restart catches up on them
+	names := make([]string, len(sources))
 	for i, src := range sources {
+		names[i] = src.Name
+	}
+
+	for _, i := range staleFirst(names) {
+		src := sources[i]
 		if src.Paused || !src.Active {
 			continue
 		}
+		processedBefore := articlesProcessed
 
 		// Implement rate limiting and retry logic
 		var feed *gofeed.Feed
 		var err error
 		
-		feed, err = fetchFeedWithRetry(parser, src.URL, cfg.MaxRetryCount, time.Duration(cfg.RetryDelaySeconds)*time.Second)
+		fetchStart := time.Now()
+		if src.Type == SourceTypeHTML {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			feed, err = fetchHTMLFeed(ctx, GetHTTPClient(), src.URL, src.Selector)
+			cancel()
+		} else {
+			feed, err = fetchFeedWithRetry(parser, src.URL, cfg.MaxRetryCount, time.Duration(cfg.RetryDelaySeconds)*time.Second)
+		}
+		updateSourceMetrics(src.Name, time.Since(fetchStart), err == nil)
+		sourcesUpdated = true
 
 		if err != nil {
 			Logger().Printf("fetch %s failed after %d retries: %v", src.Name, cfg.MaxRetryCount, err)
@@ -47,9 +74,12 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 			sources[i].LastErrorTime = time.Now()
 			sources[i].ErrorCount++
 			sourcesUpdated = true
+			RecordSourceFailure(src.Name, err)
 			continue
 		}
 		
+		checkFeedDates(src.Name, feed.Items)
+
 		// Check language filter if enabled
 		if cfg.EnableMultiLanguage && src.Language != "" && len(cfg.SupportedLanguages) > 0 {
 			supportedLanguage := false
@@ -68,11 +98,19 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 
 		// Check if we have items to post
 		if len(feed.Items) > 0 {
-			// Format the message
-			msg := fmt.Sprintf("🔗 **%s**: %s\n", src.Name, feed.Title)
+			var posted []*gofeed.Item
+			
+			// Use channel override if specified
+			postChannelID := channelID
+			if src.ChannelOverride != "" {
+				postChannelID = src.ChannelOverride
+			}
 			
-			// Limit the number of posts
+			// Limit the number of posts, letting the source override the global cap
 			maxPosts := cfg.MaxPostsPerSource
+			if src.MaxPosts > 0 {
+				maxPosts = src.MaxPosts
+			}
 			if maxPosts <= 0 {
 				maxPosts = 5
 			}
@@ -83,23 +121,43 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 					break
 				}
 				
-				// Only include items with a valid published date
-				if item.PublishedParsed != nil {
-					// Skip if we've already sent this article
-					if item.Link != "" && sentArticles[item.Link] {
+				// Untagged sources are filtered per item by detected language
+				if cfg.EnableMultiLanguage && src.Language == "" {
+					lang := detectLanguage(item.Title + " " + item.Description)
+					if !languageAllowed(lang, cfg.SupportedLanguages) {
+						Logger().Printf("Skipping %s item in unsupported language %s: %s", src.Name, lang, item.Title)
+						continue
+					}
+				}
+
+				// Items without a date use the fetch time unless configured to skip them
+				if !skipUndated(item) {
+					// Skip if we've already sent this article, anywhere or to
+					// this channel depending on the dedup scope
+					key := sentArticleKey(postChannelID, item.Link)
+					if item.Link != "" && sentArticles.Contains(key) {
+						continue
+					}
+
+					// Links posted recently stay held even after dedup forgets them
+					if repostSuppressed(src.Name, item.Link, repostCooldown(src.RepostCooldownHours)) {
 						continue
 					}
 					
 					// Store the fact we're sending this article
 					if item.Link != "" {
-						sentArticles[item.Link] = true
+						sentArticles.Add(key, true)
+						markPosted(item.Link)
 					}
 					
-					// Format and send message
-					msg += fmt.Sprintf("• [%s](%s) - %s\n", 
-						item.Title,
-						item.Link, 
-						item.PublishedParsed.Format("Jan 02"))
+					// Sensitive items are posted on their own with a content warning
+					if reason, spoiler := sensitiveReason(src.Sensitive, item); reason == "" {
+						posted = append(posted, item)
+					} else if !suppressPosts {
+						if err := postSensitiveItem(s, postChannelID, src, item, reason, spoiler); err != nil {
+							Logger().Printf("Failed to send sensitive item: %v", err)
+						}
+					}
 					postCount++
 					articlesProcessed++
 					
@@ -113,8 +171,9 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 						go performAutoFactCheck(s, item, src)
 					}
 					
-					// Auto summarize if enabled for this source
-					if cfg.EnableSummarization && src.SummarizeAuto && item.Link != "" {
+					// Auto summarize if enabled for this source; paywalled pages and
+					// podcast episodes have nothing to summarize
+					if cfg.EnableSummarization && src.SummarizeAuto && !src.Paywalled && src.Type != SourceTypePodcast && item.Link != "" {
 						go performAutoSummarize(s, item, src)
 					}
 					
@@ -126,15 +185,9 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 			}
 			
 			// Only send if we have articles to post
-			if postCount > 0 {
-				// Use channel override if specified
-				postChannelID := channelID
-				if src.ChannelOverride != "" {
-					postChannelID = src.ChannelOverride
-				}
-				
-				// Send the message
-				_, err = s.ChannelMessageSend(postChannelID, msg)
+			if len(posted) > 0 && !suppressPosts {
+				// Send the message in the configured format
+				err = FormatNewsPost(s, postChannelID, src, feed, posted, layoutForStyle(defaultFormatStyle()))
 				if err != nil {
 					Logger().Printf("Failed to send message: %v", err)
 				}
@@ -145,14 +198,27 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 			sources[i].LastError = ""
 			sourcesUpdated = true
 		}
+
+		// Save progress per source so a crash doesn't lose the cycle's accounting
+		if outage := RecordSourceSuccess(src.Name, articlesProcessed-processedBefore); outage != nil {
+			noteSourceRecovered(s, src.Name, outage)
+		}
+	}
+	EndFetchCycle()
+	
+	// Alert on sources breaching their response time or uptime targets
+	if current, err := LoadSources(); err == nil {
+		checkSourceSLAs(s, current)
 	}
 	
 	// Update the next time in the state
-	state.NewsNextTime = time.Now().Add(parseCron(cfg.News15MinCron))
-	state.LastInterval = int(parseCron(cfg.News15MinCron).Minutes())
-	state.LastFetchTime = time.Now()
-	state.TotalArticles += articlesProcessed
-	SaveState(state)
+	if err := UpdateState(func(st *State) {
+		st.NewsNextTime = time.Now().Add(parseCron(cfg.News15MinCron))
+		st.LastInterval = int(parseCron(cfg.News15MinCron).Minutes())
+		st.LastFetchTime = time.Now()
+	}); err != nil {
+		Logger().Printf("Failed to update state after news fetch: %v", err)
+	}
 	
 	// Save sources if they were updated
 	if sourcesUpdated {
@@ -162,6 +228,29 @@ func fetchAndPostNews(s *discordgo.Session, channelID string, sources []Source)
 	Logger().Printf("News fetch completed: processed %d articles", articlesProcessed)
 }
 
+var (
+	sentArticles     *LRUCache
+	sentArticlesOnce sync.Once
+)
+
+// sentArticleKey is the sent cache key for a link: the link itself in the
+// global dedup scope, or the channel and link in the per-channel scope
+func sentArticleKey(channelID, link string) string {
+	if dedupScope() == DedupScopeChannel {
+		return channelID + "|" + link
+	}
+	return link
+}
+
+// sentArticleCache returns the links already posted, remembered across fetch
+// cycles up to the configured dedup cache size and TTL
+func sentArticleCache() *LRUCache {
+	sentArticlesOnce.Do(func() {
+		sentArticles = NewLRUCache("sent_articles", dedupCacheSize(), dedupCacheTTL())
+	})
+	return sentArticles
+}
+
 // fetchFeedWithRetry attempts to fetch an RSS feed with retries
 func fetchFeedWithRetry(parser *gofeed.Parser, url string, maxRetries int, delay time.Duration) (*gofeed.Feed, error) {
 	var feed *gofeed.Feed
@@ -208,32 +297,26 @@ func parseCron(cronExpr string) time.Duration {
 
 // performAutoFactCheck performs fact checking on an article
 func performAutoFactCheck(s *discordgo.Session, item *gofeed.Item, source Source) {
-	// Only proceed if we have the required API keys
-	if cfg.OpenAIAPIKey == "" && cfg.GoogleFactCheckAPIKey == "" && cfg.ClaimBustersAPIKey == "" {
-		return
-	}
-	
 	// Extract article content
-	articleContent := ""
-	if item.Description != "" {
-		articleContent = item.Description
-	} else if item.Content != "" {
-		articleContent = item.Content
-	}
+	articleContent := itemBody(item)
 	
 	// Perform fact check
-	factCheck, err := FactCheckArticle(item.Title, articleContent, item.Link)
+	factCheck, err := FactCheckArticle(item.Title, articleContent, item.Link, source.Name)
 	if err != nil {
 		Logger().Printf("Auto fact check failed for %s: %v", item.Link, err)
 		return
 	}
 	
 	// Only report significant fact check results (low trust score)
-	if factCheck.TrustScore < 0.7 {
+	if factCheck.Score < 0.7 {
 		// Send fact check result to audit log channel
 		if cfg.AuditLogChannelID != "" {
-			embed := createFactCheckEmbed(factCheck, item, source)
-			_, err := s.ChannelMessageSendEmbed(cfg.AuditLogChannelID, embed)
+			var err error
+			if defaultFormatStyle() == FormatStyleEmbed {
+				_, err = s.ChannelMessageSendEmbed(cfg.AuditLogChannelID, createFactCheckEmbed(factCheck, item, source))
+			} else {
+				_, err = s.ChannelMessageSend(cfg.AuditLogChannelID, formatFactCheckText(factCheck, item, source))
+			}
 			if err != nil {
 				Logger().Printf("Failed to send fact check result: %v", err)
 			}
@@ -245,27 +328,33 @@ func performAutoFactCheck(s *discordgo.Session, item *gofeed.Item, source Source
 func createFactCheckEmbed(factCheck *FactCheckResult, item *gofeed.Item, source Source) *discordgo.MessageEmbed {
 	// Determine color based on trust score
 	var color int
-	if factCheck.TrustScore > 0.7 {
+	if factCheck.Score > 0.7 {
 		color = 0x00FF00 // Green
-	} else if factCheck.TrustScore > 0.4 {
+	} else if factCheck.Score > 0.4 {
 		color = 0xFFFF00 // Yellow
 	} else {
 		color = 0xFF0000 // Red
 	}
 	
 	// Format trust score as percentage
-	trustScoreStr := fmt.Sprintf("%.1f%%", factCheck.TrustScore*100)
+	trustScoreStr := fmt.Sprintf("%.1f%%", factCheck.Score*100)
+	
+	// Lead with the first claim found, if any
+	var description string
+	if claim := factCheck.TopClaim(); claim != nil {
+		description = claim.Text
+	}
 	
 	// Create embed
 	embed := &discordgo.MessageEmbed{
 		Title:       "Fact Check: " + item.Title,
 		URL:         item.Link,
-		Description: factCheck.Claim,
+		Description: description,
 		Color:       color,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Rating",
-				Value:  factCheck.Rating,
+				Name:   "Reliability",
+				Value:  factCheck.ReliabilityTier,
 				Inline: true,
 			},
 			{
@@ -280,58 +369,62 @@ func createFactCheckEmbed(factCheck *FactCheckResult, item *gofeed.Item, source
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Method: %s", factCheck.Method),
+			Text: fmt.Sprintf("%d claims checked", len(factCheck.Claims)),
 		},
-		Timestamp: factCheck.CheckedAt.Format(time.RFC3339),
+		Timestamp: factCheck.Timestamp.Format(time.RFC3339),
 	}
 	
 	// Add explanation if available
-	if factCheck.Explanation != "" {
+	if explanation := factCheck.Explanation(); explanation != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:  "Explanation",
-			Value: factCheck.Explanation,
+			Value: explanation,
 		})
 	}
 	
 	return embed
 }
 
+// formatFactCheckText renders fact check results as plain text for non-embed styles
+func formatFactCheckText(factCheck *FactCheckResult, item *gofeed.Item, source Source) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Fact Check:** [%s](%s)\n", item.Title, item.Link))
+	sb.WriteString(fmt.Sprintf("Reliability: %s • Trust Score: %.1f%% • Source: %s\n",
+		factCheck.ReliabilityTier, factCheck.Score*100, source.Name))
+	if claim := factCheck.TopClaim(); claim != nil {
+		sb.WriteString(fmt.Sprintf("> %s\n", claim.Text))
+	}
+	if explanation := factCheck.Explanation(); explanation != "" {
+		sb.WriteString(explanation + "\n")
+	}
+	return sb.String()
+}
+
 // performAutoSummarize performs article summarization
 func performAutoSummarize(s *discordgo.Session, item *gofeed.Item, source Source) {
-	// Only proceed if we have OpenAI API key
-	if cfg.OpenAIAPIKey == "" {
-		return
-	}
-	
 	// Create article object
 	article := &Article{
 		Title:     item.Title,
 		URL:       item.Link,
 		Source:    source.Name,
-		Timestamp: *item.PublishedParsed,
+		Timestamp: getPublishedTime(item),
 	}
 	
 	// Try to extract more content
+	wordCount := 0
 	if cfg.EnableImageEmbed {
 		extractor := NewArticleExtractor()
 		extractedArticle, err := extractor.Extract(item.Link)
 		if err == nil {
 			article.Content = extractedArticle.Content
+			wordCount = extractedArticle.WordCount
 		} else {
-			// Fallback to description
-			if item.Description != "" {
-				article.Content = item.Description
-			} else if item.Content != "" {
-				article.Content = item.Content
-			}
+			// Fallback to the feed's own body
+			article.Content = itemBody(item)
 		}
 	} else {
-		// Use description or content
-		if item.Description != "" {
-			article.Content = item.Description
-		} else if item.Content != "" {
-			article.Content = item.Content
-		}
+		// Use the longest body the feed provides
+		article.Content = itemBody(item)
 	}
 	
 	// Generate summary
@@ -343,12 +436,13 @@ func performAutoSummarize(s *discordgo.Session, item *gofeed.Item, source Source
 	
 	// Only post summary if we have a channel to post to
 	if cfg.AuditLogChannelID != "" {
-		// Format message
-		msg := fmt.Sprintf("**Summary of \"%s\"** from %s\n\n%s\n\n[Read full article](%s)",
-			item.Title, source.Name, summary, item.Link)
-		
-		// Send message
-		_, err := s.ChannelMessageSend(cfg.AuditLogChannelID, msg)
+		// Format and send message in the configured style
+		content, embeds := FormatNewsItem(item, source.Name, source.Category, summary, "", nil, defaultFormatStyle(), false, true, defaultLanguage())
+		content, embeds = applyReadingTime(content, embeds, defaultFormatStyle(), wordCount, defaultLanguage())
+		if reason, spoiler := sensitiveReason(source.Sensitive, item); reason != "" {
+			content, embeds = applySensitive(content, embeds, itemImageURL(item), reason, spoiler)
+		}
+		err := sendFormattedNewsWithContent(s, cfg.AuditLogChannelID, content, embeds)
 		if err != nil {
 			Logger().Printf("Failed to send summary: %v", err)
 		}
@@ -0,0 +1,61 @@
+// cmd/sankarea/scheduled_digest.go
+package main
+
+import (
+    "fmt"
+    "time"
+)
+
+// ScheduledDigestConfig controls the digest posted to a channel on a schedule
+type ScheduledDigestConfig struct {
+    Enabled   bool   `json:"enabled"`
+    ChannelID string `json:"channel_id"`
+    Schedule  string `json:"schedule"` // cron spec, default daily at 08:00
+    Hours     int    `json:"hours"`    // articles published this many hours back, default 24
+}
+
+// scheduledDigestWindow returns the publish window the next scheduled digest covers
+func (b *Bot) scheduledDigestWindow() (time.Time, time.Time) {
+    end := time.Now().UTC()
+    return end.Add(-time.Duration(b.config.ScheduledDigest.Hours) * time.Hour), end
+}
+
+// channelDigest builds the digest the scheduled job posts, in the default
+// language and without any user's filters. /preview-digest shows the same one.
+func (b *Bot) channelDigest(start, end time.Time) (*DigestResult, error) {
+    articles, err := b.database.GetArticlesByTimeRange(start, end)
+    if err != nil {
+        return nil, fmt.Errorf("failed to fetch articles: %v", err)
+    }
+    return buildDigest(articles, start, end, defaultLanguage()), nil
+}
+
+// runScheduledDigest posts the digest to the digest channel. It posts in
+// digest-only mode too, since then it's the only thing that does.
+func (b *Bot) runScheduledDigest() {
+    channelID := b.config.ScheduledDigest.ChannelID
+    start, end := b.scheduledDigestWindow()
+
+    digest, err := b.channelDigest(start, end)
+    if err != nil {
+        b.logger.Error("Scheduled digest failed: %v", err)
+        return
+    }
+    if digest.TotalNews == 0 {
+        b.logger.Info("Skipped the scheduled digest: no articles since %s", start.Format(time.RFC3339))
+        return
+    }
+
+    for _, msg := range digest.Messages() {
+        if err := waitForSlowMode(b.discord, channelID); err != nil {
+            b.logger.Error("Failed to post scheduled digest: %v", err)
+            return
+        }
+        if _, err := b.discord.ChannelMessageSendComplex(channelID, msg); err != nil {
+            b.logger.Error("Failed to post scheduled digest: %v", err)
+            return
+        }
+    }
+
+    b.logger.Info("Posted scheduled digest with %d articles", digest.TotalNews)
+}
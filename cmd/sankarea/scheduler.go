@@ -3,13 +3,21 @@ package main
 
 import (
     "context"
+    "errors"
     "fmt"
+    "sort"
+    "strings"
     "sync"
     "time"
 
     "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+    "github.com/robfig/cron/v3"
 )
 
+// cronManager runs cron-scheduled jobs such as reports and database maintenance
+var cronManager = cron.New()
+
 // Stats represents bot statistics
 type Stats struct {
     ArticleCount   int64
@@ -21,10 +29,39 @@ type Stats struct {
 
 // Source represents a news source configuration
 type Source struct {
-    Name     string `yaml:"name"`
-    URL      string `yaml:"url"`
-    Category string `yaml:"category"`
-    Enabled  bool   `yaml:"enabled"`
+    Name      string `yaml:"name"`
+    URL       string `yaml:"url"`
+    Category  string `yaml:"category"`
+    Enabled   bool   `yaml:"enabled"`
+    Priority  int    `yaml:"priority,omitempty"`  // 1 (highest) to 3; 0 means normal
+    Proxy     string `yaml:"proxy,omitempty"`     // overrides the global proxy_url for this feed
+    Sensitive bool   `yaml:"sensitive,omitempty"` // always post with a content warning
+    MaxPosts  int    `yaml:"max_posts,omitempty"` // per-run post cap; 0 uses max_posts_per_source
+    Language  string `yaml:"language,omitempty"`  // feed language; detected per article when empty
+
+    // ForceCategory ignores the feed's own item categories
+    ForceCategory bool `yaml:"force_category,omitempty"`
+
+    // Paywalled adds a paywall notice and skips extraction and summarization
+    Paywalled bool `yaml:"paywalled,omitempty"`
+
+    // RepostCooldownHours overrides repost_cooldown_hours; 0 uses the global value
+    RepostCooldownHours int `yaml:"repost_cooldown_hours,omitempty"`
+
+    // Feed authentication
+    Headers       map[string]string `yaml:"headers,omitempty"`
+    BasicAuthUser string            `yaml:"basic_auth_user,omitempty"`
+    BasicAuthPass string            `yaml:"basic_auth_pass,omitempty"`
+
+    // HTML scraping; type "html" scrapes URL as a web page using Selector
+    Type     string `yaml:"type,omitempty"`
+    Selector string `yaml:"selector,omitempty"`
+
+    // Fetch status
+    LastFetched   time.Time `yaml:"last_fetched,omitempty"`
+    LastError     string    `yaml:"last_error,omitempty"`
+    LastErrorTime time.Time `yaml:"last_error_time,omitempty"`
+    ErrorCount    int       `yaml:"error_count,omitempty"`
 }
 
 // NewsArticle represents a processed news article
@@ -41,7 +78,7 @@ type NewsArticle struct {
 // Scheduler handles periodic news feed checks
 type Scheduler struct {
     bot        *Bot
-    sources    []Source
+    sources    []NewsSource
     stats      Stats
     ticker     *time.Ticker
     done       chan bool
@@ -49,6 +86,8 @@ type Scheduler struct {
     interval   time.Duration
     processor  *NewsProcessor
     lastCheck  map[string]time.Time
+    inFlight   inFlightArticles // fetched this run but not yet posted
+    stopOnce   sync.Once
 }
 
 // NewScheduler creates a new scheduler instance
@@ -57,7 +96,7 @@ func NewScheduler(bot *Bot, interval time.Duration) *Scheduler {
         bot:       bot,
         done:      make(chan bool),
         interval:  interval,
-        processor: NewNewsProcessor(),
+        processor: NewNewsProcessor(bot),
         lastCheck: make(map[string]time.Time),
     }
 }
@@ -69,10 +108,16 @@ func (s *Scheduler) Start() error {
         return err
     }
 
+    // Boosts that ran out while the bot was down end now; the rest resume
+    for _, name := range ExpireFetchBoosts() {
+        s.bot.logger.Info("Fetch boost for %s expired during downtime", name)
+    }
+
     s.ticker = time.NewTicker(s.interval)
+    go s.runFetchBoosts()
     
     go func() {
-        // Initial check
+        // Initial check, which also posts what the last shutdown left behind
         if err := s.checkFeeds(); err != nil {
             s.bot.logger.Error("Initial feed check failed: %v", err)
         }
@@ -92,16 +137,19 @@ func (s *Scheduler) Start() error {
     return nil
 }
 
-// Stop halts the scheduler
+// Stop halts the scheduler; a running check stops posting after its
+// current article. Use DrainInFlight to save what it didn't post.
 func (s *Scheduler) Stop() {
-    if s.ticker != nil {
-        s.ticker.Stop()
-    }
-    s.done <- true
+    s.stopOnce.Do(func() {
+        if s.ticker != nil {
+            s.ticker.Stop()
+        }
+        close(s.done)
+    })
 }
 
 // GetSources returns the list of configured sources
-func (s *Scheduler) GetSources() []Source {
+func (s *Scheduler) GetSources() []NewsSource {
     s.mutex.RLock()
     defer s.mutex.RUnlock()
     return s.sources
@@ -114,13 +162,25 @@ func (s *Scheduler) GetStats() Stats {
     return s.stats
 }
 
-// RefreshNow triggers an immediate feed check
+// RefreshNow triggers an immediate feed check, including feeds fetched
+// moments ago
 func (s *Scheduler) RefreshNow() error {
-    return s.checkFeeds()
+    return s.checkSources("", true)
 }
 
 // checkFeeds performs the actual feed checking
 func (s *Scheduler) checkFeeds() error {
+    return s.checkSources("", false)
+}
+
+// checkSources fetches and posts the named source, or every source when
+// only is empty. With force set, recently fetched feeds are fetched again.
+func (s *Scheduler) checkSources(only string, force bool) error {
+    // A standby stays connected but leaves fetching and posting to the leader
+    if !s.bot.leader.IsLeader() {
+        return nil
+    }
+
     s.mutex.Lock()
     defer s.mutex.Unlock()
 
@@ -128,68 +188,194 @@ func (s *Scheduler) checkFeeds() error {
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
     defer cancel()
 
-    // Process feeds
-    articles, err := s.processor.ProcessFeeds(ctx, s.sources)
+    sources := s.sources
+    if only != "" {
+        sources = nil
+        for _, source := range s.sources {
+            if strings.EqualFold(source.Name, only) {
+                sources = append(sources, source)
+            }
+        }
+        if len(sources) == 0 {
+            return nil
+        }
+    }
+
+    // Process feeds; breaking stories go out as soon as their feed is in,
+    // ahead of the regular posts
+    priorities := sourcePriorities()
+    breaking := &breakingPosts{}
+    articles, err := s.processor.ProcessFeedsNotify(ctx, sources, force, func(feedArticles []*NewsArticle) {
+        s.postBreakingNow(feedArticles, priorities, breaking)
+    })
+
+    // Alert on sources breaching their response time or uptime targets
+    if current, loadErr := LoadSources(); loadErr == nil {
+        checkSourceSLAs(s.bot.discord, current)
+    }
+
     if err != nil {
         s.stats.LastError = err.Error()
         s.stats.ErrorCount++
         return err
     }
 
+    // Drop rehosted copies of the same story
+    articles = s.bot.dedupByImage(articles)
+
     // Update stats
     s.stats.LastUpdate = time.Now()
     s.stats.ArticleCount += int64(len(articles))
     s.stats.ActiveSources = len(s.sources)
 
     // Update last check times
-    for _, source := range s.sources {
+    for _, source := range sources {
         s.lastCheck[source.URL] = time.Now()
     }
 
+    // In digest-only mode articles are stored for the digest but not posted
+    if digestOnly() {
+        for _, article := range articles {
+            traceDecision(article.URL, StageMode, "digest only", "stored for the digest, not posted")
+        }
+        return nil
+    }
+
+    // Queued articles from earlier cycles compete for this cycle's slots;
+    // stories already out on the breaking path aren't posted again
+    articles = breaking.drop(articles)
+    articles = append(s.takePendingArticles(), articles...)
+    articles = s.dropCoolingDown(articles)
+    articles = s.capCycleArticles(articles, priorities)
+
     // Post articles to appropriate channels
+    s.inFlight.add(articles)
+    postCtx, stop := s.postingContext()
+    defer stop()
+    s.postArticles(postCtx, articles)
+
+    return nil
+}
+
+// postArticles sends articles to their channels. In the embed style the
+// embeds for each channel are batched into as few messages as possible.
+// Articles held up by a long slow mode backlog are queued for the next
+// cycle; ctx ending leaves the rest in flight for DrainInFlight.
+func (s *Scheduler) postArticles(ctx context.Context, articles []*NewsArticle) {
+    if defaultFormatStyle() != FormatStyleEmbed {
+        for _, article := range articles {
+            if s.stopping() {
+                return
+            }
+            err := s.postArticle(ctx, article)
+            if err != nil && ctx.Err() != nil {
+                return
+            }
+            s.inFlight.done(article)
+            if errors.Is(err, errSlowModeBacklog) {
+                s.deferArticle(article, "channel slow mode backlog; queued for the next cycle")
+                continue
+            }
+            if err != nil {
+                s.bot.logger.Error("Failed to post article: %v", err)
+                traceDecision(article.URL, StageRouting, "not posted", err.Error())
+                continue
+            }
+            traceDecision(article.URL, StageRouting, "posted", fmt.Sprintf("<#%s>", s.bot.config.CategoryChannels[article.Category]))
+            markPosted(article.URL)
+            // Add small delay between posts to avoid rate limiting
+            time.Sleep(time.Second)
+        }
+        return
+    }
+
+    // Group by channel, keeping each article's embeds together so the
+    // message they land in can be recorded for later edits
+    var channels []string
+    byChannel := make(map[string][]*NewsArticle)
+    embedsByChannel := make(map[string][][]*discordgo.MessageEmbed)
     for _, article := range articles {
-        if err := s.postArticle(article); err != nil {
+        channelID, _, embeds, err := s.formatArticle(article)
+        if err != nil {
             s.bot.logger.Error("Failed to post article: %v", err)
+            s.inFlight.done(article)
+            traceDecision(article.URL, StageRouting, "not routed", err.Error())
             continue
         }
-        // Add small delay between posts to avoid rate limiting
-        time.Sleep(time.Second)
+        if _, ok := byChannel[channelID]; !ok {
+            channels = append(channels, channelID)
+        }
+        byChannel[channelID] = append(byChannel[channelID], article)
+        embedsByChannel[channelID] = append(embedsByChannel[channelID], embeds)
     }
 
-    return nil
+    for _, channelID := range channels {
+        if s.stopping() {
+            return
+        }
+        // Only the articles of a failed message count as failed
+        errs := s.bot.postArticleEmbeds(ctx, channelID, byChannel[channelID], embedsByChannel[channelID])
+        for idx, article := range byChannel[channelID] {
+            if errs[idx] != nil && ctx.Err() != nil {
+                continue
+            }
+            s.inFlight.done(article)
+            if errors.Is(errs[idx], errSlowModeBacklog) {
+                s.deferArticle(article, fmt.Sprintf("<#%s> slow mode backlog; queued for the next cycle", channelID))
+                continue
+            }
+            if err := errs[idx]; err != nil {
+                traceDecision(article.URL, StageRouting, "post failed", fmt.Sprintf("<#%s>: %v", channelID, err))
+                continue
+            }
+            traceDecision(article.URL, StageRouting, "posted", fmt.Sprintf("<#%s>", channelID))
+            markPosted(article.URL)
+        }
+    }
 }
 
-// LoadSources loads sources from configuration
+// LoadSources loads the fetchable sources from the sources file. Paused
+// sources are left out, the same flag /sources disable and the manager set.
 func (s *Scheduler) LoadSources() error {
     s.mutex.Lock()
     defer s.mutex.Unlock()
 
-    // Load sources from config file
-    sources, err := LoadSourcesConfig(s.bot.config.SourcesPath)
+    // Load sources from the sources file, seeding it on first run
+    sources, err := LoadSources()
     if err != nil {
         return fmt.Errorf("failed to load sources: %v", err)
     }
 
     // Filter enabled sources and validate categories
-    var enabledSources []Source
+    var enabledSources []NewsSource
     for _, source := range sources {
-        if !source.Enabled {
+        if source.Paused {
             continue
         }
-        
+
+        // Hand edits to the sources file skip the checks /sources add makes
+        if err := validateSourceURL(source.URL); err != nil {
+            s.bot.logger.Warn("Invalid URL for source %s: %v", source.Name, err)
+            continue
+        }
+
         // Validate category
-        validCategory := false
-        for _, cat := range s.bot.config.Categories {
-            if source.Category == cat {
-                validCategory = true
-                break
-            }
+        categories := s.bot.config.Categories
+        if len(categories) == 0 {
+            categories = DefaultCategories
         }
-        
-        if !validCategory {
+        if !containsFold(categories, source.Category) {
             s.bot.logger.Warn("Invalid category for source %s: %s", source.Name, source.Category)
             continue
         }
+
+        if err := validateProxyURL(source.Proxy); err != nil {
+            s.bot.logger.Warn("Invalid proxy for source %s: %v", source.Name, err)
+            continue
+        }
+        if source.Proxy != "" {
+            s.bot.logger.Info("Source %s will be fetched through its own proxy", source.Name)
+        }
         
         enabledSources = append(enabledSources, source)
     }
@@ -199,34 +385,101 @@ func (s *Scheduler) LoadSources() error {
     return nil
 }
 
+// SourceDiff describes how the source list changed on reload
+type SourceDiff struct {
+    Added   []string
+    Removed []string
+    Changed []string
+}
+
+// Empty reports whether the reload changed nothing
+func (d *SourceDiff) Empty() bool {
+    return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// ReloadSources re-reads the sources file through LoadSources, validates it
+// like the initial load and reports what changed. New sources are fetched
+// from the next cycle on.
+func (s *Scheduler) ReloadSources() (*SourceDiff, error) {
+    old := make(map[string]NewsSource)
+    for _, source := range s.GetSources() {
+        old[source.Name] = source
+    }
+
+    if err := s.LoadSources(); err != nil {
+        return nil, err
+    }
+
+    diff := &SourceDiff{}
+    for _, source := range s.GetSources() {
+        previous, ok := old[source.Name]
+        switch {
+        case !ok:
+            diff.Added = append(diff.Added, source.Name)
+        case previous.URL != source.URL || previous.Category != source.Category ||
+            previous.Priority != source.Priority || previous.Proxy != source.Proxy ||
+            previous.MaxPosts != source.MaxPosts || previous.Type != source.Type ||
+            previous.Selector != source.Selector:
+            diff.Changed = append(diff.Changed, source.Name)
+        }
+        delete(old, source.Name)
+    }
+    for name := range old {
+        diff.Removed = append(diff.Removed, name)
+    }
+
+    sort.Strings(diff.Added)
+    sort.Strings(diff.Removed)
+    sort.Strings(diff.Changed)
+    return diff, nil
+}
+
 // postArticle sends an article to the appropriate Discord channel
-func (s *Scheduler) postArticle(article *NewsArticle) error {
+func (s *Scheduler) postArticle(ctx context.Context, article *NewsArticle) error {
+    channelID, content, embeds, err := s.formatArticle(article)
+    if err != nil {
+        return err
+    }
+    msg, err := postFormattedNews(ctx, s.bot.discord, channelID, content, embeds)
+    if err != nil {
+        return err
+    }
+    s.bot.recordArticlePost(msg, article, len(embeds))
+    return nil
+}
+
+// formatArticle renders an article in the configured style and picks its channel
+func (s *Scheduler) formatArticle(article *NewsArticle) (string, string, []*discordgo.MessageEmbed, error) {
     // Get channel ID for the article's category
     channelID := s.bot.config.CategoryChannels[article.Category]
     if channelID == "" {
-        return fmt.Errorf("no channel configured for category: %s", article.Category)
+        return "", "", nil, fmt.Errorf("no channel configured for category: %s", article.Category)
     }
 
-    // Create embed
-    embed := &discordgo.MessageEmbed{
-        Title:       article.Title,
-        URL:         article.URL,
-        Description: article.Description,
-        Timestamp:   article.PublishedAt.Format(time.RFC3339),
-        Color:       0x7289DA,
-        Footer: &discordgo.MessageEmbedFooter{
-            Text: fmt.Sprintf("Source: %s", article.SourceName),
-        },
+    item := &gofeed.Item{
+        Title:           article.Title,
+        Link:            article.URL,
+        Description:     article.Description,
+        PublishedParsed: &article.PublishedAt,
     }
-
-    // Add image if available
     if article.ImageURL != "" {
-        embed.Image = &discordgo.MessageEmbedImage{
-            URL: article.ImageURL,
-        }
+        item.Image = &gofeed.Image{URL: article.ImageURL}
+    }
+
+    // Fact-check results that arrived by retry are shown when the post is refreshed
+    factCheck := ""
+    if article.FactCheckResult != nil {
+        factCheck = getReliabilityBadge(article, defaultLanguage())
     }
 
-    // Send message
-    _, err := s.bot.discord.ChannelMessageSendEmbed(channelID, embed)
-    return err
+    content, embeds := FormatNewsItem(item, article.SourceName, article.Category, articleTeaser(article.Description), factCheck, nil, defaultFormatStyle(), factCheck != "", true, defaultLanguage())
+    content, embeds = applyReadingTime(content, embeds, defaultFormatStyle(), article.WordCount, defaultLanguage())
+    content, embeds = applyPodcast(content, embeds, article.AudioURL, article.AudioDuration)
+    if s.sourcePaywalled(article.SourceName) {
+        content, embeds = applyPaywall(content, embeds, article.URL)
+    }
+    if article.Edited {
+        content, embeds = markUpdated(content, embeds)
+    }
+    return channelID, content, embeds, nil
 }
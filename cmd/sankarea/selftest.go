@@ -0,0 +1,241 @@
+// cmd/sankarea/selftest.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+    "github.com/sashabaranov/go-openai"
+)
+
+// selfTestTimeout bounds each individual self-test check
+const selfTestTimeout = 15 * time.Second
+
+// selfTestResult is the outcome of one self-test check
+type selfTestResult struct {
+    Name    string
+    Passed  bool
+    Skipped bool
+    Detail  string
+}
+
+// runSelfTest checks every external dependency the bot needs and reports each result
+func (b *Bot) runSelfTest(ctx context.Context) []selfTestResult {
+    checks := []struct {
+        name string
+        run  func(context.Context) (string, error)
+    }{
+        {"Discord", b.selfTestDiscord},
+        {"Database", b.selfTestDatabase},
+        {"OpenAI", selfTestOpenAI},
+        {"Google Fact Check", selfTestGoogleFactCheck},
+        {"ClaimBuster", selfTestClaimBuster},
+        {"Feed fetch", b.selfTestFeed},
+    }
+
+    results := make([]selfTestResult, 0, len(checks))
+    for _, check := range checks {
+        checkCtx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+        detail, err := check.run(checkCtx)
+        cancel()
+
+        result := selfTestResult{Name: check.name, Passed: err == nil, Detail: detail}
+        if err == errSelfTestSkipped {
+            result.Passed, result.Skipped = true, true
+        } else if err != nil {
+            result.Detail = err.Error()
+        }
+        results = append(results, result)
+    }
+    return results
+}
+
+// errSelfTestSkipped marks a check that doesn't apply, e.g. an unset API key
+var errSelfTestSkipped = fmt.Errorf("not configured")
+
+// selfTestDiscord fetches the bot user over REST
+func (b *Bot) selfTestDiscord(ctx context.Context) (string, error) {
+    start := time.Now()
+    user, err := b.discord.User("@me", discordgo.WithContext(ctx))
+    if err != nil {
+        return "", fmt.Errorf("failed to reach Discord: %v", err)
+    }
+    return fmt.Sprintf("%s in %s", user.Username, time.Since(start).Round(time.Millisecond)), nil
+}
+
+// selfTestDatabase writes and reads back a row inside a rolled-back transaction
+func (b *Bot) selfTestDatabase(ctx context.Context) (string, error) {
+    if b.database == nil {
+        return "Database disabled", errSelfTestSkipped
+    }
+    if err := b.database.SelfTest(ctx); err != nil {
+        return "", err
+    }
+    return "Read/write OK", nil
+}
+
+// selfTestOpenAI lists models, which costs no tokens
+func selfTestOpenAI(ctx context.Context) (string, error) {
+    if cfg == nil || cfg.OpenAIAPIKey == "" {
+        return "No API key", errSelfTestSkipped
+    }
+    models, err := openai.NewClient(cfg.OpenAIAPIKey).ListModels(ctx)
+    if err != nil {
+        return "", fmt.Errorf("OpenAI API error: %v", err)
+    }
+    return fmt.Sprintf("Key valid, %d models", len(models.Models)), nil
+}
+
+// selfTestGoogleFactCheck runs a one-result claim search
+func selfTestGoogleFactCheck(ctx context.Context) (string, error) {
+    if cfg == nil || cfg.GoogleFactCheckAPIKey == "" {
+        return "No API key", errSelfTestSkipped
+    }
+    endpoint := googleFactCheckEndpoint + "?pageSize=1&query=test&key=" + url.QueryEscape(cfg.GoogleFactCheckAPIKey)
+    return selfTestHTTP(ctx, endpoint, nil)
+}
+
+// selfTestClaimBuster scores a one-word sentence
+func selfTestClaimBuster(ctx context.Context) (string, error) {
+    if cfg == nil || cfg.ClaimBustersAPIKey == "" {
+        return "No API key", errSelfTestSkipped
+    }
+    return selfTestHTTP(ctx, claimBusterEndpoint+"test", map[string]string{"x-api-key": cfg.ClaimBustersAPIKey})
+}
+
+// selfTestHTTP performs a GET and treats any 2xx response as a valid key
+func selfTestHTTP(ctx context.Context, endpoint string, headers map[string]string) (string, error) {
+    req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+    if err != nil {
+        return "", fmt.Errorf("failed to create request: %v", err)
+    }
+    for k, v := range headers {
+        req.Header.Set(k, v)
+    }
+
+    resp, err := GetHTTPClient().Do(req)
+    if err != nil {
+        return "", fmt.Errorf("request failed: %v", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return "", fmt.Errorf("HTTP %d, key rejected or service unavailable", resp.StatusCode)
+    }
+    return "Key valid", nil
+}
+
+// selfTestFeed fetches the first enabled source
+func (b *Bot) selfTestFeed(ctx context.Context) (string, error) {
+    var source *NewsSource
+    for _, src := range b.scheduler.GetSources() {
+        if !src.Paused {
+            src := src
+            source = &src
+            break
+        }
+    }
+    if source == nil {
+        return "No enabled sources", errSelfTestSkipped
+    }
+
+    client, err := httpClientFor(source.Proxy)
+    if err != nil {
+        return "", err
+    }
+    req, err := http.NewRequestWithContext(ctx, "GET", source.URL, nil)
+    if err != nil {
+        return "", fmt.Errorf("failed to create request: %v", err)
+    }
+    req.Header.Set("User-Agent", "Sankarea News Bot/1.0")
+    setSourceAuth(req, source.Headers, source.BasicAuthUser, source.BasicAuthPass)
+
+    resp, err := client.Do(req)
+    if err != nil {
+        return "", fmt.Errorf("failed to fetch %s: %v", source.Name, err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return "", fmt.Errorf("%s returned HTTP %d", source.Name, resp.StatusCode)
+    }
+
+    feed, err := gofeed.NewParser().Parse(resp.Body)
+    if err != nil {
+        return "", fmt.Errorf("failed to parse %s: %v", source.Name, err)
+    }
+    return fmt.Sprintf("%s: %d items", source.Name, len(feed.Items)), nil
+}
+
+// selfTestEmbed renders self-test results as a pass/fail embed
+func selfTestEmbed(results []selfTestResult) *discordgo.MessageEmbed {
+    failed := 0
+    fields := make([]*discordgo.MessageEmbedField, 0, len(results))
+    for _, r := range results {
+        icon := "✅"
+        switch {
+        case r.Skipped:
+            icon = "⏭️"
+        case !r.Passed:
+            icon = "❌"
+            failed++
+        }
+        fields = append(fields, &discordgo.MessageEmbedField{
+            Name:  fmt.Sprintf("%s %s", icon, r.Name),
+            Value: truncateString(r.Detail, 1024),
+        })
+    }
+
+    embed := &discordgo.MessageEmbed{
+        Title:     "🩺 Self-test passed",
+        Color:     0x43B581,
+        Fields:    fields,
+        Timestamp: time.Now().Format(time.RFC3339),
+    }
+    if failed > 0 {
+        embed.Title = fmt.Sprintf("🩺 Self-test failed (%d of %d checks)", failed, len(results))
+        embed.Color = 0xF04747
+    }
+    return embed
+}
+
+// handleSelfTestCommand runs the self-test on demand
+func (b *Bot) handleSelfTestCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    editResponseWithEmbed(s, i, selfTestEmbed(b.runSelfTest(context.Background())))
+    return nil
+}
+
+// logSelfTest runs the self-test at startup and logs any failures
+func (b *Bot) logSelfTest() {
+    var failed []string
+    for _, r := range b.runSelfTest(context.Background()) {
+        if !r.Passed {
+            failed = append(failed, fmt.Sprintf("%s (%s)", r.Name, r.Detail))
+        }
+    }
+    if len(failed) > 0 {
+        b.logger.Warn("Startup self-test failed: %s", strings.Join(failed, "; "))
+        return
+    }
+    b.logger.Info("Startup self-test passed")
+}
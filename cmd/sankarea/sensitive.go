@@ -0,0 +1,121 @@
+// cmd/sankarea/sensitive.go
+package main
+
+import (
+    "strings"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/mmcdole/gofeed"
+)
+
+// Ways to handle images on sensitive articles
+const (
+    SensitiveImageSpoiler = "spoiler" // post the image link behind a spoiler
+    SensitiveImageOmit    = "omit"    // drop the image entirely
+)
+
+// sensitiveModerationCategories are the moderation categories that mark an article as sensitive
+var sensitiveModerationCategories = []string{"violence", "sexual", "self_harm"}
+
+// sensitiveReason reports why an item should carry a content warning, or ""
+// if it shouldn't, and whether the post goes behind a spoiler. Items matching
+// content_warnings are spoilered; sources flagged sensitive always get a
+// warning; otherwise the OpenAI moderation endpoint is consulted when
+// auto-detection is enabled.
+func sensitiveReason(sourceSensitive bool, item *gofeed.Item) (string, bool) {
+    if reason := keywordWarning(item); reason != "" {
+        return reason, true
+    }
+    if sourceSensitive {
+        return "this source may include graphic content", false
+    }
+    if cfg == nil || !cfg.AutoDetectSensitive {
+        return "", false
+    }
+
+    result, err := ModerateContent(item.Title + "\n" + item.Description)
+    if err != nil {
+        Logger().Printf("Sensitive content check failed for %s: %v", item.Link, err)
+        return "", false
+    }
+    if !result.Flagged {
+        return "", false
+    }
+
+    var flagged []string
+    for _, category := range sensitiveModerationCategories {
+        if result.Categories[category] {
+            flagged = append(flagged, strings.ReplaceAll(category, "_", "-"))
+        }
+    }
+    if len(flagged) == 0 {
+        return "", false
+    }
+    return strings.Join(flagged, ", "), false
+}
+
+// sensitiveImageMode returns the configured handling for sensitive images
+func sensitiveImageMode() string {
+    if cfg != nil && cfg.SensitiveImageMode == SensitiveImageOmit {
+        return SensitiveImageOmit
+    }
+    return SensitiveImageSpoiler
+}
+
+// applySensitive adds a content warning to formatted news and removes the
+// image from its embeds, re-adding it behind a spoiler if configured. With
+// spoiler set the text goes behind spoilers too and the image is dropped.
+func applySensitive(content string, embeds []*discordgo.MessageEmbed, imageURL, reason string, spoiler bool) (string, []*discordgo.MessageEmbed) {
+    for _, embed := range embeds {
+        embed.Image = nil
+        embed.Thumbnail = nil
+    }
+
+    warning := "⚠️ **Content warning:** " + reason
+    if spoiler {
+        spoilerEmbeds(embeds, reason)
+        if content != "" {
+            content = "||" + content + "||"
+        }
+    } else if imageURL != "" && sensitiveImageMode() == SensitiveImageSpoiler {
+        warning += "\n||" + imageURL + "||"
+    }
+
+    if content == "" {
+        return warning, embeds
+    }
+    return warning + "\n" + content, embeds
+}
+
+// sendFormattedNewsWithContent sends content and embeds together in one message
+func sendFormattedNewsWithContent(s *discordgo.Session, channelID, content string, embeds []*discordgo.MessageEmbed) error {
+    if len(embeds) == 0 {
+        return sendFormattedNews(s, channelID, content, nil)
+    }
+    if err := waitForSlowMode(s, channelID); err != nil {
+        return err
+    }
+    _, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+        Content: content,
+        Embeds:  embeds,
+    })
+    return err
+}
+
+// itemImageURL returns the image attached to a feed item, if any
+func itemImageURL(item *gofeed.Item) string {
+    if item.Image != nil {
+        return item.Image.URL
+    }
+    return ""
+}
+
+// postSensitiveItem posts a single feed item with a content warning
+func postSensitiveItem(s *discordgo.Session, channelID string, source Source, item *gofeed.Item, reason string, spoiler bool) error {
+    content, embeds := FormatNewsItem(item, source.Name, source.Category, "", "", nil, defaultFormatStyle(), false, false, defaultLanguage())
+    content, embeds = applySensitive(content, embeds, itemImageURL(item), reason, spoiler)
+    if source.Paywalled {
+        content, embeds = applyPaywall(content, embeds, item.Link)
+    }
+    return sendFormattedNewsWithContent(s, channelID, content, embeds)
+}
@@ -0,0 +1,125 @@
+// cmd/sankarea/slowmode.go
+package main
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// slowModeRefreshInterval is how long a channel's slow mode setting is cached
+const slowModeRefreshInterval = 10 * time.Minute
+
+// slowModeMaxWait is the longest a post waits for its slot in a slow-moded channel
+const slowModeMaxWait = 2 * time.Minute
+
+// errSlowModeBacklog means a channel's next slow mode slot is further away
+// than the caller is willing to wait
+var errSlowModeBacklog = errors.New("channel slow mode backlog is too long")
+
+// channelSlowMode is the cached slow mode state of one channel
+type channelSlowMode struct {
+    delay     time.Duration // Discord's RateLimitPerUser; 0 when slow mode is off
+    checkedAt time.Time
+    nextPost  time.Time // earliest time the next post may go out
+}
+
+// SlowModePacer spaces out posts to channels that have slow mode enabled,
+// so Discord doesn't reject them
+type SlowModePacer struct {
+    mu       sync.Mutex
+    channels map[string]*channelSlowMode
+}
+
+// slowModePacer is shared by every news posting path
+var slowModePacer = NewSlowModePacer()
+
+// NewSlowModePacer creates an empty pacer
+func NewSlowModePacer() *SlowModePacer {
+    return &SlowModePacer{channels: make(map[string]*channelSlowMode)}
+}
+
+// Wait blocks until a post to the channel is allowed under its slow mode and
+// reserves that slot. Channels without slow mode return immediately. A slot
+// more than maxWait away isn't reserved and errSlowModeBacklog is returned;
+// when ctx ends during the wait the slot is given back and ctx's error returned.
+func (p *SlowModePacer) Wait(ctx context.Context, s *discordgo.Session, channelID string, maxWait time.Duration) error {
+    delay := p.delay(s, channelID)
+    if delay <= 0 {
+        return nil
+    }
+
+    p.mu.Lock()
+    state := p.channels[channelID]
+    now := time.Now()
+    wait := state.nextPost.Sub(now)
+    if wait < 0 {
+        wait = 0
+    }
+    if wait > maxWait {
+        p.mu.Unlock()
+        return errSlowModeBacklog
+    }
+    previous := state.nextPost
+    reserved := now.Add(wait + delay)
+    state.nextPost = reserved
+    p.mu.Unlock()
+
+    if wait == 0 {
+        return nil
+    }
+    timer := time.NewTimer(wait)
+    defer timer.Stop()
+    select {
+    case <-timer.C:
+        return nil
+    case <-ctx.Done():
+        p.mu.Lock()
+        // Keep the reservation if a later post already queued behind it
+        if state.nextPost.Equal(reserved) {
+            state.nextPost = previous
+        }
+        p.mu.Unlock()
+        return ctx.Err()
+    }
+}
+
+// delay returns the channel's slow mode interval, refreshing it from Discord
+// when the cached value is stale. Lookup failures keep the last known value.
+func (p *SlowModePacer) delay(s *discordgo.Session, channelID string) time.Duration {
+    p.mu.Lock()
+    state, ok := p.channels[channelID]
+    if ok && time.Since(state.checkedAt) < slowModeRefreshInterval {
+        p.mu.Unlock()
+        return state.delay
+    }
+    if !ok {
+        state = &channelSlowMode{}
+        p.channels[channelID] = state
+    }
+    // Mark as checked now so concurrent posts don't all hit the API
+    state.checkedAt = time.Now()
+    p.mu.Unlock()
+
+    channel, err := s.Channel(channelID)
+    if err != nil {
+        Logger().Printf("Failed to check slow mode for channel %s: %v", channelID, err)
+        p.mu.Lock()
+        defer p.mu.Unlock()
+        return state.delay
+    }
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    state.delay = time.Duration(channel.RateLimitPerUser) * time.Second
+    return state.delay
+}
+
+// waitForSlowMode paces a post to the channel through the shared pacer,
+// waiting at most slowModeMaxWait
+func waitForSlowMode(s *discordgo.Session, channelID string) error {
+    return slowModePacer.Wait(context.Background(), s, channelID, slowModeMaxWait)
+}
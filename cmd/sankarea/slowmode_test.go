@@ -0,0 +1,63 @@
+// cmd/sankarea/slowmode_test.go
+package main
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+)
+
+// cachedPacer returns a pacer that already knows the channel's slow mode,
+// so Wait never asks Discord
+func cachedPacer(channelID string, delay time.Duration, nextPost time.Time) *SlowModePacer {
+    p := NewSlowModePacer()
+    p.channels[channelID] = &channelSlowMode{delay: delay, checkedAt: time.Now(), nextPost: nextPost}
+    return p
+}
+
+func TestSlowModePacerWait(t *testing.T) {
+    ctx := context.Background()
+
+    off := cachedPacer("c", 0, time.Now().Add(time.Hour))
+    if err := off.Wait(ctx, nil, "c", time.Minute); err != nil {
+        t.Errorf("Wait without slow mode = %v, want nil", err)
+    }
+
+    // The first post goes out at once and reserves the next slot
+    p := cachedPacer("c", time.Hour, time.Time{})
+    if err := p.Wait(ctx, nil, "c", time.Minute); err != nil {
+        t.Fatalf("first Wait = %v, want nil", err)
+    }
+    reserved := p.channels["c"].nextPost
+    if until := time.Until(reserved); until < 59*time.Minute {
+        t.Fatalf("next slot in %v, want about an hour", until)
+    }
+
+    // The next slot is further away than the caller waits for
+    if err := p.Wait(ctx, nil, "c", time.Minute); !errors.Is(err, errSlowModeBacklog) {
+        t.Errorf("Wait past maxWait = %v, want errSlowModeBacklog", err)
+    }
+    if !p.channels["c"].nextPost.Equal(reserved) {
+        t.Error("a refused Wait reserved a slot")
+    }
+}
+
+func TestSlowModePacerWaitCanceled(t *testing.T) {
+    previous := time.Now().Add(time.Minute)
+    p := cachedPacer("c", time.Hour, previous)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    time.AfterFunc(10*time.Millisecond, cancel)
+    start := time.Now()
+    if err := p.Wait(ctx, nil, "c", time.Hour); !errors.Is(err, context.Canceled) {
+        t.Fatalf("canceled Wait = %v, want context.Canceled", err)
+    }
+    if elapsed := time.Since(start); elapsed > 5*time.Second {
+        t.Errorf("canceled Wait returned after %v", elapsed)
+    }
+    // The slot it gave up is free again
+    if !p.channels["c"].nextPost.Equal(previous) {
+        t.Errorf("next slot = %v after cancel, want %v", p.channels["c"].nextPost, previous)
+    }
+}
@@ -0,0 +1,189 @@
+// cmd/sankarea/source_export.go
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "encoding/xml"
+    "fmt"
+    "net/http"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+    "gopkg.in/yaml.v2"
+)
+
+// Source export formats
+const (
+    SourceExportYAML     = "yaml" // same layout as sources.yml
+    SourceExportJSON     = "json"
+    SourceExportOPML     = "opml"      // for feed readers
+    SourceExportOPMLFull = "opml-full" // OPML with every setting, for /sources import
+)
+
+// opmlDocument is the OPML 2.0 subset needed to list feeds by category
+type opmlDocument struct {
+    XMLName   xml.Name      `xml:"opml"`
+    Version   string        `xml:"version,attr"`
+    Namespace string        `xml:"xmlns:sankarea,attr,omitempty"` // declared by full exports
+    Title     string        `xml:"head>title"`
+    Created   string        `xml:"head>dateCreated"`
+    Body      []opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline is a category folder or a feed
+type opmlOutline struct {
+    Text     string        `xml:"text,attr"`
+    Title    string        `xml:"title,attr,omitempty"`
+    Type     string        `xml:"type,attr,omitempty"`
+    XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+    Settings []xml.Attr    `xml:",any,attr"` // sankarea:* source settings in full exports
+    Outlines []opmlOutline `xml:"outline,omitempty"`
+}
+
+// ExportSources serializes sources with credentials redacted. It returns
+// the data, its content type and a file name.
+func ExportSources(sources []NewsSource, format string) ([]byte, string, string, error) {
+    redacted := make([]NewsSource, len(sources))
+    for idx, source := range sources {
+        redacted[idx] = source.Redacted()
+    }
+    stamp := time.Now().UTC().Format("20060102")
+
+    switch strings.ToLower(format) {
+    case "", SourceExportYAML:
+        data, err := yaml.Marshal(sourcesFile{Sources: redacted})
+        if err != nil {
+            return nil, "", "", fmt.Errorf("failed to marshal sources: %v", err)
+        }
+        return data, "application/x-yaml", "sources-" + stamp + ".yml", nil
+    case SourceExportJSON:
+        data, err := json.MarshalIndent(redacted, "", "  ")
+        if err != nil {
+            return nil, "", "", fmt.Errorf("failed to marshal sources: %v", err)
+        }
+        return data, "application/json", "sources-" + stamp + ".json", nil
+    case SourceExportOPML:
+        data, err := sourcesOPML(redacted, false)
+        if err != nil {
+            return nil, "", "", err
+        }
+        return data, "text/x-opml", "sources-" + stamp + ".opml", nil
+    case SourceExportOPMLFull:
+        data, err := sourcesOPML(redacted, true)
+        if err != nil {
+            return nil, "", "", err
+        }
+        return data, "text/x-opml", "sources-full-" + stamp + ".opml", nil
+    default:
+        return nil, "", "", fmt.Errorf("unknown export format %q (use yaml, json, opml or opml-full)", format)
+    }
+}
+
+// sourcesOPML groups feeds into one outline per category. HTML sources are
+// left out since feed readers can't use them, unless full is set: then every
+// source is listed with its settings so /sources import can restore it.
+func sourcesOPML(sources []NewsSource, full bool) ([]byte, error) {
+    byCategory := make(map[string][]opmlOutline)
+    for _, source := range sources {
+        if source.Type == SourceTypeHTML && !full {
+            continue
+        }
+        outline := opmlOutline{
+            Text:   source.Name,
+            Title:  source.Name,
+            Type:   "rss",
+            XMLURL: source.URL,
+        }
+        if full {
+            outline.Settings = opmlSettings(source)
+        }
+        byCategory[source.Category] = append(byCategory[source.Category], outline)
+    }
+
+    categories := make([]string, 0, len(byCategory))
+    for category := range byCategory {
+        categories = append(categories, category)
+    }
+    sort.Strings(categories)
+
+    doc := opmlDocument{
+        Version: "2.0",
+        Title:   AppName + " sources",
+        Created: time.Now().UTC().Format(time.RFC1123Z),
+    }
+    if full {
+        doc.Namespace = opmlNamespace
+    }
+    for _, category := range categories {
+        name := category
+        if name == "" {
+            name = "Uncategorized"
+        }
+        doc.Body = append(doc.Body, opmlOutline{Text: name, Outlines: byCategory[category]})
+    }
+
+    data, err := xml.MarshalIndent(doc, "", "  ")
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal OPML: %v", err)
+    }
+    return append([]byte(xml.Header), data...), nil
+}
+
+// handleSourcesExport serves /api/sources/export?format=yaml|json|opml|opml-full
+func (d *Dashboard) handleSourcesExport(w http.ResponseWriter, r *http.Request) {
+    sources, err := LoadSources()
+    if err != nil {
+        http.Error(w, "Failed to load sources", http.StatusInternalServerError)
+        Logger().Printf("Failed to load sources: %v", err)
+        return
+    }
+
+    data, contentType, filename, err := ExportSources(sources, r.URL.Query().Get("format"))
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", contentType)
+    w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+    if _, err := w.Write(data); err != nil {
+        Logger().Printf("Failed to write sources export: %v", err)
+    }
+}
+
+// handleExportSources attaches the source list to the reply in the chosen format
+func (b *Bot) handleExportSources(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    sources, err := LoadSources()
+    if err != nil {
+        respondWithError(s, i, "Failed to load sources")
+        return fmt.Errorf("failed to load sources: %v", err)
+    }
+
+    format := getOptionString(i.ApplicationCommandData().Options, "format")
+    data, contentType, filename, err := ExportSources(sources, format)
+    if err != nil {
+        respondWithError(s, i, err.Error())
+        return nil
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: fmt.Sprintf("📦 %d sources (credentials redacted)", len(sources)),
+            Files: []*discordgo.File{{
+                Name:        filename,
+                ContentType: contentType,
+                Reader:      bytes.NewReader(data),
+            }},
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
@@ -0,0 +1,393 @@
+// cmd/sankarea/source_manager.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// sourceManagePrefix starts the custom ID of every source manager component:
+// srcmgr:<action>:<page or source name>
+const sourceManagePrefix = "srcmgr:"
+
+// sourceMenuSize is how many sources one select menu can list
+const sourceMenuSize = 25
+
+// sortedSources returns the sources ordered by name
+func sortedSources(sources []NewsSource) []NewsSource {
+    sorted := make([]NewsSource, len(sources))
+    copy(sorted, sources)
+    sort.SliceStable(sorted, func(i, j int) bool {
+        return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+    })
+    return sorted
+}
+
+// findSource returns the named source, matching case-insensitively
+func findSource(sources []NewsSource, name string) (NewsSource, bool) {
+    for _, src := range sources {
+        if strings.EqualFold(src.Name, name) {
+            return src, true
+        }
+    }
+    return NewsSource{}, false
+}
+
+// sourceStatus is a source's state in one word, with its emoji
+func sourceStatus(src NewsSource) string {
+    switch {
+    case src.Broken:
+        return "⚠️ Broken"
+    case src.Paused:
+        return "⏸️ Paused"
+    default:
+        return "✅ Active"
+    }
+}
+
+// sourceMenu lists one page of sources in a select menu, with page buttons
+// when they don't fit in one
+func sourceMenu(sources []NewsSource, page int) []discordgo.MessageComponent {
+    sources = sortedSources(sources)
+    pages := (len(sources) + sourceMenuSize - 1) / sourceMenuSize
+    if page < 0 || page >= pages {
+        page = 0
+    }
+    start := page * sourceMenuSize
+    end := min(start+sourceMenuSize, len(sources))
+
+    options := make([]discordgo.SelectMenuOption, 0, end-start)
+    for _, src := range sources[start:end] {
+        options = append(options, discordgo.SelectMenuOption{
+            Label:       truncateString(src.Name, 100),
+            Value:       src.Name,
+            Description: truncateString(fmt.Sprintf("%s · %s", sourceStatus(src), src.Category), 100),
+        })
+    }
+
+    components := []discordgo.MessageComponent{
+        discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+            discordgo.SelectMenu{
+                CustomID:    fmt.Sprintf("%sselect:%d", sourceManagePrefix, page),
+                Placeholder: "Pick a source to manage",
+                Options:     options,
+            },
+        }},
+    }
+    if pages > 1 {
+        components = append(components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+            discordgo.Button{
+                Label:    "◀",
+                Style:    discordgo.SecondaryButton,
+                CustomID: fmt.Sprintf("%spage:%d", sourceManagePrefix, page-1),
+                Disabled: page == 0,
+            },
+            discordgo.Button{
+                Label:    fmt.Sprintf("%d/%d", page+1, pages),
+                Style:    discordgo.SecondaryButton,
+                CustomID: sourceManagePrefix + "counter:",
+                Disabled: true,
+            },
+            discordgo.Button{
+                Label:    "▶",
+                Style:    discordgo.SecondaryButton,
+                CustomID: fmt.Sprintf("%spage:%d", sourceManagePrefix, page+1),
+                Disabled: page == pages-1,
+            },
+        }})
+    }
+    return components
+}
+
+// sourceActions are the buttons for one picked source
+func sourceActions(src NewsSource) []discordgo.MessageComponent {
+    toggle := discordgo.Button{
+        Label:    "Disable",
+        Style:    discordgo.SecondaryButton,
+        CustomID: sourceManagePrefix + "disable:" + src.Name,
+    }
+    if src.Paused {
+        toggle = discordgo.Button{
+            Label:    "Enable",
+            Style:    discordgo.SuccessButton,
+            CustomID: sourceManagePrefix + "enable:" + src.Name,
+        }
+    }
+    return []discordgo.MessageComponent{
+        discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+            toggle,
+            discordgo.Button{
+                Label:    "Info",
+                Style:    discordgo.PrimaryButton,
+                CustomID: sourceManagePrefix + "info:" + src.Name,
+            },
+            discordgo.Button{
+                Label:    "Delete",
+                Style:    discordgo.DangerButton,
+                CustomID: sourceManagePrefix + "delete:" + src.Name,
+            },
+            discordgo.Button{
+                Label:    "Back",
+                Style:    discordgo.SecondaryButton,
+                CustomID: sourceManagePrefix + "page:0",
+            },
+        }},
+    }
+}
+
+// sourceInfoEmbed shows a source's settings, without its credentials
+func sourceInfoEmbed(src NewsSource) *discordgo.MessageEmbed {
+    sourceType := src.Type
+    if sourceType == "" {
+        sourceType = SourceTypeRSS
+    }
+    category := src.Category
+    if category == "" {
+        category = "none"
+    }
+    status := sourceStatus(src)
+    if src.Broken && src.BrokenReason != "" {
+        status += ": " + src.BrokenReason
+    }
+
+    fields := []*discordgo.MessageEmbedField{
+        {Name: "Status", Value: status, Inline: true},
+        {Name: "Category", Value: category, Inline: true},
+        {Name: "Type", Value: sourceType, Inline: true},
+        {Name: "Priority", Value: strconv.Itoa(sourceTier(src.Priority)), Inline: true},
+        {Name: "Fact check", Value: strconv.FormatBool(src.FactCheck), Inline: true},
+    }
+    if src.Language != "" {
+        fields = append(fields, &discordgo.MessageEmbedField{Name: "Language", Value: src.Language, Inline: true})
+    }
+    if src.Trust > 0 || src.Bias != "" {
+        bias := src.Bias
+        if bias == "" {
+            bias = "unrated"
+        }
+        fields = append(fields, &discordgo.MessageEmbedField{
+            Name:   "Profile",
+            Value:  fmt.Sprintf("%s · trust %.2f", bias, src.Trust),
+            Inline: true,
+        })
+    }
+    if !src.Added.IsZero() {
+        added := src.Added.Format("2006-01-02")
+        if src.AddedBy != "" {
+            added += fmt.Sprintf(" by <@%s>", src.AddedBy)
+        }
+        fields = append(fields, &discordgo.MessageEmbedField{Name: "Added", Value: added, Inline: true})
+    }
+    if boost, ok := fetchBoost(src.Name); ok {
+        fields = append(fields, &discordgo.MessageEmbedField{Name: "Fetch boost", Value: describeFetchBoost(boost), Inline: false})
+    }
+    if !src.LastValidated.IsZero() {
+        fields = append(fields, &discordgo.MessageEmbedField{
+            Name:   "Last validated",
+            Value:  fmt.Sprintf("<t:%d:R>", src.LastValidated.Unix()),
+            Inline: true,
+        })
+    }
+
+    return &discordgo.MessageEmbed{
+        Title:       src.Name,
+        URL:         src.URL,
+        Description: src.URL,
+        Color:       0x7289DA,
+        Fields:      fields,
+        Timestamp:   time.Now().Format(time.RFC3339),
+    }
+}
+
+// handleSourceInfo shows one source's settings and any running fetch boost
+func (b *Bot) handleSourceInfo(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    name := strings.TrimSpace(getOptionString(i.ApplicationCommandData().Options, "name"))
+    if name == "" {
+        respondWithError(s, i, "Please specify the source name")
+        return nil
+    }
+    sources, err := LoadSources()
+    if err != nil {
+        respondWithError(s, i, "Failed to load sources")
+        return fmt.Errorf("failed to load sources: %v", err)
+    }
+    src, ok := findSource(sources, name)
+    if !ok {
+        respondWithError(s, i, "Source not found")
+        return nil
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Embeds: []*discordgo.MessageEmbed{sourceInfoEmbed(src)},
+            Flags:  discordgo.MessageFlagsEphemeral,
+        },
+    })
+}
+
+// setSourcePaused enables or disables a source in the sources file
+func setSourcePaused(actor, name string, paused bool) error {
+    return UpdateSources(actor, func(sources []NewsSource) ([]NewsSource, error) {
+        for idx := range sources {
+            if strings.EqualFold(sources[idx].Name, name) {
+                sources[idx].Paused = paused
+                return sources, nil
+            }
+        }
+        return nil, errSourceNotFound
+    })
+}
+
+// deleteSource removes a source from the sources file
+func deleteSource(actor, name string) error {
+    return UpdateSources(actor, func(sources []NewsSource) ([]NewsSource, error) {
+        for idx := range sources {
+            if strings.EqualFold(sources[idx].Name, name) {
+                return append(sources[:idx], sources[idx+1:]...), nil
+            }
+        }
+        return nil, errSourceNotFound
+    })
+}
+
+// handleManageSources opens the source manager: a select menu of sources,
+// then buttons to act on the one picked
+func (b *Bot) handleManageSources(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    sources, err := LoadSources()
+    if err != nil {
+        respondWithError(s, i, "Failed to load sources")
+        return fmt.Errorf("failed to load sources: %v", err)
+    }
+    if len(sources) == 0 {
+        respondWithError(s, i, "No sources configured")
+        return nil
+    }
+
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content:    fmt.Sprintf("🗂️ **Source manager** · %d sources", len(sources)),
+            Flags:      discordgo.MessageFlagsEphemeral,
+            Components: sourceMenu(sources, 0),
+        },
+    })
+}
+
+// handleSourceManageComponent handles the source manager's select menu and
+// buttons by updating the manager message in place
+func (b *Bot) handleSourceManageComponent(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to manage sources")
+        return nil
+    }
+
+    data := i.MessageComponentData()
+    action, arg, ok := strings.Cut(strings.TrimPrefix(data.CustomID, sourceManagePrefix), ":")
+    if !ok {
+        return fmt.Errorf("malformed source manager component %q", data.CustomID)
+    }
+
+    update := func(content string, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) error {
+        embeds := []*discordgo.MessageEmbed{}
+        if embed != nil {
+            embeds = append(embeds, embed)
+        }
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseUpdateMessage,
+            Data: &discordgo.InteractionResponseData{
+                Content:    content,
+                Embeds:     embeds,
+                Components: components,
+            },
+        })
+    }
+
+    sources, err := LoadSources()
+    if err != nil {
+        update("❌ Failed to load sources", nil, []discordgo.MessageComponent{})
+        return fmt.Errorf("failed to load sources: %v", err)
+    }
+
+    if action == "page" {
+        page, _ := strconv.Atoi(arg)
+        return update(fmt.Sprintf("🗂️ **Source manager** · %d sources", len(sources)), nil, sourceMenu(sources, page))
+    }
+
+    name := arg
+    if action == "select" {
+        if len(data.Values) == 0 {
+            return fmt.Errorf("source manager select sent no value")
+        }
+        name = data.Values[0]
+    }
+    src, found := findSource(sources, name)
+    if !found {
+        return update(fmt.Sprintf("❌ Source **%s** no longer exists", name), nil, sourceMenu(sources, 0))
+    }
+
+    actor := interactionUserID(i)
+    switch action {
+    case "select", "show":
+        return update(fmt.Sprintf("🗂️ **%s** · %s", src.Name, sourceStatus(src)), nil, sourceActions(src))
+
+    case "enable", "disable":
+        src.Paused = action == "disable"
+        if err := setSourcePaused(actor, src.Name, src.Paused); err != nil {
+            update("❌ Failed to update source", nil, sourceActions(src))
+            return fmt.Errorf("failed to %s %s: %v", action, src.Name, err)
+        }
+        b.logger.Info("Source %s %sd by %s", src.Name, action, actor)
+        return update(fmt.Sprintf("✅ **%s** %sd · %s", src.Name, action, sourceStatus(src)), nil, sourceActions(src))
+
+    case "info":
+        return update("", sourceInfoEmbed(src), sourceActions(src))
+
+    case "delete":
+        return update(fmt.Sprintf("⚠️ Delete **%s** from the sources file? Its stored articles are kept.", src.Name), nil,
+            []discordgo.MessageComponent{
+                discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+                    discordgo.Button{
+                        Label:    "Delete",
+                        Style:    discordgo.DangerButton,
+                        CustomID: sourceManagePrefix + "confirmdelete:" + src.Name,
+                    },
+                    discordgo.Button{
+                        Label:    "Cancel",
+                        Style:    discordgo.SecondaryButton,
+                        CustomID: sourceManagePrefix + "show:" + src.Name,
+                    },
+                }},
+            })
+
+    case "confirmdelete":
+        if err := deleteSource(actor, src.Name); err != nil {
+            update("❌ Failed to delete source", nil, sourceActions(src))
+            return fmt.Errorf("failed to delete %s: %v", src.Name, err)
+        }
+        b.logger.Info("Source %s deleted by %s", src.Name, actor)
+        remaining, err := LoadSources()
+        if err != nil || len(remaining) == 0 {
+            return update(fmt.Sprintf("🗑️ Deleted **%s**", src.Name), nil, []discordgo.MessageComponent{})
+        }
+        return update(fmt.Sprintf("🗑️ Deleted **%s** · %d sources left", src.Name, len(remaining)), nil, sourceMenu(remaining, 0))
+
+    default:
+        return fmt.Errorf("unknown source manager action %q", action)
+    }
+}
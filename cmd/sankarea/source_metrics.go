@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// slaAlertCooldown is the minimum time between SLA alerts for the same source
+const slaAlertCooldown = 6 * time.Hour
+
+var (
+	slaAlertMutex sync.Mutex
+	slaLastAlert  = make(map[string]time.Time)
+)
+
+// defaultSourceMetricsSmoothing weights each new fetch at 10%
+const defaultSourceMetricsSmoothing = 0.1
+
+// sourceMetricsSmoothing returns the configured EMA smoothing factor
+func sourceMetricsSmoothing() float64 {
+	if cfg != nil && cfg.SourceMetricsSmoothing > 0 && cfg.SourceMetricsSmoothing <= 1 {
+		return cfg.SourceMetricsSmoothing
+	}
+	return defaultSourceMetricsSmoothing
+}
+
+// SourceMetrics are a source's fetch response time and uptime averages
+type SourceMetrics struct {
+	FetchAttempts   int     `json:"fetch_attempts"`
+	AvgResponseTime float64 `json:"avg_response_time_ms"` // in milliseconds
+	UptimePercent   float64 `json:"uptime_percent"`
+}
+
+// record adds the outcome of a fetch to the exponential moving averages.
+// Until there are enough samples for the smoothing factor the plain mean is
+// used, so early fetches aren't overweighted and one slow or failed fetch
+// moves the averages by only the smoothing factor.
+func (m *SourceMetrics) record(responseTime time.Duration, success bool, smoothing float64) {
+	m.FetchAttempts++
+	weight := smoothing
+	if mean := 1 / float64(m.FetchAttempts); mean > weight {
+		weight = mean
+	}
+
+	ms := float64(responseTime.Milliseconds())
+	m.AvgResponseTime += weight * (ms - m.AvgResponseTime)
+
+	outcome := 0.0
+	if success {
+		outcome = 100.0
+	}
+	m.UptimePercent += weight * (outcome - m.UptimePercent)
+}
+
+// updateSourceMetrics records the outcome of a fetch of the named source.
+// Metrics are kept in the state file rather than sources.yml so fetches
+// don't rewrite the source list.
+func updateSourceMetrics(name string, responseTime time.Duration, success bool) {
+	smoothing := sourceMetricsSmoothing()
+	if err := UpdateState(func(s *State) {
+		if s.SourceMetrics == nil {
+			s.SourceMetrics = make(map[string]SourceMetrics)
+		}
+		metrics := s.SourceMetrics[name]
+		metrics.record(responseTime, success, smoothing)
+		s.SourceMetrics[name] = metrics
+	}); err != nil {
+		Logger().Printf("Failed to record fetch metrics for %s: %v", name, err)
+	}
+}
+
+// sourceMetrics returns the fetch metrics of the sources fetched so far, by name
+func sourceMetrics() map[string]SourceMetrics {
+	return GetState().SourceMetrics
+}
+
+// checkSourceSLAs alerts the error channel about sources that are too slow or failing too often
+func checkSourceSLAs(s *discordgo.Session, sources []NewsSource) {
+	if cfg.ErrorChannelID == "" {
+		return
+	}
+
+	slaAlertMutex.Lock()
+	defer slaAlertMutex.Unlock()
+
+	metrics := sourceMetrics()
+	var lines []string
+	for _, src := range sources {
+		m := metrics[src.Name]
+		if m.FetchAttempts == 0 {
+			continue
+		}
+
+		var problems []string
+		if m.AvgResponseTime > float64(cfg.SlowSourceThresholdMs) {
+			problems = append(problems, fmt.Sprintf("avg response %.0fms > %dms", m.AvgResponseTime, cfg.SlowSourceThresholdMs))
+		}
+		if m.UptimePercent < cfg.MinSourceUptimePercent {
+			problems = append(problems, fmt.Sprintf("uptime %.1f%% < %.1f%%", m.UptimePercent, cfg.MinSourceUptimePercent))
+		}
+		if len(problems) == 0 {
+			continue
+		}
+
+		if time.Since(slaLastAlert[src.Name]) < slaAlertCooldown {
+			continue
+		}
+		slaLastAlert[src.Name] = time.Now()
+		lines = append(lines, fmt.Sprintf("• **%s**: %s", src.Name, strings.Join(problems, ", ")))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	msg := "⚠️ **Source SLA Alert**\n" + strings.Join(lines, "\n")
+	if _, err := s.ChannelMessageSend(cfg.ErrorChannelID, truncateString(msg, 2000)); err != nil {
+		Logger().Printf("Failed to send source SLA alert: %v", err)
+	}
+}
+
+// slowestSources returns up to n sources with the highest average response time
+func slowestSources(sources []NewsSource, n int) []SlowSourceStat {
+	metrics := sourceMetrics()
+	var measured []SlowSourceStat
+	for _, src := range sources {
+		if m := metrics[src.Name]; m.FetchAttempts > 0 {
+			measured = append(measured, SlowSourceStat{
+				Name:            src.Name,
+				AvgResponseTime: m.AvgResponseTime,
+				UptimePercent:   m.UptimePercent,
+			})
+		}
+	}
+
+	sort.Slice(measured, func(i, j int) bool {
+		return measured[i].AvgResponseTime > measured[j].AvgResponseTime
+	})
+
+	if len(measured) > n {
+		measured = measured[:n]
+	}
+	return measured
+}
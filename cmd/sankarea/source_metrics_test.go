@@ -0,0 +1,121 @@
+// cmd/sankarea/source_metrics_test.go
+package main
+
+import (
+    "math"
+    "testing"
+    "time"
+)
+
+type fetchSample struct {
+    ms int
+    ok bool
+}
+
+// repeatSamples returns the samples repeated n times
+func repeatSamples(n int, samples ...fetchSample) []fetchSample {
+    var out []fetchSample
+    for i := 0; i < n; i++ {
+        out = append(out, samples...)
+    }
+    return out
+}
+
+func TestSourceMetricsRecord(t *testing.T) {
+    steady := repeatSamples(20, fetchSample{100, true})
+    tests := []struct {
+        name      string
+        smoothing float64
+        samples   []fetchSample
+        // From sample settle on (or only at the end when 0), the averages
+        // stay within these ranges
+        settle               int
+        avgMin, avgMax       float64
+        uptimeMin, uptimeMax float64
+    }{
+        {
+            name:      "first sample sets the averages",
+            smoothing: 0.2,
+            samples:   []fetchSample{{250, true}},
+            avgMin:    250, avgMax: 250,
+            uptimeMin: 100, uptimeMax: 100,
+        },
+        {
+            name:      "zero first sample",
+            smoothing: 0.2,
+            samples:   []fetchSample{{0, false}, {100, true}},
+            avgMin:    50, avgMax: 50,
+            uptimeMin: 50, uptimeMax: 50,
+        },
+        {
+            name:      "plain mean until the smoothing factor takes over",
+            smoothing: 0.2,
+            samples:   []fetchSample{{100, true}, {200, true}, {300, false}},
+            avgMin:    200, avgMax: 200,
+            uptimeMin: 66.66, uptimeMax: 66.67,
+        },
+        {
+            name:      "zero smoothing keeps the plain mean",
+            smoothing: 0,
+            samples:   []fetchSample{{0, true}, {0, true}, {300, true}},
+            avgMin:    100, avgMax: 100,
+            uptimeMin: 100, uptimeMax: 100,
+        },
+        {
+            name:      "one slow fetch moves the average by the smoothing factor",
+            smoothing: 0.1,
+            samples:   append(append([]fetchSample(nil), steady...), fetchSample{1100, true}),
+            avgMin:    200, avgMax: 200,
+            uptimeMin: 100, uptimeMax: 100,
+        },
+        {
+            name:      "one failure costs the smoothing factor of uptime",
+            smoothing: 0.1,
+            samples:   append(append([]fetchSample(nil), steady...), fetchSample{100, false}),
+            avgMin:    100, avgMax: 100,
+            uptimeMin: 90, uptimeMax: 90,
+        },
+        {
+            name:      "noisy sequence settles around its mean",
+            smoothing: 0.1,
+            samples: repeatSamples(20,
+                fetchSample{100, true}, fetchSample{300, true}, fetchSample{100, true}, fetchSample{300, true},
+                fetchSample{100, true}, fetchSample{300, true}, fetchSample{100, true}, fetchSample{300, true},
+                fetchSample{100, true}, fetchSample{300, false}),
+            settle: 50,
+            avgMin: 180, avgMax: 220,
+            uptimeMin: 80, uptimeMax: 100,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var m SourceMetrics
+            lo, hi := math.Inf(1), math.Inf(-1)
+            for idx, sample := range tt.samples {
+                m.record(time.Duration(sample.ms)*time.Millisecond, sample.ok, tt.smoothing)
+                lo, hi = math.Min(lo, float64(sample.ms)), math.Max(hi, float64(sample.ms))
+
+                if m.FetchAttempts != idx+1 {
+                    t.Fatalf("sample %d: %d fetch attempts", idx, m.FetchAttempts)
+                }
+                // The averages never leave the range of the samples seen so far
+                if math.IsNaN(m.AvgResponseTime) || m.AvgResponseTime < lo-1e-9 || m.AvgResponseTime > hi+1e-9 {
+                    t.Fatalf("sample %d: average %.2fms outside [%v, %v]", idx, m.AvgResponseTime, lo, hi)
+                }
+                if math.IsNaN(m.UptimePercent) || m.UptimePercent < -1e-9 || m.UptimePercent > 100+1e-9 {
+                    t.Fatalf("sample %d: uptime %.2f%% outside [0, 100]", idx, m.UptimePercent)
+                }
+
+                if (tt.settle > 0 && idx >= tt.settle) || idx == len(tt.samples)-1 {
+                    if m.AvgResponseTime < tt.avgMin-0.01 || m.AvgResponseTime > tt.avgMax+0.01 {
+                        t.Errorf("sample %d: average %.2fms, want within [%v, %v]", idx, m.AvgResponseTime, tt.avgMin, tt.avgMax)
+                    }
+                    if m.UptimePercent < tt.uptimeMin-0.01 || m.UptimePercent > tt.uptimeMax+0.01 {
+                        t.Errorf("sample %d: uptime %.2f%%, want within [%v, %v]", idx, m.UptimePercent, tt.uptimeMin, tt.uptimeMax)
+                    }
+                }
+            }
+        })
+    }
+}
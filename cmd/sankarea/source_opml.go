@@ -0,0 +1,283 @@
+// cmd/sankarea/source_opml.go
+package main
+
+import (
+    "encoding/xml"
+    "fmt"
+    "io"
+    "net/url"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Namespace and prefix of the source settings in a full OPML export
+const (
+    opmlNamespace = "https://github.com/NullMeDev/Sankarea/opml"
+    opmlPrefix    = "sankarea"
+)
+
+// maxImportSize caps an OPML file given to /sources import
+const maxImportSize = 2 << 20
+
+// opmlSettings returns a source's settings as namespaced outline attributes.
+// Credentials are left out; an import keeps those of the source it replaces.
+func opmlSettings(source NewsSource) []xml.Attr {
+    var attrs []xml.Attr
+    add := func(key, value string) {
+        if value != "" {
+            attrs = append(attrs, xml.Attr{Name: xml.Name{Local: opmlPrefix + ":" + key}, Value: value})
+        }
+    }
+    flag := func(key string, value bool) {
+        if value {
+            add(key, "true")
+        }
+    }
+    number := func(key string, value int) {
+        if value != 0 {
+            add(key, strconv.Itoa(value))
+        }
+    }
+
+    add("category", source.Category)
+    add("fact_check", strconv.FormatBool(source.FactCheck))
+    flag("paused", source.Paused)
+    number("priority", source.Priority)
+    if u, err := url.Parse(source.Proxy); err == nil && u.User == nil {
+        add("proxy", source.Proxy)
+    }
+    flag("sensitive", source.Sensitive)
+    number("max_posts", source.MaxPosts)
+    add("language", source.Language)
+    if !source.Added.IsZero() {
+        add("added", source.Added.UTC().Format(time.RFC3339))
+    }
+    add("added_by", source.AddedBy)
+    flag("force_category", source.ForceCategory)
+    flag("paywalled", source.Paywalled)
+    number("repost_cooldown_hours", source.RepostCooldownHours)
+    add("bias", source.Bias)
+    if source.Trust != 0 {
+        add("trust", strconv.FormatFloat(source.Trust, 'f', -1, 64))
+    }
+    // Not "type": on import the outline's own type attribute would claim it
+    add("source_type", source.Type)
+    add("selector", source.Selector)
+    return attrs
+}
+
+// applyOPMLSetting sets one namespaced setting on an imported source
+func applyOPMLSetting(source *NewsSource, key, value string) error {
+    var err error
+    switch key {
+    case "category":
+        source.Category = value
+    case "fact_check":
+        source.FactCheck, err = strconv.ParseBool(value)
+    case "paused":
+        source.Paused, err = strconv.ParseBool(value)
+    case "priority":
+        source.Priority, err = strconv.Atoi(value)
+    case "proxy":
+        source.Proxy = value
+    case "sensitive":
+        source.Sensitive, err = strconv.ParseBool(value)
+    case "max_posts":
+        source.MaxPosts, err = strconv.Atoi(value)
+    case "language":
+        source.Language = value
+    case "added":
+        source.Added, err = time.Parse(time.RFC3339, value)
+    case "added_by":
+        source.AddedBy = value
+    case "force_category":
+        source.ForceCategory, err = strconv.ParseBool(value)
+    case "paywalled":
+        source.Paywalled, err = strconv.ParseBool(value)
+    case "repost_cooldown_hours":
+        source.RepostCooldownHours, err = strconv.Atoi(value)
+    case "bias":
+        source.Bias = value
+    case "trust":
+        source.Trust, err = strconv.ParseFloat(value, 64)
+    case "source_type":
+        source.Type = value
+    case "selector":
+        source.Selector = value
+    default:
+        // Settings from a newer version are ignored rather than refused
+        Logger().Printf("OPML import: ignoring unknown setting %s:%s on %s", opmlPrefix, key, source.Name)
+    }
+    if err != nil {
+        return fmt.Errorf("invalid %s:%s %q for %s", opmlPrefix, key, value, source.Name)
+    }
+    return nil
+}
+
+// ParseSourcesOPML reads sources from an OPML file. Settings from a full
+// export are restored; feeds from plain OPML get the category of their
+// folder and the same defaults as /sources add.
+func ParseSourcesOPML(data []byte) ([]NewsSource, error) {
+    var doc opmlDocument
+    if err := xml.Unmarshal(data, &doc); err != nil {
+        return nil, fmt.Errorf("failed to parse OPML: %v", err)
+    }
+
+    var sources []NewsSource
+    seen := make(map[string]bool)
+    var walk func(outlines []opmlOutline, folder string) error
+    walk = func(outlines []opmlOutline, folder string) error {
+        for _, outline := range outlines {
+            if outline.XMLURL == "" {
+                if err := walk(outline.Outlines, outline.Text); err != nil {
+                    return err
+                }
+                continue
+            }
+
+            source := NewsSource{Name: outline.Title, URL: outline.XMLURL, FactCheck: true}
+            if source.Name == "" {
+                source.Name = outline.Text
+            }
+            source.Name = strings.TrimSpace(source.Name)
+            if source.Name == "" {
+                return fmt.Errorf("feed %s has no name", outline.XMLURL)
+            }
+            if seen[strings.ToLower(source.Name)] {
+                return fmt.Errorf("source %s appears more than once", source.Name)
+            }
+            seen[strings.ToLower(source.Name)] = true
+            if folder != "Uncategorized" {
+                source.Category = folder
+            }
+
+            // The prefix is only resolved to the namespace when it's declared
+            for _, attr := range outline.Settings {
+                if attr.Name.Space != opmlNamespace && attr.Name.Space != opmlPrefix {
+                    continue
+                }
+                if err := applyOPMLSetting(&source, attr.Name.Local, attr.Value); err != nil {
+                    return err
+                }
+            }
+            if err := validateSourceURL(source.URL); err != nil {
+                return fmt.Errorf("source %s: %v", source.Name, err)
+            }
+            sources = append(sources, source)
+        }
+        return nil
+    }
+    if err := walk(doc.Body, ""); err != nil {
+        return nil, err
+    }
+    if len(sources) == 0 {
+        return nil, fmt.Errorf("the file has no feeds")
+    }
+    return sources, nil
+}
+
+// mergeImportedSources replaces same-named sources with their imported
+// version and appends the rest. Replaced sources keep their credentials and
+// feed validation status, which exports don't carry.
+func mergeImportedSources(sources, imported []NewsSource) ([]NewsSource, int, int) {
+    added, updated := 0, 0
+    for _, source := range imported {
+        replaced := false
+        for idx := range sources {
+            if !strings.EqualFold(sources[idx].Name, source.Name) {
+                continue
+            }
+            old := sources[idx]
+            source.Headers = old.Headers
+            source.BasicAuthUser = old.BasicAuthUser
+            source.BasicAuthPass = old.BasicAuthPass
+            if source.Proxy == "" {
+                source.Proxy = old.Proxy
+            }
+            source.LastValidated = old.LastValidated
+            source.Broken = old.Broken
+            source.BrokenReason = old.BrokenReason
+            sources[idx] = source
+            replaced = true
+            updated++
+            break
+        }
+        if !replaced {
+            sources = append(sources, source)
+            added++
+        }
+    }
+    return sources, added, updated
+}
+
+// handleImportSources restores sources from an attached OPML file, usually
+// a full export from another deployment
+func (b *Bot) handleImportSources(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    data := i.ApplicationCommandData()
+    var attachment *discordgo.MessageAttachment
+    for _, opt := range data.Options {
+        if opt.Name == "file" && data.Resolved != nil {
+            attachment = data.Resolved.Attachments[fmt.Sprint(opt.Value)]
+        }
+    }
+    if attachment == nil {
+        respondWithError(s, i, "Please attach the OPML file to import")
+        return nil
+    }
+    if attachment.Size > maxImportSize {
+        respondWithError(s, i, fmt.Sprintf("The file is too large (at most %d KB)", maxImportSize>>10))
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    resp, err := GetHTTPClient().Get(attachment.URL)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to download the file")
+        return fmt.Errorf("failed to download OPML import: %v", err)
+    }
+    defer resp.Body.Close()
+    body, err := io.ReadAll(io.LimitReader(resp.Body, maxImportSize))
+    if err != nil {
+        editResponse(s, i, "❌ Failed to download the file")
+        return fmt.Errorf("failed to read OPML import: %v", err)
+    }
+
+    imported, err := ParseSourcesOPML(body)
+    if err != nil {
+        editResponse(s, i, "❌ "+err.Error())
+        return nil
+    }
+
+    actor := interactionUserID(i)
+    var added, updated int
+    err = UpdateSources(actor, func(sources []NewsSource) ([]NewsSource, error) {
+        var merged []NewsSource
+        merged, added, updated = mergeImportedSources(sources, imported)
+        return merged, nil
+    })
+    if err != nil {
+        editResponse(s, i, "❌ Failed to save sources")
+        return fmt.Errorf("failed to import sources: %v", err)
+    }
+    b.logger.Info("Imported %d sources from %s by %s: %d added, %d updated", len(imported), attachment.Filename, actor, added, updated)
+
+    editResponse(s, i, fmt.Sprintf("📥 Imported %d sources from %s: %d added, %d updated", len(imported), attachment.Filename, added, updated))
+    return nil
+}
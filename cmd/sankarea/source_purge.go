@@ -0,0 +1,181 @@
+// cmd/sankarea/source_purge.go
+package main
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Purge confirmations expire after this long
+const purgeConfirmTTL = 5 * time.Minute
+
+// purgePrefix starts the custom ID of the purge buttons: purge:<id>:confirm|cancel
+const purgePrefix = "purge:"
+
+// pendingPurge is a purge waiting for its confirmation button
+type pendingPurge struct {
+    source  string
+    userID  string
+    created time.Time
+}
+
+// pendingPurges holds unconfirmed purges by ID
+var pendingPurges = struct {
+    sync.Mutex
+    byID map[string]*pendingPurge
+    next uint64
+}{byID: make(map[string]*pendingPurge)}
+
+// registerPurge stores a purge awaiting confirmation and returns its ID
+func registerPurge(source, userID string) string {
+    pendingPurges.Lock()
+    defer pendingPurges.Unlock()
+
+    for id, p := range pendingPurges.byID {
+        if time.Since(p.created) > purgeConfirmTTL {
+            delete(pendingPurges.byID, id)
+        }
+    }
+    pendingPurges.next++
+    id := strconv.FormatUint(pendingPurges.next, 36)
+    pendingPurges.byID[id] = &pendingPurge{source: source, userID: userID, created: time.Now()}
+    return id
+}
+
+// takePurge removes and returns a pending purge, or nil if it expired
+func takePurge(id string) *pendingPurge {
+    pendingPurges.Lock()
+    defer pendingPurges.Unlock()
+
+    p, ok := pendingPurges.byID[id]
+    delete(pendingPurges.byID, id)
+    if !ok || time.Since(p.created) > purgeConfirmTTL {
+        return nil
+    }
+    return p
+}
+
+// pauseSource disables a source so it isn't fetched again
+func pauseSource(actor, name string) error {
+    return setSourcePaused(actor, name, true)
+}
+
+// handlePurgeSource disables a source and, unless keep_history is set, asks
+// for confirmation before deleting its stored articles
+func (b *Bot) handlePurgeSource(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    options := i.ApplicationCommandData().Options
+    name := strings.TrimSpace(getOptionString(options, "name"))
+    if name == "" {
+        respondWithError(s, i, "Please specify the source name to purge")
+        return nil
+    }
+
+    count, err := b.database.CountSourceArticles(name)
+    if err != nil {
+        respondWithError(s, i, "Failed to count the source's articles")
+        return fmt.Errorf("failed to count articles for %s: %v", name, err)
+    }
+
+    if getOptionBool(options, "keep_history") {
+        if err := pauseSource(interactionUserID(i), name); err == errSourceNotFound {
+            respondWithError(s, i, "Source not found")
+            return nil
+        } else if err != nil {
+            respondWithError(s, i, "Failed to disable source")
+            return fmt.Errorf("failed to disable %s: %v", name, err)
+        }
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseChannelMessageWithSource,
+            Data: &discordgo.InteractionResponseData{
+                Content: fmt.Sprintf("⏸️ Disabled **%s** and kept its %d stored articles", name, count),
+                Flags:   discordgo.MessageFlagsEphemeral,
+            },
+        })
+    }
+
+    if count == 0 {
+        respondWithError(s, i, fmt.Sprintf("No stored articles from **%s**", name))
+        return nil
+    }
+
+    id := registerPurge(name, interactionUserID(i))
+    return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Content: fmt.Sprintf("⚠️ This disables **%s** and permanently deletes its %d stored articles. Continue?", name, count),
+            Flags:   discordgo.MessageFlagsEphemeral,
+            Components: []discordgo.MessageComponent{
+                discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+                    discordgo.Button{
+                        Label:    "Purge",
+                        Style:    discordgo.DangerButton,
+                        CustomID: purgePrefix + id + ":confirm",
+                    },
+                    discordgo.Button{
+                        Label:    "Cancel",
+                        Style:    discordgo.SecondaryButton,
+                        CustomID: purgePrefix + id + ":cancel",
+                    },
+                }},
+            },
+        },
+    })
+}
+
+// handlePurgeButton carries out or cancels a pending purge
+func (b *Bot) handlePurgeButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to purge sources")
+        return nil
+    }
+
+    parts := strings.Split(strings.TrimPrefix(i.MessageComponentData().CustomID, purgePrefix), ":")
+    if len(parts) != 2 {
+        return fmt.Errorf("malformed purge button %q", i.MessageComponentData().CustomID)
+    }
+
+    update := func(content string) error {
+        return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+            Type: discordgo.InteractionResponseUpdateMessage,
+            Data: &discordgo.InteractionResponseData{
+                Content:    content,
+                Components: []discordgo.MessageComponent{},
+            },
+        })
+    }
+
+    p := takePurge(parts[0])
+    if p == nil {
+        return update("⌛ This confirmation expired; run the purge again")
+    }
+    if p.userID != interactionUserID(i) {
+        return update("❌ Only the admin who started the purge can confirm it")
+    }
+    if parts[1] != "confirm" {
+        return update(fmt.Sprintf("Purge of **%s** cancelled", p.source))
+    }
+
+    // Disable first so a fetch can't store new articles behind the purge
+    if err := pauseSource(p.userID, p.source); err != nil && err != errSourceNotFound {
+        update("❌ Failed to disable source")
+        return fmt.Errorf("failed to disable %s: %v", p.source, err)
+    }
+    removed, err := b.database.PurgeSourceArticles(p.source)
+    if err != nil {
+        update("❌ Failed to purge articles")
+        return fmt.Errorf("failed to purge %s: %v", p.source, err)
+    }
+
+    b.logger.Info("Purged %d articles from %s (requested by %s)", removed, p.source, p.userID)
+    return update(fmt.Sprintf("🗑️ Disabled **%s** and removed %d stored articles", p.source, removed))
+}
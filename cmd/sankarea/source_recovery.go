@@ -0,0 +1,74 @@
+// cmd/sankarea/source_recovery.go
+package main
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// SourceOutage tracks a source's failed fetches since its last success
+type SourceOutage struct {
+    Since     time.Time `json:"since"` // first failure of the run
+    Failures  int       `json:"failures"`
+    LastError string    `json:"last_error,omitempty"`
+}
+
+// RecordSourceFailure counts a failed fetch towards the source's outage
+func RecordSourceFailure(name string, fetchErr error) {
+    if err := UpdateState(func(s *State) {
+        if s.SourceOutages == nil {
+            s.SourceOutages = make(map[string]SourceOutage)
+        }
+        outage, ok := s.SourceOutages[name]
+        if !ok {
+            outage.Since = time.Now()
+        }
+        outage.Failures++
+        if fetchErr != nil {
+            outage.LastError = fetchErr.Error()
+        }
+        s.SourceOutages[name] = outage
+    }); err != nil {
+        Logger().Printf("Failed to record failed fetch of %s: %v", name, err)
+    }
+}
+
+// endSourceOutage clears a source's outage on a successful fetch and
+// returns it, or nil if the source wasn't failing
+func endSourceOutage(s *State, name string) *SourceOutage {
+    outage, ok := s.SourceOutages[name]
+    if !ok {
+        return nil
+    }
+    delete(s.SourceOutages, name)
+    return &outage
+}
+
+// noteSourceRecovered posts a recovery notice for a source that fetched
+// again after at least source_recovery_min_errors failures in a row. The
+// notice goes to the error channel, or the audit log channel without one.
+func noteSourceRecovered(s *discordgo.Session, name string, outage *SourceOutage) {
+    if outage == nil || cfg == nil || cfg.SourceRecoveryMinErrors <= 0 || outage.Failures < cfg.SourceRecoveryMinErrors {
+        return
+    }
+    down := time.Since(outage.Since).Round(time.Second)
+    Logger().Printf("Source %s recovered after %d failed fetches (down %s)", name, outage.Failures, down)
+
+    channel := cfg.ErrorChannelID
+    if channel == "" {
+        channel = cfg.AuditLogChannelID
+    }
+    if s == nil || channel == "" {
+        return
+    }
+    message := fmt.Sprintf("✅ **%s** recovered after %d failed fetches, down for %s (since <t:%d:f>)",
+        name, outage.Failures, FormatDuration(down), outage.Since.Unix())
+    if outage.LastError != "" {
+        message += "\nLast error: " + outage.LastError
+    }
+    if _, err := s.ChannelMessageSend(channel, truncateString(message, 2000)); err != nil {
+        Logger().Printf("Failed to post recovery notice for %s: %v", name, err)
+    }
+}
@@ -0,0 +1,117 @@
+// cmd/sankarea/sourcehealth.go
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// sourceHealthPageSize is how many sources each /sourcehealth page lists
+const sourceHealthPageSize = 15
+
+// sourceHealthStatus classifies a source, with a rank where higher is worse.
+// Paused sources rank lowest so they sink below healthy ones.
+func sourceHealthStatus(src NewsSource, m SourceMetrics, outage SourceOutage) (string, int) {
+    switch {
+    case src.Paused:
+        return "⏸️", 0
+    case outage.Failures > 0:
+        return "🔴", 3
+    case m.FetchAttempts == 0:
+        return "⚪", 2
+    case m.UptimePercent < cfg.MinSourceUptimePercent,
+        m.AvgResponseTime > float64(cfg.SlowSourceThresholdMs):
+        return "🟡", 2
+    default:
+        return "🟢", 1
+    }
+}
+
+// sortSourcesWorstFirst orders sources by health rank, then lowest uptime
+func sortSourcesWorstFirst(sources []NewsSource, metrics map[string]SourceMetrics, outages map[string]SourceOutage) {
+    sort.SliceStable(sources, func(i, j int) bool {
+        mi, mj := metrics[sources[i].Name], metrics[sources[j].Name]
+        _, ri := sourceHealthStatus(sources[i], mi, outages[sources[i].Name])
+        _, rj := sourceHealthStatus(sources[j], mj, outages[sources[j].Name])
+        if ri != rj {
+            return ri > rj
+        }
+        if mi.UptimePercent != mj.UptimePercent {
+            return mi.UptimePercent < mj.UptimePercent
+        }
+        return strings.ToLower(sources[i].Name) < strings.ToLower(sources[j].Name)
+    })
+}
+
+// sourceHealthPages renders sources as a fixed-width table, a page per embed,
+// with failures and fetch times from the state snapshot st
+func sourceHealthPages(sources []NewsSource, metrics map[string]SourceMetrics, st State) []*discordgo.MessageEmbed {
+    var pages []*discordgo.MessageEmbed
+    for start := 0; start < len(sources); start += sourceHealthPageSize {
+        end := start + sourceHealthPageSize
+        if end > len(sources) {
+            end = len(sources)
+        }
+
+        var table strings.Builder
+        table.WriteString(fmt.Sprintf("   %-20s %7s %5s  %s\n", "Source", "Uptime", "Errs", "Last success"))
+        for _, src := range sources[start:end] {
+            m := metrics[src.Name]
+            outage := st.SourceOutages[src.Name]
+            emoji, _ := sourceHealthStatus(src, m, outage)
+            uptime := "-"
+            if m.FetchAttempts > 0 {
+                uptime = fmt.Sprintf("%.1f%%", m.UptimePercent)
+            }
+            table.WriteString(fmt.Sprintf("%s %-20s %7s %5d  %s\n",
+                emoji, truncateString(src.Name, 20), uptime, outage.Failures, formatTimeAgo(st.SourceLastSuccess[src.Name])))
+        }
+
+        pages = append(pages, &discordgo.MessageEmbed{
+            Title:       "🩺 Source Health",
+            Description: "```\n" + table.String() + "```",
+            Color:       0x7289DA,
+            Footer: &discordgo.MessageEmbedFooter{
+                Text: fmt.Sprintf("%d sources, worst first · 🔴 failing 🟡 slow or low uptime ⚪ never fetched 🟢 healthy ⏸️ paused", len(sources)),
+            },
+            Timestamp: time.Now().Format(time.RFC3339),
+        })
+    }
+    return pages
+}
+
+// handleSourceHealthCommand lists every source's health, worst first,
+// including paused ones
+func (b *Bot) handleSourceHealthCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    sources, err := LoadSources()
+    if err != nil {
+        respondWithError(s, i, "Failed to load sources")
+        return fmt.Errorf("failed to load sources: %v", err)
+    }
+    if len(sources) == 0 {
+        respondWithError(s, i, "No sources configured")
+        return nil
+    }
+
+    err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    metrics := sourceMetrics()
+    st := GetState()
+    sortSourcesWorstFirst(sources, metrics, st.SourceOutages)
+    if err := editResponsePaginated(s, i, sourceHealthPages(sources, metrics, st)); err != nil {
+        return fmt.Errorf("failed to send source health: %v", err)
+    }
+    return nil
+}
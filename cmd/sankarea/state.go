@@ -5,6 +5,7 @@ import (
     "encoding/json"
     "fmt"
     "os"
+    "sort"
     "sync"
     "time"
 )
@@ -19,8 +20,28 @@ type State struct {
     ConnectedGuilds int               `json:"connected_guilds"`
     ActiveSources   int               `json:"active_sources"`
     HealthStatus    string            `json:"health_status"`
+    LastArticleTime time.Time         `json:"last_article_time"`
     Components      map[string]Status `json:"components"`
-    mutex           sync.RWMutex
+    PostingMode     string            `json:"posting_mode,omitempty"`  // set by /mode; overrides digest_only
+    AlertMention    string            `json:"alert_mention,omitempty"` // set by /alert target; overrides breaking_news.mention
+
+    // Fetch cycle progress, saved as each source finishes so a crash
+    // mid-cycle keeps the work already done
+    CycleStartedAt    time.Time            `json:"cycle_started_at,omitempty"`
+    CycleFinishedAt   time.Time            `json:"cycle_finished_at,omitempty"`
+    SourceLastSuccess map[string]time.Time `json:"source_last_success,omitempty"`
+
+    // UnreliableDates maps a source name to why its item dates look wrong
+    UnreliableDates map[string]string `json:"unreliable_dates,omitempty"`
+
+    // FetchBoosts maps a source name to its temporary fetch interval, set by /sources boost
+    FetchBoosts map[string]FetchBoost `json:"fetch_boosts,omitempty"`
+
+    // SourceOutages maps a failing source's name to its failed fetches since its last success
+    SourceOutages map[string]SourceOutage `json:"source_outages,omitempty"`
+
+    // SourceMetrics maps a source name to its fetch response time and uptime averages
+    SourceMetrics map[string]SourceMetrics `json:"source_metrics,omitempty"`
 }
 
 // Status represents the status of a component
@@ -74,7 +95,137 @@ func GetState() State {
     stateMux.RLock()
     defer stateMux.RUnlock()
 
-    return *state
+    return copyState(state)
+}
+
+// LoadState returns a snapshot of the current state. Changes to the snapshot
+// are not saved; use UpdateState to modify the state.
+func LoadState() (*State, error) {
+    stateMux.RLock()
+    defer stateMux.RUnlock()
+
+    if state == nil {
+        return nil, fmt.Errorf("state not initialized")
+    }
+    snapshot := copyState(state)
+    return &snapshot, nil
+}
+
+// UpdateState applies fn to the state under the lock and persists the result,
+// so concurrent writers never overwrite each other's changes
+func UpdateState(fn func(*State)) error {
+    stateMux.Lock()
+    defer stateMux.Unlock()
+
+    if state == nil {
+        return fmt.Errorf("state not initialized")
+    }
+    fn(state)
+    state.LastUpdate = time.Now()
+    return saveState()
+}
+
+// SaveState replaces the whole state with s and persists it. Prefer
+// UpdateState, which only touches the fields it changes.
+func SaveState(s *State) error {
+    return UpdateState(func(current *State) {
+        *current = copyState(s)
+    })
+}
+
+// copyState returns a copy of s that shares no maps with it
+func copyState(s *State) State {
+    snapshot := *s
+    snapshot.Components = make(map[string]Status, len(s.Components))
+    for name, status := range s.Components {
+        snapshot.Components[name] = status
+    }
+    snapshot.SourceLastSuccess = make(map[string]time.Time, len(s.SourceLastSuccess))
+    for name, t := range s.SourceLastSuccess {
+        snapshot.SourceLastSuccess[name] = t
+    }
+    snapshot.UnreliableDates = make(map[string]string, len(s.UnreliableDates))
+    for name, reason := range s.UnreliableDates {
+        snapshot.UnreliableDates[name] = reason
+    }
+    snapshot.FetchBoosts = make(map[string]FetchBoost, len(s.FetchBoosts))
+    for name, boost := range s.FetchBoosts {
+        snapshot.FetchBoosts[name] = boost
+    }
+    snapshot.SourceOutages = make(map[string]SourceOutage, len(s.SourceOutages))
+    for name, outage := range s.SourceOutages {
+        snapshot.SourceOutages[name] = outage
+    }
+    snapshot.SourceMetrics = make(map[string]SourceMetrics, len(s.SourceMetrics))
+    for name, metrics := range s.SourceMetrics {
+        snapshot.SourceMetrics[name] = metrics
+    }
+    return snapshot
+}
+
+// BeginFetchCycle marks a fetch cycle as started and reports whether the
+// previous one was interrupted before finishing
+func BeginFetchCycle() bool {
+    interrupted := false
+    if err := UpdateState(func(s *State) {
+        interrupted = s.CycleStartedAt.After(s.CycleFinishedAt)
+        s.CycleStartedAt = time.Now()
+    }); err != nil {
+        Logger().Printf("Failed to record fetch cycle start: %v", err)
+    }
+    return interrupted
+}
+
+// EndFetchCycle marks the current fetch cycle as finished
+func EndFetchCycle() {
+    if err := UpdateState(func(s *State) {
+        s.CycleFinishedAt = time.Now()
+    }); err != nil {
+        Logger().Printf("Failed to record fetch cycle end: %v", err)
+    }
+}
+
+// RecordSourceSuccess saves a successful fetch of one source and the
+// articles it produced, so progress survives a crash mid-cycle. It returns
+// the outage the fetch ended, or nil if the source wasn't failing.
+func RecordSourceSuccess(name string, articles int) *SourceOutage {
+    var outage *SourceOutage
+    if err := UpdateState(func(s *State) {
+        if s.SourceLastSuccess == nil {
+            s.SourceLastSuccess = make(map[string]time.Time)
+        }
+        s.SourceLastSuccess[name] = time.Now()
+        s.ArticleCount += articles
+        if articles > 0 {
+            s.LastArticleTime = time.Now()
+        }
+        outage = endSourceOutage(s, name)
+    }); err != nil {
+        Logger().Printf("Failed to record fetch of %s: %v", name, err)
+    }
+    return outage
+}
+
+// staleFirst returns the indexes of the named sources ordered by last
+// successful fetch, never-fetched sources first
+func staleFirst(names []string) []int {
+    stateMux.RLock()
+    last := make([]time.Time, len(names))
+    if state != nil {
+        for i, name := range names {
+            last[i] = state.SourceLastSuccess[name]
+        }
+    }
+    stateMux.RUnlock()
+
+    order := make([]int, len(names))
+    for i := range order {
+        order[i] = i
+    }
+    sort.SliceStable(order, func(a, b int) bool {
+        return last[order[a]].Before(last[order[b]])
+    })
+    return order
 }
 
 // UpdateComponentStatus updates the status of a specific component
@@ -82,11 +233,14 @@ func UpdateComponentStatus(component string, status string, err error) {
     stateMux.Lock()
     defer stateMux.Unlock()
 
-    state.Components[component] = Status{
+    componentStatus := Status{
         Status:    status,
         LastCheck: time.Now(),
-        LastError: err.Error(),
     }
+    if err != nil {
+        componentStatus.LastError = err.Error()
+    }
+    state.Components[component] = componentStatus
 
     // Update overall health status
     updateHealthStatus()
@@ -154,14 +308,14 @@ func UpdateSourceCount(count int) {
     saveState()
 }
 
-// saveState saves the current state to disk
+// saveState saves the current state to disk; callers must hold stateMux
 func saveState() error {
     data, err := json.MarshalIndent(state, "", "  ")
     if err != nil {
         return fmt.Errorf("failed to marshal state: %v", err)
     }
 
-    if err := os.WriteFile(stateFile, data, 0644); err != nil {
+    if err := writeFileAtomic(stateFile, data, 0644); err != nil {
         return fmt.Errorf("failed to write state file: %v", err)
     }
 
@@ -0,0 +1,235 @@
+// cmd/sankarea/summarizer.go
+package main
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "regexp"
+    "sort"
+    "strings"
+
+    "github.com/sashabaranov/go-openai"
+)
+
+// Summarizer backends selectable with ai.summarizer
+const (
+    SummarizerOpenAI     = "openai"     // OpenAI chat completions, counted against the AI budget
+    SummarizerLocal      = "local"      // an OpenAI-compatible server at ai.local_llm_url
+    SummarizerExtractive = "extractive" // offline sentence ranking, no API needed
+)
+
+// Summarizer produces a short summary of an article
+type Summarizer interface {
+    Name() string
+    Summarize(ctx context.Context, article *Article, maxLength int) (string, error)
+}
+
+// activeSummarizer returns the configured summarizer. When none is set,
+// OpenAI is used if an API key is configured and the extractive
+// summarizer otherwise, so summaries work fully offline.
+func activeSummarizer() Summarizer {
+    name := ""
+    if cfg != nil {
+        name = cfg.AI.Summarizer
+    }
+    switch name {
+    case SummarizerOpenAI:
+        return &OpenAISummarizer{}
+    case SummarizerLocal:
+        return &OpenAISummarizer{baseURL: cfg.AI.LocalLLMURL, local: true}
+    case SummarizerExtractive:
+        return &ExtractiveSummarizer{}
+    }
+    if cfg != nil && cfg.OpenAIAPIKey != "" {
+        return &OpenAISummarizer{}
+    }
+    return &ExtractiveSummarizer{}
+}
+
+// OpenAISummarizer summarizes with a chat completion model. With local set
+// it talks to an OpenAI-compatible server instead and isn't budgeted.
+type OpenAISummarizer struct {
+    baseURL string
+    local   bool
+}
+
+// Name identifies the summarizer
+func (o *OpenAISummarizer) Name() string {
+    if o.local {
+        return SummarizerLocal
+    }
+    return SummarizerOpenAI
+}
+
+// Summarize asks the model for a neutral summary under maxLength characters
+func (o *OpenAISummarizer) Summarize(ctx context.Context, article *Article, maxLength int) (string, error) {
+    clientConfig := openai.DefaultConfig(cfg.OpenAIAPIKey)
+    if o.local {
+        if o.baseURL == "" {
+            return "", fmt.Errorf("ai.local_llm_url is not set")
+        }
+        clientConfig.BaseURL = o.baseURL
+    } else {
+        if cfg.OpenAIAPIKey == "" {
+            return "", fmt.Errorf("OpenAI integration not configured")
+        }
+        if aiBudgetExceeded() {
+            return "", ErrAIBudgetExceeded
+        }
+    }
+    task := cfg.AI.Summarize
+    client := openai.NewClientWithConfig(clientConfig)
+
+    // Prepare system prompt for article summarization
+    systemPrompt := fmt.Sprintf(`You are an AI that summarizes news articles in a neutral, factual manner.
+Create a concise summary that captures the key points of the article.
+Keep the summary under %d characters.
+Do not include your own opinions or analysis.`, maxLength)
+
+    // Take the first 2500 characters or so for summarization
+    contentToAnalyze := article.Title
+    if len(article.Content) > 0 {
+        contentToAnalyze += "\n\n" + article.Content[:min(len(article.Content), 2500)]
+    }
+
+    resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+        Model:     task.Model,
+        MaxTokens: task.MaxTokens,
+        Messages: []openai.ChatCompletionMessage{
+            {Role: "system", Content: systemPrompt},
+            {Role: "user", Content: fmt.Sprintf("Summarize this article from %s:\n\n%s", article.Source, contentToAnalyze)},
+        },
+        Temperature: task.RequestTemperature(),
+    })
+    if err != nil {
+        return "", fmt.Errorf("%s API error: %v", o.Name(), err)
+    }
+    if len(resp.Choices) == 0 {
+        return "", fmt.Errorf("%s returned no choices", o.Name())
+    }
+
+    // Local models cost nothing against the OpenAI budget
+    if !o.local {
+        updateOpenAIUsageCost(resp.Usage.TotalTokens)
+    }
+
+    return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
+
+// ExtractiveSummarizer picks the article's most central sentences with a
+// TextRank-style ranking. It needs no network access.
+type ExtractiveSummarizer struct{}
+
+// sentencePattern splits text after sentence-ending punctuation
+var sentencePattern = regexp.MustCompile(`[^.!?]+[.!?]+["')\]]*|[^.!?]+$`)
+
+// Name identifies the summarizer
+func (e *ExtractiveSummarizer) Name() string {
+    return SummarizerExtractive
+}
+
+// Summarize returns the highest-ranked sentences, in their original order,
+// that fit within maxLength characters
+func (e *ExtractiveSummarizer) Summarize(ctx context.Context, article *Article, maxLength int) (string, error) {
+    var sentences []string
+    for _, s := range sentencePattern.FindAllString(strings.Join(strings.Fields(article.Content), " "), -1) {
+        if s = strings.TrimSpace(s); len(strings.Fields(s)) >= 4 {
+            sentences = append(sentences, s)
+        }
+    }
+    if len(sentences) == 0 {
+        if article.Title == "" {
+            return "", fmt.Errorf("no text to summarize")
+        }
+        return truncateString(article.Title, maxLength), nil
+    }
+
+    scores := rankSentences(sentences)
+    order := make([]int, len(sentences))
+    for i := range order {
+        order[i] = i
+    }
+    sort.SliceStable(order, func(a, b int) bool {
+        return scores[order[a]] > scores[order[b]]
+    })
+
+    // Take the best sentences that fit, then restore reading order
+    var picked []int
+    length := 0
+    for _, idx := range order {
+        if n := len(sentences[idx]) + 1; length+n <= maxLength {
+            picked = append(picked, idx)
+            length += n
+        }
+    }
+    if len(picked) == 0 {
+        return truncateString(sentences[order[0]], maxLength), nil
+    }
+    sort.Ints(picked)
+
+    parts := make([]string, len(picked))
+    for i, idx := range picked {
+        parts[i] = sentences[idx]
+    }
+    return strings.Join(parts, " "), nil
+}
+
+// rankSentences scores sentences by PageRank over a word-overlap similarity graph
+func rankSentences(sentences []string) []float64 {
+    const (
+        damping    = 0.85
+        iterations = 30
+    )
+
+    words := make([]map[string]bool, len(sentences))
+    for i, s := range sentences {
+        words[i] = make(map[string]bool)
+        for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+            return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+        }) {
+            if len(w) > 2 {
+                words[i][w] = true
+            }
+        }
+    }
+
+    n := len(sentences)
+    weights := make([][]float64, n)
+    totals := make([]float64, n)
+    for i := range weights {
+        weights[i] = make([]float64, n)
+        for j := range weights[i] {
+            if i == j || len(words[i]) < 2 || len(words[j]) < 2 {
+                continue
+            }
+            shared := 0
+            for w := range words[i] {
+                if words[j][w] {
+                    shared++
+                }
+            }
+            weights[i][j] = float64(shared) / (math.Log(float64(len(words[i]))) + math.Log(float64(len(words[j]))))
+            totals[i] += weights[i][j]
+        }
+    }
+
+    scores := make([]float64, n)
+    for i := range scores {
+        scores[i] = 1
+    }
+    for iter := 0; iter < iterations; iter++ {
+        next := make([]float64, n)
+        for i := 0; i < n; i++ {
+            sum := 0.0
+            for j := 0; j < n; j++ {
+                if weights[j][i] > 0 {
+                    sum += weights[j][i] / totals[j] * scores[j]
+                }
+            }
+            next[i] = (1 - damping) + damping*sum
+        }
+        scores = next
+    }
+    return scores
+}
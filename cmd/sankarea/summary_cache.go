@@ -0,0 +1,87 @@
+// cmd/sankarea/summary_cache.go
+package main
+
+import (
+    "sync"
+    "time"
+)
+
+// defaultSummaryCacheHours is how long summaries are reused when unset
+const defaultSummaryCacheHours = 7 * 24
+
+// SummaryCache stores AI summaries by article URL so each article is only
+// summarized once. It is backed by the summaries table and does nothing
+// until a database is set.
+type SummaryCache struct {
+    mu sync.RWMutex
+    db *Database
+}
+
+// summaryCache is the shared cache used by SummarizeArticle
+var summaryCache = &SummaryCache{}
+
+// SetDatabase enables the cache
+func (c *SummaryCache) SetDatabase(db *Database) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.db = db
+}
+
+// database returns the backing store, or nil when caching is off
+func (c *SummaryCache) database() *Database {
+    if cfg != nil && cfg.AI.SummaryCacheHours < 0 {
+        return nil
+    }
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    return c.db
+}
+
+// ttl returns how long a cached summary stays valid
+func (c *SummaryCache) ttl() time.Duration {
+    hours := defaultSummaryCacheHours
+    if cfg != nil && cfg.AI.SummaryCacheHours > 0 {
+        hours = cfg.AI.SummaryCacheHours
+    }
+    return time.Duration(hours) * time.Hour
+}
+
+// Get returns the cached summary for an article if it hasn't expired and,
+// unless summary_keep_on_edit is set, the article text hasn't changed since
+func (c *SummaryCache) Get(article *Article) (string, bool) {
+    db := c.database()
+    if db == nil || article.URL == "" {
+        return "", false
+    }
+
+    cached, err := db.GetSummary(article.URL)
+    if err != nil {
+        Logger().Printf("Failed to read cached summary for %s: %v", article.URL, err)
+        return "", false
+    }
+    if cached == nil || time.Since(cached.CreatedAt) > c.ttl() {
+        return "", false
+    }
+    keepOnEdit := cfg != nil && cfg.AI.SummaryKeepOnEdit
+    if !keepOnEdit && cached.ContentHash != contentHash(article.Title, article.Content) {
+        return "", false
+    }
+    return cached.Summary, true
+}
+
+// Put stores a freshly generated summary
+func (c *SummaryCache) Put(article *Article, summary string) {
+    db := c.database()
+    if db == nil || article.URL == "" {
+        return
+    }
+    err := db.SaveSummary(&CachedSummary{
+        URL:         article.URL,
+        ContentHash: contentHash(article.Title, article.Content),
+        Summary:     summary,
+        CreatedAt:   time.Now(),
+    })
+    if err != nil {
+        Logger().Printf("Failed to cache summary for %s: %v", article.URL, err)
+    }
+}
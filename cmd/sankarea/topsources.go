@@ -0,0 +1,93 @@
+// cmd/sankarea/topsources.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// Limits for /topsources
+const (
+    defaultTopSourcesDays = 7
+    maxTopSourcesDays     = 90
+    topSourcesShown       = 10
+)
+
+// handleTopSourcesCommand ranks sources by articles and reliability over a window
+func (b *Bot) handleTopSourcesCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    days := defaultTopSourcesDays
+    if n, ok := getOptionIntValue(i.ApplicationCommandData().Options, "days"); ok {
+        days = int(n)
+    }
+    if days < 1 || days > maxTopSourcesDays {
+        respondWithError(s, i, fmt.Sprintf("Days must be between 1 and %d", maxTopSourcesDays))
+        return nil
+    }
+    if b.database == nil {
+        respondWithError(s, i, "Source rankings need the article database")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    end := time.Now().UTC()
+    start := end.AddDate(0, 0, -days)
+    articles, err := b.database.GetArticlesByTimeRange(start, end)
+    if err != nil {
+        editResponse(s, i, "❌ Failed to load articles")
+        return fmt.Errorf("failed to load articles for top sources: %v", err)
+    }
+    entries := buildSourceLeaderboard(articles)
+    total := len(entries)
+    if len(entries) > topSourcesShown {
+        entries = entries[:topSourcesShown]
+    }
+
+    // Trust comes from the source list; sources removed since are still ranked
+    configured := make(map[string]NewsSource)
+    if sources, err := LoadSources(); err == nil {
+        for _, source := range sources {
+            configured[strings.ToLower(source.Name)] = source
+        }
+    }
+
+    lines := make([]string, 0, len(entries))
+    for idx, entry := range entries {
+        reliability := "unchecked"
+        if avg := entry.AverageScore(); avg >= 0 {
+            reliability = fmt.Sprintf("reliability %.2f", avg)
+        }
+        trust := "trust n/a"
+        if source, ok := configured[strings.ToLower(entry.Name)]; ok {
+            trust = fmt.Sprintf("trust %.2f", sourceTrust(source))
+        }
+        errCount, err := b.database.CountFeedErrors(entry.Name, start)
+        if err != nil {
+            b.logger.Error("Failed to count errors for %s: %v", entry.Name, err)
+        }
+        lines = append(lines, fmt.Sprintf("%d. **%s** · %d articles · %s · %s · %d errors",
+            idx+1, entry.Name, entry.Count, reliability, trust, errCount))
+    }
+    if len(lines) == 0 {
+        lines = append(lines, "No articles were stored in this window.")
+    }
+
+    editResponseWithEmbed(s, i, &discordgo.MessageEmbed{
+        Title:       fmt.Sprintf("📈 Top sources, last %d days", days),
+        Description: truncateString(strings.Join(lines, "\n"), MaxEmbedLength),
+        Color:       0x43B581,
+        Footer: &discordgo.MessageEmbedFooter{
+            Text: fmt.Sprintf("%d articles from %d sources", len(articles), total),
+        },
+        Timestamp: end.Format(time.RFC3339),
+    })
+    return nil
+}
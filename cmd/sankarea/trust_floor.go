@@ -0,0 +1,97 @@
+// cmd/sankarea/trust_floor.go
+package main
+
+import (
+    "fmt"
+
+    "github.com/bwmarrin/discordgo"
+)
+
+// trustFloor returns the global minimum trust score, 0 when disabled
+func trustFloor() float64 {
+    if cfg == nil {
+        return 0
+    }
+    return cfg.MinTrustScore
+}
+
+// sourceTrust is a source's configured trust, falling back to the fact
+// checker's built-in reliability list
+func sourceTrust(source NewsSource) float64 {
+    if source.Trust > 0 {
+        return source.Trust
+    }
+    score, _ := DefaultFactChecker().getSourceReliabilityScore(source.Name)
+    return score
+}
+
+// trustFloorReason explains why an article is held back by the global trust
+// floor, or returns "" if it may be posted
+func trustFloorReason(source NewsSource, article *NewsArticle) string {
+    floor := trustFloor()
+    if floor <= 0 {
+        return ""
+    }
+    if trust := sourceTrust(source); trust < floor {
+        return fmt.Sprintf("source trust %.2f is below the %.2f floor", trust, floor)
+    }
+    return lowReliabilityReason(article)
+}
+
+// lowReliabilityReason holds back articles fact-checked as Low while the
+// trust floor is enabled
+func lowReliabilityReason(article *NewsArticle) string {
+    if trustFloor() <= 0 || article.FactCheckResult == nil || article.FactCheckResult.ReliabilityTier != TierLow {
+        return ""
+    }
+    return fmt.Sprintf("fact check rated it Low (%.2f)", article.FactCheckResult.Score)
+}
+
+// noteHeldArticle tells the audit log that an article was stored but not posted
+func noteHeldArticle(s *discordgo.Session, article *NewsArticle, reason string) {
+    if s == nil || cfg == nil || !cfg.TrustFloorAuditLog || cfg.AuditLogChannelID == "" {
+        return
+    }
+    message := fmt.Sprintf("🚫 **Held back**: [%s](<%s>) from %s - %s", truncateString(article.Title, 200), article.URL, article.Source, reason)
+    if _, err := s.ChannelMessageSend(cfg.AuditLogChannelID, message); err != nil {
+        Logger().Printf("Failed to log held article: %v", err)
+    }
+}
+
+// retractPostedArticle takes down the posts of an article that turned out to
+// be below the trust floor: its embeds are replaced by a notice, or the
+// message is deleted when it held nothing else
+func (b *Bot) retractPostedArticle(article *NewsArticle, reason string) {
+    records, err := b.database.GetArticleMessages(article.ID)
+    if err != nil {
+        b.logger.Error("Failed to load posts for %s: %v", article.URL, err)
+        return
+    }
+
+    notice := []*discordgo.MessageEmbed{{
+        Title:       "🚫 Article withheld",
+        Description: "This article was removed after fact checking.",
+        Color:       0x747F8D,
+    }}
+    for _, record := range records {
+        msg, err := b.discord.ChannelMessage(record.ChannelID, record.MessageID)
+        switch {
+        case err != nil:
+            err = messageLoadError(err)
+        case len(msg.Embeds) <= record.EmbedCount:
+            err = b.discord.ChannelMessageDelete(record.ChannelID, record.MessageID)
+        default:
+            err = b.editArticleMessage(record, notice)
+        }
+        if err != nil && err != errMessageGone {
+            b.logger.Error("Failed to retract post of %s in %s: %v", article.URL, record.ChannelID, err)
+            continue
+        }
+        if err := b.database.DeleteArticleMessage(record); err != nil {
+            b.logger.Error("Failed to drop message record for %s: %v", article.URL, err)
+        }
+    }
+
+    b.logger.Info("Retracted %s: %s", article.Title, reason)
+    noteHeldArticle(b.discord, article, reason)
+}
@@ -205,6 +205,11 @@ func handleKickCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
+	// Record the action in the user's moderation history
+	if err := AddUserWarning(userID, UserWarning{GuildID: i.GuildID, Action: "kick", Reason: reason, IssuedBy: interactionUserID(i)}); err != nil {
+		Logger().Printf("Failed to record warning for %s: %v", userID, err)
+	}
+
 	// Log to audit channel
 	auditMessage := fmt.Sprintf("👢 **User Kicked**: %s#%s (ID: %s)\n**Reason**: %s\n**Performed by**: %s",
 		member.User.Username, member.User.Discriminator, member.User.ID, reason, i.Member.User.Username)
@@ -274,6 +279,11 @@ func handleBanCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
+	// Record the action in the user's moderation history
+	if err := AddUserWarning(userID, UserWarning{GuildID: i.GuildID, Action: "ban", Reason: reason, IssuedBy: interactionUserID(i)}); err != nil {
+		Logger().Printf("Failed to record warning for %s: %v", userID, err)
+	}
+
 	// Log to audit channel
 	auditMessage := fmt.Sprintf("🔨 **User Banned**: %s#%s (ID: %s)\n**Reason**: %s\n**Performed by**: %s\n**Days of messages deleted**: %d",
 		member.User.Username, member.User.Discriminator, member.User.ID, reason, i.Member.User.Username, days)
@@ -346,6 +356,11 @@ func handleMuteCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
+	// Record the action in the user's moderation history
+	if err := AddUserWarning(userID, UserWarning{GuildID: i.GuildID, Action: "mute", Reason: reason, IssuedBy: interactionUserID(i)}); err != nil {
+		Logger().Printf("Failed to record warning for %s: %v", userID, err)
+	}
+
 	// Log to audit channel
 	auditMessage := fmt.Sprintf("🔇 **User Timed Out**: %s#%s (ID: %s)\n**Reason**: %s\n**Duration**: %d minutes\n**Performed by**: %s",
 		member.User.Username, member.User.Discriminator, member.User.ID, reason, duration, i.Member.User.Username)
@@ -0,0 +1,490 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	userFiltersDir        = "data/user_filters"
+	userSubscriptionsFile = "data/subscriptions.json"
+	userLanguagesFile     = "data/user_languages.json"
+	trackedKeywordsFile   = "data/tracked_keywords.json"
+	readMarkersFile       = "data/read_markers.json"
+	userWarningsFile      = "data/warnings.json"
+)
+
+// userDataMutex guards all per-user JSON stores
+var userDataMutex sync.Mutex
+
+// UserFilter holds a user's personal news filters
+type UserFilter struct {
+	UserID             string   `json:"user_id"`
+	ExcludedSources    []string `json:"excluded_sources,omitempty"`
+	ExcludedCategories []string `json:"excluded_categories,omitempty"`
+	MinTrustScore      float64  `json:"min_trust_score,omitempty"`
+	// Languages limits articles to these base languages; empty allows all
+	Languages []string `json:"languages,omitempty"`
+	// SnoozedSources maps a source name to when its snooze expires
+	SnoozedSources map[string]time.Time `json:"snoozed_sources,omitempty"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// pruneSnoozes drops expired snoozes and reports whether any were removed
+func (f *UserFilter) pruneSnoozes(now time.Time) bool {
+	pruned := false
+	for source, until := range f.SnoozedSources {
+		if !now.Before(until) {
+			delete(f.SnoozedSources, source)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// Allows reports whether an article passes the filter
+func (f *UserFilter) Allows(article *NewsArticle) bool {
+	for _, name := range f.ExcludedSources {
+		if strings.EqualFold(name, article.Source) {
+			return false
+		}
+	}
+	for _, category := range f.ExcludedCategories {
+		if strings.EqualFold(category, article.Category) {
+			return false
+		}
+	}
+	for name := range f.SnoozedSources {
+		if strings.EqualFold(name, article.Source) {
+			return false
+		}
+	}
+	if f.MinTrustScore > 0 && article.FactCheckResult != nil && article.FactCheckResult.Score < f.MinTrustScore {
+		return false
+	}
+	if len(f.Languages) > 0 {
+		lang := article.Language
+		if lang == "" {
+			lang = detectLanguage(article.Title + " " + article.Content)
+		}
+		if !languageAllowed(lang, f.Languages) {
+			return false
+		}
+	}
+	return true
+}
+
+// UserWarning records a moderation action taken against a user
+type UserWarning struct {
+	GuildID  string    `json:"guild_id"`
+	Action   string    `json:"action"`
+	Reason   string    `json:"reason"`
+	IssuedBy string    `json:"issued_by"`
+	Time     time.Time `json:"time"`
+}
+
+// UserData is a snapshot of everything the bot stores about a single user
+type UserData struct {
+	Filter          *UserFilter
+	Subscriptions   []string
+	Language        string
+	TrackedKeywords []string
+	ReadMarkers     map[string]time.Time
+	Warnings        []UserWarning
+}
+
+// Empty reports whether no data is stored for the user
+func (d *UserData) Empty() bool {
+	return d.Filter == nil && len(d.Subscriptions) == 0 && d.Language == "" &&
+		len(d.TrackedKeywords) == 0 && len(d.ReadMarkers) == 0 && len(d.Warnings) == 0
+}
+
+// isUserID reports whether id looks like a Discord user ID, a numeric
+// snowflake. IDs end up in file paths, so anything else is refused.
+func isUserID(id string) bool {
+	if len(id) < 15 || len(id) > 20 {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// userFilterPath returns the file a user's filter is stored in
+func userFilterPath(userID string) string {
+	return filepath.Join(userFiltersDir, userID+".json")
+}
+
+// LoadUserFilter loads a user's filter, returning nil if none is stored
+func LoadUserFilter(userID string) (*UserFilter, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+	return loadUserFilterLocked(userID)
+}
+
+// loadUserFilterLocked reads a user's filter. Caller must hold userDataMutex.
+func loadUserFilterLocked(userID string) (*UserFilter, error) {
+	data, err := os.ReadFile(userFilterPath(userID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user filter: %v", err)
+	}
+
+	var filter UserFilter
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return nil, fmt.Errorf("failed to parse user filter: %v", err)
+	}
+	return &filter, nil
+}
+
+// SaveUserFilter stores a user's filter
+func SaveUserFilter(filter *UserFilter) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+	return saveUserFilterLocked(filter)
+}
+
+// saveUserFilterLocked writes a user's filter. Caller must hold userDataMutex.
+func saveUserFilterLocked(filter *UserFilter) error {
+	filter.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(filter, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal user filter: %v", err)
+	}
+	if err := os.MkdirAll(userFiltersDir, 0755); err != nil {
+		return fmt.Errorf("failed to create user filters directory: %v", err)
+	}
+	return os.WriteFile(userFilterPath(filter.UserID), data, 0644)
+}
+
+// updateUserFilter applies fn to a user's filter, creating an empty one if
+// none is stored, and saves the result while holding userDataMutex so
+// concurrent updates can't overwrite each other
+func updateUserFilter(userID string, fn func(*UserFilter)) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	filter, err := loadUserFilterLocked(userID)
+	if err != nil {
+		return err
+	}
+	if filter == nil {
+		filter = &UserFilter{UserID: userID}
+	}
+	fn(filter)
+	return saveUserFilterLocked(filter)
+}
+
+// SnoozeSource hides a source from a user's news until the given time
+func SnoozeSource(userID, source string, until time.Time) error {
+	return updateUserFilter(userID, func(filter *UserFilter) {
+		if filter.SnoozedSources == nil {
+			filter.SnoozedSources = make(map[string]time.Time)
+		}
+		filter.pruneSnoozes(time.Now())
+		filter.SnoozedSources[source] = until
+	})
+}
+
+// SetArticleLanguages limits a user's news to the given languages; an empty list clears the limit
+func SetArticleLanguages(userID string, languages []string) error {
+	return updateUserFilter(userID, func(filter *UserFilter) {
+		filter.Languages = languages
+	})
+}
+
+// pruneUserSnoozes drops a user's expired snoozes and returns their filter,
+// or nil if none is stored
+func pruneUserSnoozes(userID string) (*UserFilter, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	filter, err := loadUserFilterLocked(userID)
+	if err != nil || filter == nil {
+		return filter, err
+	}
+	if filter.pruneSnoozes(time.Now()) {
+		if err := saveUserFilterLocked(filter); err != nil {
+			Logger().Printf("Failed to save filter for user %s: %v", userID, err)
+		}
+	}
+	return filter, nil
+}
+
+// ApplyUserFilter removes articles the user has filtered out or snoozed.
+// Expired snoozes are cleaned up here rather than by a background job.
+func ApplyUserFilter(userID string, articles []*NewsArticle) []*NewsArticle {
+	filter, err := pruneUserSnoozes(userID)
+	if err != nil {
+		Logger().Printf("Failed to load filter for user %s: %v", userID, err)
+		return articles
+	}
+	if filter == nil {
+		return articles
+	}
+
+	filtered := make([]*NewsArticle, 0, len(articles))
+	for _, article := range articles {
+		if filter.Allows(article) {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered
+}
+
+// loadUserMap reads a JSON object keyed by user ID. Caller must hold userDataMutex.
+func loadUserMap(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return nil
+}
+
+// saveUserMap writes a JSON object keyed by user ID. Caller must hold userDataMutex.
+func saveUserMap(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %v", path, err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// GetUserSubscriptions returns the categories a user is subscribed to
+func GetUserSubscriptions(userID string) ([]string, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	subs := make(map[string][]string)
+	if err := loadUserMap(userSubscriptionsFile, &subs); err != nil {
+		return nil, err
+	}
+	return subs[userID], nil
+}
+
+// SetUserSubscriptions replaces the categories a user is subscribed to
+func SetUserSubscriptions(userID string, categories []string) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	subs := make(map[string][]string)
+	if err := loadUserMap(userSubscriptionsFile, &subs); err != nil {
+		return err
+	}
+	subs[userID] = categories
+	return saveUserMap(userSubscriptionsFile, subs)
+}
+
+// GetUserLanguage returns a user's preferred language, or "" if unset
+func GetUserLanguage(userID string) (string, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	langs := make(map[string]string)
+	if err := loadUserMap(userLanguagesFile, &langs); err != nil {
+		return "", err
+	}
+	return langs[userID], nil
+}
+
+// SetUserLanguage stores a user's preferred language
+func SetUserLanguage(userID, language string) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	langs := make(map[string]string)
+	if err := loadUserMap(userLanguagesFile, &langs); err != nil {
+		return err
+	}
+	langs[userID] = language
+	return saveUserMap(userLanguagesFile, langs)
+}
+
+// GetTrackedKeywords returns the keywords a user is tracking
+func GetTrackedKeywords(userID string) ([]string, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	keywords := make(map[string][]string)
+	if err := loadUserMap(trackedKeywordsFile, &keywords); err != nil {
+		return nil, err
+	}
+	return keywords[userID], nil
+}
+
+// SetTrackedKeywords replaces the keywords a user is tracking
+func SetTrackedKeywords(userID string, keywords []string) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	all := make(map[string][]string)
+	if err := loadUserMap(trackedKeywordsFile, &all); err != nil {
+		return err
+	}
+	all[userID] = keywords
+	return saveUserMap(trackedKeywordsFile, all)
+}
+
+// MarkArticleRead records that a user has read an article
+func MarkArticleRead(userID, articleID string) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	markers := make(map[string]map[string]time.Time)
+	if err := loadUserMap(readMarkersFile, &markers); err != nil {
+		return err
+	}
+	if markers[userID] == nil {
+		markers[userID] = make(map[string]time.Time)
+	}
+	markers[userID][articleID] = time.Now()
+	return saveUserMap(readMarkersFile, markers)
+}
+
+// GetReadMarkers returns the articles a user has read, keyed by article ID
+func GetReadMarkers(userID string) (map[string]time.Time, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	markers := make(map[string]map[string]time.Time)
+	if err := loadUserMap(readMarkersFile, &markers); err != nil {
+		return nil, err
+	}
+	return markers[userID], nil
+}
+
+// AddUserWarning records a moderation action against a user
+func AddUserWarning(userID string, warning UserWarning) error {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	warnings := make(map[string][]UserWarning)
+	if err := loadUserMap(userWarningsFile, &warnings); err != nil {
+		return err
+	}
+	warning.Time = time.Now()
+	warnings[userID] = append(warnings[userID], warning)
+	return saveUserMap(userWarningsFile, warnings)
+}
+
+// GetUserWarnings returns the moderation actions recorded against a user
+func GetUserWarnings(userID string) ([]UserWarning, error) {
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	warnings := make(map[string][]UserWarning)
+	if err := loadUserMap(userWarningsFile, &warnings); err != nil {
+		return nil, err
+	}
+	return warnings[userID], nil
+}
+
+// GetUserData gathers everything stored about a user from each per-user store
+func GetUserData(userID string) (*UserData, error) {
+	var err error
+	data := &UserData{}
+
+	if data.Filter, err = LoadUserFilter(userID); err != nil {
+		return nil, err
+	}
+	if data.Subscriptions, err = GetUserSubscriptions(userID); err != nil {
+		return nil, err
+	}
+	if data.Language, err = GetUserLanguage(userID); err != nil {
+		return nil, err
+	}
+	if data.TrackedKeywords, err = GetTrackedKeywords(userID); err != nil {
+		return nil, err
+	}
+	if data.ReadMarkers, err = GetReadMarkers(userID); err != nil {
+		return nil, err
+	}
+	if data.Warnings, err = GetUserWarnings(userID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// deleteUserKey removes a user's entry from a JSON store and reports whether one existed.
+// Caller must hold userDataMutex.
+func deleteUserKey(path, userID string) (bool, error) {
+	all := make(map[string]json.RawMessage)
+	if err := loadUserMap(path, &all); err != nil {
+		return false, err
+	}
+	if _, ok := all[userID]; !ok {
+		return false, nil
+	}
+	delete(all, userID)
+	return true, saveUserMap(path, all)
+}
+
+// DeleteUserData removes a user from every per-user store and returns the names
+// of the stores that held data. Warnings and the user's audit log entries are
+// kept when keepWarnings is set.
+func DeleteUserData(userID string, keepWarnings bool) ([]string, error) {
+	if !isUserID(userID) {
+		return nil, fmt.Errorf("invalid user ID %q", userID)
+	}
+
+	userDataMutex.Lock()
+	defer userDataMutex.Unlock()
+
+	var removed []string
+
+	err := os.Remove(userFilterPath(userID))
+	if err == nil {
+		removed = append(removed, "filters")
+	} else if !os.IsNotExist(err) {
+		return removed, fmt.Errorf("failed to delete user filter: %v", err)
+	}
+
+	type userStore struct{ name, path string }
+	stores := []userStore{
+		{"subscriptions", userSubscriptionsFile},
+		{"language preference", userLanguagesFile},
+		{"tracked keywords", trackedKeywordsFile},
+		{"read markers", readMarkersFile},
+	}
+	if !keepWarnings {
+		stores = append(stores, userStore{"warnings", userWarningsFile})
+	}
+
+	for _, store := range stores {
+		found, err := deleteUserKey(store.path, userID)
+		if err != nil {
+			return removed, fmt.Errorf("failed to delete %s: %v", store.name, err)
+		}
+		if found {
+			removed = append(removed, store.name)
+		}
+	}
+
+	if !keepWarnings {
+		deleted, err := auditTrail.Forget(userID)
+		if err != nil {
+			return removed, fmt.Errorf("failed to delete audit log entries: %v", err)
+		}
+		if deleted > 0 {
+			removed = append(removed, "audit log entries")
+		}
+	}
+
+	return removed, nil
+}
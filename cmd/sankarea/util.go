@@ -3,7 +3,10 @@ package main
 
 import (
     "encoding/json"
+    "fmt"
     "net/http"
+    "os"
+    "path/filepath"
     "runtime"
     "strings"
     "time"
@@ -15,6 +18,35 @@ const (
     TimeFormatFull = "2006-01-02 15:04:05 MST"
 )
 
+// writeFileAtomic writes data to a temp file in the same directory, syncs it
+// and renames it over path, so a crash never leaves a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+    tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+    if err != nil {
+        return fmt.Errorf("failed to create temp file: %v", err)
+    }
+    defer os.Remove(tmp.Name()) // no-op once renamed
+
+    if _, err := tmp.Write(data); err != nil {
+        tmp.Close()
+        return fmt.Errorf("failed to write temp file: %v", err)
+    }
+    if err := tmp.Sync(); err != nil {
+        tmp.Close()
+        return fmt.Errorf("failed to sync temp file: %v", err)
+    }
+    if err := tmp.Close(); err != nil {
+        return fmt.Errorf("failed to close temp file: %v", err)
+    }
+    if err := os.Chmod(tmp.Name(), perm); err != nil {
+        return fmt.Errorf("failed to set file permissions: %v", err)
+    }
+    if err := os.Rename(tmp.Name(), path); err != nil {
+        return fmt.Errorf("failed to replace %s: %v", path, err)
+    }
+    return nil
+}
+
 // HTTP Response Helpers
 func respondWithHTTPError(w http.ResponseWriter, code int, message string) {
     respondWithJSON(w, code, map[string]string{"error": message})
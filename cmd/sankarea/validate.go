@@ -0,0 +1,300 @@
+// cmd/sankarea/validate.go
+package main
+
+import (
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+
+    "github.com/bwmarrin/discordgo"
+    "github.com/robfig/cron/v3"
+)
+
+// Validation outcomes, worst last
+const (
+    ValidationPass = iota
+    ValidationWarn
+    ValidationFail
+)
+
+// validationCheck is one line of a /validate report
+type validationCheck struct {
+    Section string
+    Level   int
+    Detail  string
+}
+
+// validationSections orders the report
+var validationSections = []string{"Schedules", "Channels", "API keys", "Sources", "Categories"}
+
+// postPermissions are what the bot needs in every channel it posts to
+const postPermissions = discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionEmbedLinks
+
+// ValidateCronExpression checks a schedule the way cronManager parses it
+func ValidateCronExpression(expr string) error {
+    if strings.TrimSpace(expr) == "" {
+        return fmt.Errorf("empty schedule")
+    }
+    if _, err := cron.ParseStandard(expr); err != nil {
+        return fmt.Errorf("invalid cron expression %q: %v", expr, err)
+    }
+    return nil
+}
+
+// validationReport collects checks as they run
+type validationReport []validationCheck
+
+// add records one check
+func (r *validationReport) add(section string, level int, format string, args ...interface{}) {
+    *r = append(*r, validationCheck{Section: section, Level: level, Detail: fmt.Sprintf(format, args...)})
+}
+
+// validateSetup checks the loaded config and sources without changing anything
+func (b *Bot) validateSetup(s *discordgo.Session) validationReport {
+    var report validationReport
+    b.validateSchedules(&report)
+    b.validateChannels(s, &report)
+    validateAPIKeys(&report)
+    b.validateSources(&report)
+    return report
+}
+
+// validateSchedules parses every cron schedule the bot registers
+func (b *Bot) validateSchedules(report *validationReport) {
+    schedules := []struct {
+        name, expr string
+        enabled    bool
+    }{
+        {"cleanup_schedule", b.config.CleanupSchedule, true},
+        {"vacuum_schedule", b.config.VacuumSchedule, true},
+        {"feed_validation_schedule", b.config.FeedValidationSchedule, true},
+        {"csv_export.schedule", b.config.CSVExport.Schedule, b.config.CSVExport.Enabled},
+        {"source_leaderboard.schedule", b.config.SourceLeaderboard.Schedule, b.config.SourceLeaderboard.Enabled},
+    }
+    for _, schedule := range schedules {
+        if !schedule.enabled {
+            continue
+        }
+        if err := ValidateCronExpression(schedule.expr); err != nil {
+            report.add("Schedules", ValidationFail, "`%s`: %v", schedule.name, err)
+            continue
+        }
+        report.add("Schedules", ValidationPass, "`%s`", schedule.name)
+    }
+}
+
+// validateChannels checks that every configured channel exists and that
+// the bot can post embeds there
+func (b *Bot) validateChannels(s *discordgo.Session, report *validationReport) {
+    channels := make(map[string]string) // channel ID -> config key
+    for category, channelID := range b.config.CategoryChannels {
+        channels[channelID] = fmt.Sprintf("category_channels.%s", category)
+    }
+    if b.config.BreakingNews.Enabled {
+        channels[b.config.BreakingNews.ChannelID] = "breaking_news.channel_id"
+    }
+    if b.config.SourceLeaderboard.Enabled {
+        channels[b.config.SourceLeaderboard.ChannelID] = "source_leaderboard.channel_id"
+    }
+    if cfg != nil && cfg.ErrorChannelID != "" {
+        channels[cfg.ErrorChannelID] = "error_channel_id"
+    }
+    if cfg != nil && cfg.AuditLogChannelID != "" {
+        channels[cfg.AuditLogChannelID] = "audit_log_channel_id"
+    }
+
+    if len(b.config.CategoryChannels) == 0 {
+        report.add("Channels", ValidationFail, "No `category_channels` configured; nothing will be posted")
+    }
+    for channelID, key := range channels {
+        if channelID == "" {
+            report.add("Channels", ValidationFail, "`%s` is empty", key)
+            continue
+        }
+        channel, err := s.Channel(channelID)
+        if err != nil {
+            report.add("Channels", ValidationFail, "`%s`: channel %s not found", key, channelID)
+            continue
+        }
+        perms, err := s.UserChannelPermissions(s.State.User.ID, channelID)
+        if err != nil {
+            report.add("Channels", ValidationWarn, "`%s`: couldn't read permissions in #%s: %v", key, channel.Name, err)
+            continue
+        }
+        if perms&postPermissions != postPermissions {
+            report.add("Channels", ValidationFail, "`%s`: missing View, Send or Embed Links in #%s", key, channel.Name)
+            continue
+        }
+        report.add("Channels", ValidationPass, "`%s` → #%s", key, channel.Name)
+    }
+}
+
+// validateAPIKeys checks that features which call an API have its key
+func validateAPIKeys(report *validationReport) {
+    if cfg == nil {
+        report.add("API keys", ValidationFail, "No configuration loaded")
+        return
+    }
+
+    needsOpenAI := map[string]bool{
+        "`auto_categorize`":       cfg.AutoCategorize,
+        "`auto_detect_sensitive`": cfg.AutoDetectSensitive,
+        "`ai.summarizer: openai`": cfg.AI.Summarizer == SummarizerOpenAI,
+    }
+    for feature, enabled := range needsOpenAI {
+        switch {
+        case !enabled:
+        case cfg.OpenAIAPIKey == "":
+            report.add("API keys", ValidationFail, "%s needs `openai_api_key`", feature)
+        default:
+            report.add("API keys", ValidationPass, "%s has an OpenAI key", feature)
+        }
+    }
+
+    if cfg.EnableFactCheck && cfg.GoogleFactCheckAPIKey == "" && cfg.ClaimBustersAPIKey == "" {
+        report.add("API keys", ValidationWarn, "`enable_fact_check` has no `google_fact_check_api_key` or `claimbusters_api_key`; only heuristics run")
+    } else if cfg.EnableFactCheck {
+        report.add("API keys", ValidationPass, "Fact checking has an API key")
+    }
+    if cfg.DashboardEnabled && cfg.WebhookSecret == "" {
+        report.add("API keys", ValidationWarn, "Dashboard enabled without `webhook_secret`; the refresh webhook is off")
+    }
+}
+
+// validateSources checks every source's URL and category, and that each
+// category has a channel to post to
+func (b *Bot) validateSources(report *validationReport) {
+    sources := b.scheduler.GetSources()
+    if len(sources) == 0 {
+        report.add("Sources", ValidationFail, "No sources configured")
+    }
+
+    seen := make(map[string]bool)
+    for _, src := range sources {
+        if seen[strings.ToLower(src.Name)] {
+            report.add("Sources", ValidationFail, "**%s** is listed more than once", src.Name)
+        }
+        seen[strings.ToLower(src.Name)] = true
+
+        u, err := url.Parse(src.URL)
+        switch {
+        case err != nil || u.Host == "":
+            report.add("Sources", ValidationFail, "**%s**: malformed URL `%s`", src.Name, src.URL)
+        case u.Scheme != "http" && u.Scheme != "https":
+            report.add("Sources", ValidationFail, "**%s**: URL scheme must be http or https", src.Name)
+        default:
+            report.add("Sources", ValidationPass, "**%s**", src.Name)
+        }
+
+        if isUncategorized(src.Category) {
+            continue
+        }
+        category := normalizeCategory(src.Category)
+        switch {
+        case category == "":
+            report.add("Categories", ValidationWarn, "**%s**: unknown category `%s`; add it to `category_aliases`", src.Name, src.Category)
+        case !src.Paused && !hasCategoryChannel(b.config.CategoryChannels, category):
+            report.add("Categories", ValidationWarn, "**%s**: no channel for category `%s`", src.Name, category)
+        default:
+            report.add("Categories", ValidationPass, "**%s** → `%s`", src.Name, category)
+        }
+    }
+
+    for category := range b.config.CategoryChannels {
+        if normalizeCategory(category) == "" {
+            report.add("Categories", ValidationWarn, "`category_channels` key `%s` is not a known category", category)
+        }
+    }
+    if cfg != nil {
+        if err := ValidateCategoryAliases(cfg.CategoryAliases); err != nil {
+            report.add("Categories", ValidationFail, "`category_aliases`: %v", err)
+        }
+    }
+}
+
+// hasCategoryChannel reports whether a canonical category routes to a channel
+func hasCategoryChannel(channels map[string]string, category string) bool {
+    for key, channelID := range channels {
+        if channelID != "" && normalizeCategory(key) == category {
+            return true
+        }
+    }
+    return false
+}
+
+// validationEmbed renders a report with a field per section. Passing
+// checks are counted; warnings and failures are listed.
+func validationEmbed(report validationReport) *discordgo.MessageEmbed {
+    counts := make(map[int]int)
+    var fields []*discordgo.MessageEmbedField
+    for _, section := range validationSections {
+        var lines []string
+        passed := 0
+        for _, check := range report {
+            if check.Section != section {
+                continue
+            }
+            counts[check.Level]++
+            switch check.Level {
+            case ValidationPass:
+                passed++
+            case ValidationWarn:
+                lines = append(lines, "⚠️ "+check.Detail)
+            case ValidationFail:
+                lines = append(lines, "❌ "+check.Detail)
+            }
+        }
+        if passed > 0 {
+            lines = append([]string{fmt.Sprintf("✅ %d passed", passed)}, lines...)
+        }
+        if len(lines) == 0 {
+            lines = []string{"⏭️ Nothing to check"}
+        }
+        fields = append(fields, &discordgo.MessageEmbedField{
+            Name:  section,
+            Value: truncateString(strings.Join(lines, "\n"), 1024),
+        })
+    }
+
+    embed := &discordgo.MessageEmbed{
+        Title:  "🧪 Configuration valid",
+        Color:  0x43B581,
+        Fields: fields,
+        Footer: &discordgo.MessageEmbedFooter{
+            Text: fmt.Sprintf("%d passed · %d warnings · %d failures", counts[ValidationPass], counts[ValidationWarn], counts[ValidationFail]),
+        },
+        Timestamp: time.Now().Format(time.RFC3339),
+    }
+    switch {
+    case counts[ValidationFail] > 0:
+        embed.Title = "🧪 Configuration has errors"
+        embed.Color = 0xF04747
+    case counts[ValidationWarn] > 0:
+        embed.Title = "🧪 Configuration valid, with warnings"
+        embed.Color = 0xFAA61A
+    }
+    return embed
+}
+
+// handleValidateCommand checks the config and sources and reports pass,
+// warn and fail results by section
+func (b *Bot) handleValidateCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+    if !b.isAdmin(i) {
+        respondWithError(s, i, "You need administrator permissions to use this command")
+        return nil
+    }
+
+    err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+        Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+        Data: &discordgo.InteractionResponseData{
+            Flags: discordgo.MessageFlagsEphemeral,
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("failed to acknowledge interaction: %v", err)
+    }
+
+    editResponseWithEmbed(s, i, validationEmbed(b.validateSetup(s)))
+    return nil
+}
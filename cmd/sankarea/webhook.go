@@ -0,0 +1,112 @@
+// cmd/sankarea/webhook.go
+package main
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "net/http"
+    "strconv"
+    "strings"
+    "sync/atomic"
+    "time"
+)
+
+// Headers carrying an inbound webhook's signature and signing time
+const (
+    webhookSignatureHeader = "X-Sankarea-Signature"
+    webhookTimestampHeader = "X-Sankarea-Timestamp"
+)
+
+// webhookMaxSkew rejects signed requests older or newer than this, so a
+// captured request can't be replayed later
+const webhookMaxSkew = 5 * time.Minute
+
+// webhookMaxBody caps how much of a request body is read and signed
+const webhookMaxBody = 64 * 1024
+
+// webhookSignature returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func webhookSignature(secret, timestamp string, body []byte) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(timestamp + "."))
+    mac.Write(body)
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyWebhookRequest checks a request's timestamp and "sha256=<hex>"
+// signature against the secret
+func verifyWebhookRequest(secret string, header http.Header, body []byte, now time.Time) error {
+    timestamp := header.Get(webhookTimestampHeader)
+    unix, err := strconv.ParseInt(timestamp, 10, 64)
+    if err != nil {
+        return fmt.Errorf("missing or invalid %s header", webhookTimestampHeader)
+    }
+    if skew := now.Sub(time.Unix(unix, 0)); skew > webhookMaxSkew || skew < -webhookMaxSkew {
+        return fmt.Errorf("timestamp outside the allowed %s window", webhookMaxSkew)
+    }
+
+    signature := strings.TrimPrefix(header.Get(webhookSignatureHeader), "sha256=")
+    if signature == "" {
+        return fmt.Errorf("missing %s header", webhookSignatureHeader)
+    }
+    expected := webhookSignature(secret, timestamp, body)
+    if !hmac.Equal([]byte(signature), []byte(expected)) {
+        return fmt.Errorf("signature mismatch")
+    }
+    return nil
+}
+
+// webhookRefreshRunning keeps webhook refreshes from piling up
+var webhookRefreshRunning int32
+
+// handleWebhookRefresh starts a feed refresh for a correctly signed POST.
+// The endpoint doesn't exist unless webhook_secret is set.
+func (d *Dashboard) handleWebhookRefresh(w http.ResponseWriter, r *http.Request) {
+    if cfg.WebhookSecret == "" {
+        http.NotFound(w, r)
+        return
+    }
+    if r.Method != http.MethodPost {
+        w.Header().Set("Allow", http.MethodPost)
+        http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+
+    body, err := io.ReadAll(io.LimitReader(r.Body, webhookMaxBody))
+    if err != nil {
+        http.Error(w, "Failed to read body", http.StatusBadRequest)
+        return
+    }
+    if err := verifyWebhookRequest(cfg.WebhookSecret, r.Header, body, time.Now()); err != nil {
+        Logger().Printf("Rejected webhook refresh from %s: %v", r.RemoteAddr, err)
+        http.Error(w, "Unauthorized", http.StatusUnauthorized)
+        return
+    }
+
+    d.mutex.RLock()
+    refresh := d.refresh
+    d.mutex.RUnlock()
+    if refresh == nil {
+        http.Error(w, "Refresh unavailable", http.StatusServiceUnavailable)
+        return
+    }
+    if !atomic.CompareAndSwapInt32(&webhookRefreshRunning, 0, 1) {
+        http.Error(w, "Refresh already running", http.StatusConflict)
+        return
+    }
+
+    // A full refresh outlasts the server's write timeout, so it runs detached
+    go func() {
+        defer atomic.StoreInt32(&webhookRefreshRunning, 0)
+        if err := refresh(); err != nil {
+            Logger().Printf("Webhook refresh failed: %v", err)
+            return
+        }
+        Logger().Printf("Webhook refresh finished")
+    }()
+
+    Logger().Printf("Webhook refresh triggered from %s", r.RemoteAddr)
+    w.WriteHeader(http.StatusAccepted)
+}
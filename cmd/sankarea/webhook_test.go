@@ -0,0 +1,103 @@
+// cmd/sankarea/webhook_test.go
+package main
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "strconv"
+    "strings"
+    "testing"
+    "time"
+)
+
+// signedHeader returns the headers of a request signed with secret at the given time
+func signedHeader(secret string, at time.Time, body []byte) http.Header {
+    timestamp := strconv.FormatInt(at.Unix(), 10)
+    header := http.Header{}
+    header.Set(webhookTimestampHeader, timestamp)
+    header.Set(webhookSignatureHeader, "sha256="+webhookSignature(secret, timestamp, body))
+    return header
+}
+
+func TestVerifyWebhookRequest(t *testing.T) {
+    const secret = "s3cret"
+    now := time.Unix(1700000000, 0)
+    body := []byte(`{"action":"refresh"}`)
+
+    tests := []struct {
+        name   string
+        header func() http.Header
+        ok     bool
+    }{
+        {"valid", func() http.Header { return signedHeader(secret, now, body) }, true},
+        {"valid within the skew", func() http.Header { return signedHeader(secret, now.Add(-webhookMaxSkew+time.Second), body) }, true},
+        {"missing headers", func() http.Header { return http.Header{} }, false},
+        {"missing signature", func() http.Header {
+            header := signedHeader(secret, now, body)
+            header.Del(webhookSignatureHeader)
+            return header
+        }, false},
+        {"bad timestamp", func() http.Header {
+            header := signedHeader(secret, now, body)
+            header.Set(webhookTimestampHeader, "yesterday")
+            return header
+        }, false},
+        {"wrong secret", func() http.Header { return signedHeader("other", now, body) }, false},
+        {"signed for another body", func() http.Header { return signedHeader(secret, now, []byte(`{}`)) }, false},
+        {"too old", func() http.Header { return signedHeader(secret, now.Add(-webhookMaxSkew-time.Second), body) }, false},
+        {"too far ahead", func() http.Header { return signedHeader(secret, now.Add(webhookMaxSkew+time.Second), body) }, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := verifyWebhookRequest(secret, tt.header(), body, now)
+            if (err == nil) != tt.ok {
+                t.Errorf("verifyWebhookRequest = %v, want ok %v", err, tt.ok)
+            }
+        })
+    }
+}
+
+// The refresh webhook must be on the mux the started dashboard serves
+func TestWebhookRefreshRoute(t *testing.T) {
+    saved := cfg
+    cfg = &Config{WebhookSecret: "s3cret"}
+    t.Cleanup(func() { cfg = saved })
+
+    refreshed := make(chan bool, 1)
+    d := &Dashboard{}
+    d.SetRefreshFunc(func() error {
+        refreshed <- true
+        return nil
+    })
+    server := httptest.NewServer(d.routes())
+    defer server.Close()
+
+    body := `{"action":"refresh"}`
+    post := func(header http.Header) int {
+        req, err := http.NewRequest(http.MethodPost, server.URL+"/api/webhook/refresh", strings.NewReader(body))
+        if err != nil {
+            t.Fatal(err)
+        }
+        for key, values := range header {
+            req.Header[key] = values
+        }
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            t.Fatal(err)
+        }
+        resp.Body.Close()
+        return resp.StatusCode
+    }
+
+    if code := post(http.Header{}); code != http.StatusUnauthorized {
+        t.Errorf("unsigned request got %d, want %d", code, http.StatusUnauthorized)
+    }
+    if code := post(signedHeader("s3cret", time.Now(), []byte(body))); code != http.StatusAccepted {
+        t.Fatalf("signed request got %d, want %d", code, http.StatusAccepted)
+    }
+    select {
+    case <-refreshed:
+    case <-time.After(5 * time.Second):
+        t.Error("signed request didn't run the refresh")
+    }
+}